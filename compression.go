@@ -0,0 +1,224 @@
+// Copyright 2021 Airbus Defence and Space
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CompressionAlg is a compression codec for GTiff and COG outputs
+type CompressionAlg string
+
+const (
+	// CompressNone disables compression
+	CompressNone CompressionAlg = "NONE"
+	// CompressDeflate is zlib/libdeflate compression
+	CompressDeflate CompressionAlg = "DEFLATE"
+	// CompressLZW is LZW compression
+	CompressLZW CompressionAlg = "LZW"
+	// CompressZSTD is Zstandard compression
+	CompressZSTD CompressionAlg = "ZSTD"
+	// CompressWEBP is lossy or lossless WebP compression, for Byte datasets
+	CompressWEBP CompressionAlg = "WEBP"
+	// CompressJPEG is lossy JPEG compression
+	CompressJPEG CompressionAlg = "JPEG"
+	// CompressLERC is Limited Error Raster Compression
+	CompressLERC CompressionAlg = "LERC"
+)
+
+// CompressionParam is a parameter passed to Compression()
+//
+// Available CompressionParams are:
+//   - Predictor
+//   - CompressionLevel
+//   - CompressionThreads
+type CompressionParam interface {
+	setCompressionParam(co *compressionOpt)
+}
+
+type compressionOpt struct {
+	alg       CompressionAlg
+	predictor int
+	level     int
+	threads   *int
+}
+
+type predictorParam struct{ p int }
+
+func (pp predictorParam) setCompressionParam(co *compressionOpt) {
+	co.predictor = pp.p
+}
+
+// Predictor sets the predictor used by the DEFLATE, LZW and ZSTD codecs: 1 for none,
+// 2 for horizontal differencing and 3 for floating point prediction.
+func Predictor(p int) CompressionParam {
+	return predictorParam{p}
+}
+
+type levelParam struct{ l int }
+
+func (lp levelParam) setCompressionParam(co *compressionOpt) {
+	co.level = lp.l
+}
+
+// CompressionLevel sets the compression level (ZLEVEL for DEFLATE, ZSTD_LEVEL for ZSTD)
+// or the quality (WEBP_LEVEL for WEBP, JPEG_QUALITY for JPEG) of the codec. The COG
+// driver's LEVEL and QUALITY creation options are used instead for COG outputs.
+func CompressionLevel(l int) CompressionParam {
+	return levelParam{l}
+}
+
+type threadsParam struct{ n int }
+
+func (tp threadsParam) setCompressionParam(co *compressionOpt) {
+	n := tp.n
+	co.threads = &n
+}
+
+// CompressionThreads sets the number of threads used for compression. n<=0 means
+// using all available CPUs.
+func CompressionThreads(n int) CompressionParam {
+	return threadsParam{n}
+}
+
+// Compression is an option to set the compression of GTiff or COG outputs. It results
+// in the corresponding COMPRESS, PREDICTOR, level and NUM_THREADS creation options of
+// the output driver, i.e. the one passed to Create or set with a DriverName option or
+// an "-of" switch for Translate and Warp (defaulting to GTiff).
+//
+// The parameters are validated, and the codec checked to be supported by the output
+// driver of the running gdal library, before the actual Create, Translate or Warp call,
+// which will fail if that is not the case.
+func Compression(alg CompressionAlg, params ...CompressionParam) interface {
+	DatasetCreateOption
+	DatasetTranslateOption
+	DatasetWarpOption
+} {
+	co := compressionOpt{alg: alg}
+	for _, p := range params {
+		p.setCompressionParam(&co)
+	}
+	return co
+}
+
+func (co compressionOpt) setDatasetCreateOpt(o *dsCreateOpts) {
+	o.compression = &co
+}
+func (co compressionOpt) setDatasetTranslateOpt(o *dsTranslateOpts) {
+	o.compression = &co
+}
+func (co compressionOpt) setDatasetWarpOpt(o *dsWarpOpts) {
+	o.compression = &co
+}
+
+// creationOptions validates the compression parameters against the given output
+// driver ("GTiff" or "COG") and returns the corresponding creation options
+func (co compressionOpt) creationOptions(driver string) ([]string, error) {
+	cog := false
+	switch {
+	case strings.EqualFold(driver, "GTiff"):
+	case strings.EqualFold(driver, "COG"):
+		cog = true
+	default:
+		return nil, fmt.Errorf("compression is not supported by the %s driver", driver)
+	}
+	levelKey := ""
+	minLevel, maxLevel := 0, 0
+	predictor := false
+	switch co.alg {
+	case CompressNone, CompressLERC:
+	case CompressLZW:
+		predictor = true
+	case CompressDeflate:
+		levelKey, minLevel, maxLevel = "ZLEVEL", 1, 12
+		predictor = true
+	case CompressZSTD:
+		levelKey, minLevel, maxLevel = "ZSTD_LEVEL", 1, 22
+		predictor = true
+	case CompressWEBP:
+		levelKey, minLevel, maxLevel = "WEBP_LEVEL", 1, 100
+	case CompressJPEG:
+		levelKey, minLevel, maxLevel = "JPEG_QUALITY", 1, 100
+	default:
+		return nil, fmt.Errorf("unknown compression %s", co.alg)
+	}
+	if cog && levelKey != "" {
+		//the COG driver uses generic keys for all codecs
+		if co.alg == CompressWEBP || co.alg == CompressJPEG {
+			levelKey = "QUALITY"
+		} else {
+			levelKey = "LEVEL"
+		}
+	}
+	opts := []string{"COMPRESS=" + string(co.alg)}
+	if co.predictor != 0 {
+		if !predictor {
+			return nil, fmt.Errorf("predictor is not supported by %s compression", co.alg)
+		}
+		if co.predictor < 1 || co.predictor > 3 {
+			return nil, fmt.Errorf("invalid predictor %d", co.predictor)
+		}
+		if cog {
+			opts = append(opts, "PREDICTOR="+[]string{"NO", "STANDARD", "FLOATING_POINT"}[co.predictor-1])
+		} else {
+			opts = append(opts, "PREDICTOR="+strconv.Itoa(co.predictor))
+		}
+	}
+	if co.level != 0 {
+		if levelKey == "" {
+			return nil, fmt.Errorf("compression level is not supported by %s compression", co.alg)
+		}
+		if co.level < minLevel || co.level > maxLevel {
+			return nil, fmt.Errorf("invalid %s level %d, must be in [%d,%d]", co.alg, co.level, minLevel, maxLevel)
+		}
+		opts = append(opts, levelKey+"="+strconv.Itoa(co.level))
+	}
+	if co.threads != nil {
+		if *co.threads <= 0 {
+			opts = append(opts, "NUM_THREADS=ALL_CPUS")
+		} else {
+			opts = append(opts, "NUM_THREADS="+strconv.Itoa(*co.threads))
+		}
+	}
+	if co.alg != CompressNone {
+		drv, ok := getDriver(driver)
+		if !ok {
+			return nil, fmt.Errorf("%s driver is not registered", driver)
+		}
+		if !strings.Contains(drv.Metadata("DMD_CREATIONOPTIONLIST"), "<Value>"+string(co.alg)+"</Value>") {
+			return nil, fmt.Errorf("%s compression is not supported by this gdal build", co.alg)
+		}
+	}
+	return opts, nil
+}
+
+// outputDriverName returns the name of the driver used by a Translate or Warp call,
+// i.e. driver if set, or the value of the -of switch, defaulting to GTiff
+func outputDriverName(driver DriverName, switches []string) string {
+	if driver != "" {
+		if dm, ok := driverMappings[driver]; ok {
+			return dm.rasterName
+		}
+		return string(driver)
+	}
+	for i := 0; i < len(switches)-1; i++ {
+		if switches[i] == "-of" {
+			return switches[i+1]
+		}
+	}
+	return "GTiff"
+}
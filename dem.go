@@ -0,0 +1,219 @@
+// Copyright 2021 Airbus Defence and Space
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godal
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DemAlgorithm is the algorithm used to compute the slope, aspect and hillshade of a DEM
+type DemAlgorithm int
+
+const (
+	// Horn is the default gdaldem algorithm, better suited for rough terrain
+	Horn DemAlgorithm = iota
+	// ZevenbergenThorne is better suited for smooth landscapes
+	ZevenbergenThorne
+)
+
+func (a DemAlgorithm) demName() (string, error) {
+	switch a {
+	case Horn:
+		return "Horn", nil
+	case ZevenbergenThorne:
+		return "ZevenbergenThorne", nil
+	default:
+		return "", fmt.Errorf("unknown dem algorithm %d", a)
+	}
+}
+
+// HillshadeParams are the parameters of Dataset.Hillshade. Zero valued (or nil) fields
+// use the gdaldem defaults.
+type HillshadeParams struct {
+	// ZFactor is the vertical exaggeration (defaults to 1)
+	ZFactor float64
+	// Scale is the ratio of vertical units to horizontal units, e.g. 111120 for a DEM
+	// in meters with horizontal coordinates in degrees (defaults to 1)
+	Scale float64
+	// Azimuth is the azimuth of the light, in degrees (defaults to 315)
+	Azimuth *float64
+	// Altitude is the altitude of the light, in degrees (defaults to 45)
+	Altitude *float64
+	// Algorithm is the slope algorithm
+	Algorithm DemAlgorithm
+	// Combined computes a combination of slope and oblique shading
+	Combined bool
+	// Multidirectional computes a multidirectional shading. Cannot be used together with
+	// Combined or Azimuth.
+	Multidirectional bool
+	// ComputeEdges computes values at the edges of the raster instead of setting them to nodata
+	ComputeEdges bool
+}
+
+func (p HillshadeParams) switches() ([]string, error) {
+	if p.Multidirectional && (p.Combined || p.Azimuth != nil) {
+		return nil, fmt.Errorf("Multidirectional hillshading cannot be used with Combined or Azimuth")
+	}
+	sw := demSwitches{}
+	sw.float("-z", p.ZFactor)
+	sw.float("-s", p.Scale)
+	if p.Azimuth != nil {
+		sw.value("-az", *p.Azimuth)
+	}
+	if p.Altitude != nil {
+		sw.value("-alt", *p.Altitude)
+	}
+	if err := sw.alg(p.Algorithm); err != nil {
+		return nil, err
+	}
+	sw.flag("-combined", p.Combined)
+	sw.flag("-multidirectional", p.Multidirectional)
+	sw.flag("-compute_edges", p.ComputeEdges)
+	return sw, nil
+}
+
+// SlopeParams are the parameters of Dataset.Slope. Zero valued fields use the gdaldem
+// defaults.
+type SlopeParams struct {
+	// Scale is the ratio of vertical units to horizontal units (defaults to 1)
+	Scale float64
+	// Percent expresses the slope as a percentage instead of degrees
+	Percent bool
+	// Algorithm is the slope algorithm
+	Algorithm DemAlgorithm
+	// ComputeEdges computes values at the edges of the raster instead of setting them to nodata
+	ComputeEdges bool
+}
+
+// AspectParams are the parameters of Dataset.Aspect.
+type AspectParams struct {
+	// Trigonometric returns the trigonometric angle (0° is east, counterclockwise)
+	// instead of the azimuth (0° is north, clockwise)
+	Trigonometric bool
+	// ZeroForFlat returns 0 for flat areas instead of the nodata value
+	ZeroForFlat bool
+	// Algorithm is the slope algorithm
+	Algorithm DemAlgorithm
+	// ComputeEdges computes values at the edges of the raster instead of setting them to nodata
+	ComputeEdges bool
+}
+
+// ColorReliefParams are the parameters of Dataset.ColorRelief.
+type ColorReliefParams struct {
+	// Alpha adds an alpha band to the output
+	Alpha bool
+	// ExactColorEntry uses strict matching of the elevations in the color file, pixels not
+	// matching any entry are set to black (or transparent)
+	ExactColorEntry bool
+	// NearestColorEntry uses the color of the nearest entry of the color file instead of
+	// interpolating between entries
+	NearestColorEntry bool
+}
+
+type demSwitches []string
+
+func (sw *demSwitches) float(key string, v float64) {
+	if v != 0 {
+		sw.value(key, v)
+	}
+}
+func (sw *demSwitches) value(key string, v float64) {
+	*sw = append(*sw, key, strconv.FormatFloat(v, 'g', -1, 64))
+}
+func (sw *demSwitches) flag(key string, v bool) {
+	if v {
+		*sw = append(*sw, key)
+	}
+}
+func (sw *demSwitches) alg(a DemAlgorithm) error {
+	name, err := a.demName()
+	if err != nil {
+		return err
+	}
+	if a != Horn {
+		*sw = append(*sw, "-alg", name)
+	}
+	return nil
+}
+
+// Hillshade computes a shaded relief map of the DEM into dstPath. It is a typed
+// equivalent of calling Dem with the "hillshade" mode.
+func (ds *Dataset) Hillshade(dstPath string, params HillshadeParams, opts ...DemOption) (*Dataset, error) {
+	sw, err := params.switches()
+	if err != nil {
+		return nil, err
+	}
+	return ds.Dem(dstPath, "hillshade", "", sw, opts...)
+}
+
+// Slope computes a slope map of the DEM into dstPath. It is a typed equivalent of
+// calling Dem with the "slope" mode.
+func (ds *Dataset) Slope(dstPath string, params SlopeParams, opts ...DemOption) (*Dataset, error) {
+	sw := demSwitches{}
+	sw.float("-s", params.Scale)
+	sw.flag("-p", params.Percent)
+	if err := sw.alg(params.Algorithm); err != nil {
+		return nil, err
+	}
+	sw.flag("-compute_edges", params.ComputeEdges)
+	return ds.Dem(dstPath, "slope", "", sw, opts...)
+}
+
+// Aspect computes an aspect map of the DEM into dstPath, i.e. the azimuth that slopes
+// are facing. It is a typed equivalent of calling Dem with the "aspect" mode.
+func (ds *Dataset) Aspect(dstPath string, params AspectParams, opts ...DemOption) (*Dataset, error) {
+	sw := demSwitches{}
+	sw.flag("-trigonometric", params.Trigonometric)
+	sw.flag("-zero_for_flat", params.ZeroForFlat)
+	if err := sw.alg(params.Algorithm); err != nil {
+		return nil, err
+	}
+	sw.flag("-compute_edges", params.ComputeEdges)
+	return ds.Dem(dstPath, "aspect", "", sw, opts...)
+}
+
+// ColorRelief computes a color relief map of the DEM into dstPath, using the elevation
+// to color mapping contained in the colorFile text file (see the gdaldem documentation
+// for its format). It is a typed equivalent of calling Dem with the "color-relief" mode.
+func (ds *Dataset) ColorRelief(dstPath, colorFile string, params ColorReliefParams, opts ...DemOption) (*Dataset, error) {
+	if colorFile == "" {
+		return nil, fmt.Errorf("a color file is required")
+	}
+	if params.ExactColorEntry && params.NearestColorEntry {
+		return nil, fmt.Errorf("ExactColorEntry and NearestColorEntry are mutually exclusive")
+	}
+	sw := demSwitches{}
+	sw.flag("-alpha", params.Alpha)
+	sw.flag("-exact_color_entry", params.ExactColorEntry)
+	sw.flag("-nearest_color_entry", params.NearestColorEntry)
+	return ds.Dem(dstPath, "color-relief", colorFile, sw, opts...)
+}
+
+// TRI computes the Terrain Ruggedness Index of the DEM into dstPath.
+func (ds *Dataset) TRI(dstPath string, opts ...DemOption) (*Dataset, error) {
+	return ds.Dem(dstPath, "TRI", "", nil, opts...)
+}
+
+// TPI computes the Topographic Position Index of the DEM into dstPath.
+func (ds *Dataset) TPI(dstPath string, opts ...DemOption) (*Dataset, error) {
+	return ds.Dem(dstPath, "TPI", "", nil, opts...)
+}
+
+// Roughness computes the roughness of the DEM into dstPath, i.e. the largest elevation
+// difference between a pixel and its neighbors.
+func (ds *Dataset) Roughness(dstPath string, opts ...DemOption) (*Dataset, error) {
+	return ds.Dem(dstPath, "roughness", "", nil, opts...)
+}
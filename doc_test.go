@@ -47,7 +47,7 @@ func ExampleBand_IO() {
 	bands[0].Write(0, 0, buf, 200, 200)
 
 	//add a mask band to the dataset.
-	maskBnd, _ := ds.CreateMaskBand(0x02, godal.ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"))
+	maskBnd, _ := ds.CreateMaskBand(godal.GMF_PER_DATASET, godal.ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"))
 
 	//we now want to populate the mask data. we will do this block by block to optimize data access
 	structure := bands[0].Structure()
@@ -14,6 +14,8 @@
 
 package godal
 
+import "strings"
+
 //DriverName is GDAL driver
 type DriverName string
 
@@ -124,6 +126,22 @@ func (dn DriverName) setRasterizeOpt(to *rasterizeOpts) {
 	to.driver = dn
 }
 
+func (dn DriverName) setRetileOpt(to *retileOpts) {
+	to.driver = dn
+}
+
+func (dn DriverName) setSplitBandsOpt(so *splitBandsOpts) {
+	so.driver = dn
+}
+
+func (dn DriverName) setExpandPaletteOpt(eo *expandPaletteOpts) {
+	eo.driver = dn
+}
+
+func (dn DriverName) setDemOpt(do *demOpts) {
+	do.driver = dn
+}
+
 type driversOpt struct {
 	drivers []string
 }
@@ -131,12 +149,41 @@ type driversOpt struct {
 //Drivers specifies the list of drivers that are allowed to try opening the dataset
 func Drivers(drivers ...string) interface {
 	OpenOption
+	IdentifyDriverOption
 } {
 	return driversOpt{drivers}
 }
 func (do driversOpt) setOpenOpt(oo *openOpts) {
 	oo.drivers = append(oo.drivers, do.drivers...)
 }
+func (do driversOpt) setIdentifyDriverOpt(ido *identifyDriverOpts) {
+	ido.drivers = append(ido.drivers, do.drivers...)
+}
+
+type openedByOpt struct {
+	drivers []DriverName
+}
+
+// OpenedBy makes Open fail with a descriptive error (and close the dataset) if the dataset
+// was not opened by one of the given drivers. This differs from Drivers(), which only
+// restricts the drivers that are tried, in that the check is made on the driver that
+// actually opened the dataset. It should be used when opening untrusted files.
+func OpenedBy(drivers ...DriverName) interface {
+	OpenOption
+} {
+	return openedByOpt{drivers}
+}
+func (obo openedByOpt) setOpenOpt(oo *openOpts) {
+	oo.openedBy = append(oo.openedBy, obo.drivers...)
+}
+
+// matches returns whether the gdal driver shortName corresponds to dn
+func (dn DriverName) matches(shortName string) bool {
+	if dm, ok := driverMappings[dn]; ok {
+		return shortName == dm.rasterName || shortName == dm.vectorName
+	}
+	return shortName == string(dn)
+}
 
 type driverOpenOption struct {
 	oo []string
@@ -147,12 +194,52 @@ type driverOpenOption struct {
 func DriverOpenOption(keyval ...string) interface {
 	OpenOption
 	BuildVRTOption
+	NewReadViewOption
 } {
 	return driverOpenOption{keyval}
 }
+func (doo driverOpenOption) setNewReadViewOpt(o *newReadViewOpts) {
+	o.open = append(o.open, doo)
+}
 func (doo driverOpenOption) setOpenOpt(oo *openOpts) {
 	oo.options = append(oo.options, doo.oo...)
 }
 func (doo driverOpenOption) setBuildVRTOpt(bvo *buildVRTOpts) {
 	bvo.openOptions = append(bvo.openOptions, doo.oo...)
 }
+
+// DriverCapability is a driver capability, as advertised in the driver's metadata
+type DriverCapability string
+
+const (
+	// DCapRaster is set by drivers that support raster data
+	DCapRaster DriverCapability = "DCAP_RASTER"
+	// DCapVector is set by drivers that support vector data
+	DCapVector DriverCapability = "DCAP_VECTOR"
+	// DCapMultiDimRaster is set by drivers that support multidimensional raster data
+	DCapMultiDimRaster DriverCapability = "DCAP_MULTIDIM_RASTER"
+	// DCapOpen is set by drivers that can open existing datasets
+	DCapOpen DriverCapability = "DCAP_OPEN"
+	// DCapCreate is set by drivers that can create new datasets (i.e. support Create)
+	DCapCreate DriverCapability = "DCAP_CREATE"
+	// DCapCreateCopy is set by drivers that can create datasets from an existing one
+	// (i.e. are usable as the output of Translate)
+	DCapCreateCopy DriverCapability = "DCAP_CREATECOPY"
+	// DCapVirtualIO is set by drivers that can read/write from /vsi virtual filesystems
+	DCapVirtualIO DriverCapability = "DCAP_VIRTUALIO"
+	// DCapCreateLayer is set by vector drivers that can create new layers
+	DCapCreateLayer DriverCapability = "DCAP_CREATE_LAYER"
+	// DCapDeleteLayer is set by vector drivers that can delete layers
+	DCapDeleteLayer DriverCapability = "DCAP_DELETE_LAYER"
+	// DCapCreateField is set by vector drivers that can create fields
+	DCapCreateField DriverCapability = "DCAP_CREATE_FIELD"
+	// DCapSubdatasets is set by drivers that can expose subdatasets
+	DCapSubdatasets DriverCapability = "DCAP_SUBDATASETS"
+)
+
+// HasCapability returns whether the driver advertises the given capability, e.g.
+//
+//	drv.HasCapability(DCapCreate)
+func (drv Driver) HasCapability(cap DriverCapability) bool {
+	return strings.EqualFold(drv.Metadata(string(cap)), "YES")
+}
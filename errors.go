@@ -36,6 +36,32 @@ var errorHandlerIndex int
 // of the parent function
 type ErrorHandler func(ec ErrorCategory, code int, msg string) error
 
+// GDALError is the error returned by godal functions when gdal emits an error (or a
+// warning) and no ErrorHandler was provided. It carries the gdal error category and
+// code (i.e. one of the CPLE_* constants) of the most severe message emitted (the
+// first one in case of ties), so callers
+// can branch on the type of failure with errors.As, e.g. to retry on CPLE_FileIO
+// or CPLE_HttpResponse:
+//
+//	var gerr godal.GDALError
+//	if errors.As(err, &gerr) && gerr.Code == godal.CPLE_OpenFailed {
+//	  ...
+//	}
+//
+// When several messages are emitted, Message contains all of them separated by newlines.
+// Errors returned by an ErrorHandler are returned unchanged, and SkipWarnings returns
+// a GDALError for each error it receives.
+type GDALError struct {
+	Category ErrorCategory
+	Code     int
+	Message  string
+}
+
+// Error is the standard error interface
+func (ge GDALError) Error() string {
+	return ge.Message
+}
+
 type errorHandlerWrapper struct {
 	fn  ErrorHandler
 	err error
@@ -84,27 +110,49 @@ type errorAndLoggingOption interface {
 func ErrLogger(fn ErrorHandler) interface {
 	errorAndLoggingOption
 	AddGeometryOption
+	AlterFieldDefnOption
 	BandCreateMaskOption
 	BandIOOption
+	AutoStretchOption
+	BoundaryOption
 	BoundsOption
 	BufferOption
 	BuildOverviewsOption
 	BuildVRTOption
+	ChecksumOption
 	ClearOverviewsOption
 	CloseOption
+	ConvexHullOption
 	CopyLayerOption
+	CreateSOZipOption
 	CreateFeatureOption
+	CreateFeatureBatchOption
+	CreateFieldOption
 	CreateLayerOption
 	CreateSpatialRefOption
 	DatasetCreateMaskOption
 	DatasetCreateOption
 	DatasetIOOption
 	DatasetTranslateOption
+	DatasetTransformOption
+	TransformPixelsOption
+	PixelToGeoOption
+	GeoToPixelOption
+	ClearGCPsOption
+	ContourOption
+	DriverDeleteOption
+	DriverRenameOption
+	IdentifyDriverOption
+	AddVirtualOverviewsOption
+	ProcessBlocksOption
+	NewReadViewOption
 	DatasetVectorTranslateOption
 	DatasetWarpIntoOption
 	DatasetWarpOption
 	DeleteFeatureOption
+	DeleteFieldOption
 	DifferenceOption
+	Extent3DOption
 	FeatureCountOption
 	FillBandOption
 	FillNoDataOption
@@ -116,37 +164,64 @@ func ErrLogger(fn ErrorHandler) interface {
 	GetGeoTransformOption
 	GMLExportOption
 	HistogramOption
+	DefaultHistogramOption
+	SetDefaultHistogramOption
 	IntersectsOption
 	IntersectionOption
 	MetadataOption
 	NewFeatureOption
 	NewGeometryOption
 	OpenOption
+	PointOnSurfaceOption
+	MakeValidOption
+	PredicateOption
+	SegmentizeOption
+	SetPrecisionOption
 	PolygonizeOption
+	PROJJSONExportOption
+	Proj4ExportOption
+	ProximityOption
 	RasterizeGeometryOption
 	RasterizeOption
 	RasterizeIntoOption
+	RetileOption
 	SetColorInterpOption
 	SetColorTableOption
 	SetDescriptionOption
 	SetGeometryOption
 	SetFieldValueOption
+	SetFromOption
 	SetNoDataOption
 	SetScaleOffsetOption
+	SetUnitTypeOption
+	SetCategoryNamesOption
 	SetGeoTransformOption
 	SetGeometryColumnNameOption
 	SetProjectionOption
 	SetSpatialRefOption
 	SieveFilterOption
+	SplitBandsOption
+	ExpandPaletteOption
+	AddBandOption
+	AreaInCRSOption
+	MultiDimInfoOption
+	RenameLayerOption
+	SetNoDataFromMaskOption
 	SimplifyOption
 	SpatialRefValidateOption
+	FindMatchesOption
 	SubGeometryOption
 	TransformOption
+	TransformBoundsOption
 	UnionOption
+	UnaryUnionOption
+	GeometryPolygonizeOption
 	UpdateFeatureOption
 	VSIHandlerOption
 	VSIOpenOption
 	VSIUnlinkOption
+	VSIMemOption
+	PathSpecificOption
 	WKTExportOption
 	StatisticsOption
 	SetStatisticsOption
@@ -172,12 +247,30 @@ func (ec errorCallback) setErrorAndLoggingOpt(elo *errorAndLoggingOpts) {
 func (ec errorCallback) setAddGeometryOpt(ao *addGeometryOpts) {
 	ao.errorHandler = ec.fn
 }
+func (ec errorCallback) setAlterFieldDefnOpt(o *alterFieldDefnOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setCreateFieldOpt(o *createFieldOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setDeleteFieldOpt(o *deleteFieldOpts) {
+	o.errorHandler = ec.fn
+}
 func (ec errorCallback) setBandCreateMaskOpt(o *bandCreateMaskOpts) {
 	o.errorHandler = ec.fn
 }
 func (ec errorCallback) setBandIOOpt(o *bandIOOpts) {
 	o.errorHandler = ec.fn
 }
+func (ec errorCallback) setAutoStretchOpt(o *autoStretchOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setExtent3DOpt(o *extent3DOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setBoundaryOpt(o *boundaryOpts) {
+	o.errorHandler = ec.fn
+}
 func (ec errorCallback) setBoundsOpt(o *boundsOpts) {
 	o.errorHandler = ec.fn
 }
@@ -196,9 +289,15 @@ func (ec errorCallback) setClearOverviewsOpt(o *clearOvrOpts) {
 func (ec errorCallback) setCloseOpt(o *closeOpts) {
 	o.errorHandler = ec.fn
 }
+func (ec errorCallback) setConvexHullOpt(o *convexHullOpts) {
+	o.errorHandler = ec.fn
+}
 func (ec errorCallback) setCopyLayerOpt(o *copyLayerOpts) {
 	o.errorHandler = ec.fn
 }
+func (ec errorCallback) setCreateFeatureBatchOpt(cfo *createFeatureBatchOpts) {
+	cfo.errorHandler = ec.fn
+}
 func (ec errorCallback) setCreateFeatureOpt(cfo *createFeatureOpts) {
 	cfo.errorHandler = ec.fn
 }
@@ -325,6 +424,12 @@ func (ec errorCallback) setSetProjectionOpt(o *setProjectionOpts) {
 func (ec errorCallback) setSetNoDataOpt(ndo *setNodataOpts) {
 	ndo.errorHandler = ec.fn
 }
+func (ec errorCallback) setSetCategoryNamesOpt(o *setCategoryNamesOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setSetUnitTypeOpt(o *setUnitTypeOpts) {
+	o.errorHandler = ec.fn
+}
 func (ec errorCallback) setSetScaleOffsetOpt(soo *setScaleOffsetOpts) {
 	soo.errorHandler = ec.fn
 }
@@ -340,15 +445,27 @@ func (ec errorCallback) setSimplifyOpt(o *simplifyOpts) {
 func (ec errorCallback) setSpatialRefValidateOpt(o *spatialRefValidateOpts) {
 	o.errorHandler = ec.fn
 }
+func (ec errorCallback) setFindMatchesOpt(o *findMatchesOpts) {
+	o.errorHandler = ec.fn
+}
 func (ec errorCallback) setSubGeometryOpt(so *subGeometryOpts) {
 	so.errorHandler = ec.fn
 }
 func (ec errorCallback) setTransformOpt(o *trnOpts) {
 	o.errorHandler = ec.fn
 }
+func (ec errorCallback) setTransformBoundsOpt(o *transformBoundsOpts) {
+	o.errorHandler = ec.fn
+}
 func (ec errorCallback) setUnionOpt(uo *unionOpts) {
 	uo.errorHandler = ec.fn
 }
+func (ec errorCallback) setUnaryUnionOpt(uo *unaryUnionOpts) {
+	uo.errorHandler = ec.fn
+}
+func (ec errorCallback) setGeometryPolygonizeOpt(po *geometryPolygonizeOpts) {
+	po.errorHandler = ec.fn
+}
 func (ec errorCallback) setUpdateFeatureOpt(o *updateFeatureOpts) {
 	o.errorHandler = ec.fn
 }
@@ -361,10 +478,122 @@ func (ec errorCallback) setVSIOpenOpt(o *vsiOpenOpts) {
 func (ec errorCallback) setVSIUnlinkOpt(o *vsiUnlinkOpts) {
 	o.errorHandler = ec.fn
 }
+func (ec errorCallback) setVSIMemOpt(o *vsiMemOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setPathSpecificOpt(o *pathSpecificOpts) {
+	o.errorHandler = ec.fn
+}
 func (ec errorCallback) setWKTExportOpt(o *srWKTOpts) {
 	o.errorHandler = ec.fn
 }
 
+func (ec errorCallback) setDatasetTransformOpt(o *datasetTransformOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setTransformPixelsOpt(o *transformPixelsOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setRetileOpt(o *retileOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setSplitBandsOpt(o *splitBandsOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setExpandPaletteOpt(o *expandPaletteOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setAddBandOpt(o *addBandOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setAreaInCRSOpt(o *areaInCRSOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setMultiDimInfoOpt(o *multiDimInfoOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setRenameLayerOpt(o *renameLayerOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setSetNoDataFromMaskOpt(o *setNoDataFromMaskOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setPixelToGeoOpt(o *pixelToGeoOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setGeoToPixelOpt(o *geoToPixelOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setClearGCPsOpt(o *clearGCPsOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setContourOpt(o *contourOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setDriverDeleteOpt(o *driverDeleteOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setDriverRenameOpt(o *driverRenameOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setIdentifyDriverOpt(o *identifyDriverOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setAddVirtualOverviewsOpt(o *addVirtualOverviewsOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setProcessBlocksOpt(o *processBlocksOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setNewReadViewOpt(o *newReadViewOpts) {
+	o.open = append(o.open, ec)
+}
+func (ec errorCallback) setSetFromOpt(o *setFromOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setPointOnSurfaceOpt(o *pointOnSurfaceOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setMakeValidOpt(o *makeValidOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setPredicateOpt(o *predicateOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setSegmentizeOpt(o *segmentizeOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setSetPrecisionOpt(o *setPrecisionOpts) {
+	o.errorHandler = ec.fn
+}
+
+func (ec errorCallback) setPROJJSONExportOpt(o *projJSONOpts) {
+	o.errorHandler = ec.fn
+}
+func (ec errorCallback) setProj4ExportOpt(po *proj4Opts) {
+	po.errorHandler = ec.fn
+}
+
+func (ec errorCallback) setCreateSOZipOpt(o *createSOZipOpts) {
+	o.errorHandler = ec.fn
+}
+
+func (ec errorCallback) setChecksumOpt(o *checksumOpts) {
+	o.errorHandler = ec.fn
+}
+
+func (ec errorCallback) setProximityOpt(o *proximityOpts) {
+	o.errorHandler = ec.fn
+}
+
+func (ec errorCallback) setDefaultHistogramOpt(o *defaultHistogramOpts) {
+	o.errorHandler = ec.fn
+}
+
+func (ec errorCallback) setSetDefaultHistogramOpt(o *setDefaultHistogramOpts) {
+	o.errorHandler = ec.fn
+}
+
 func (ec errorCallback) setStatisticsOpt(o *statisticsOpts) {
 	o.errorHandler = ec.fn
 }
@@ -480,7 +709,7 @@ func combine(e1, e2 error) error {
 var SkipWarnings = ErrLogger(
 	func(ec ErrorCategory, code int, message string) error {
 		if ec > CE_Warning {
-			return errors.New(message)
+			return GDALError{Category: ec, Code: code, Message: message}
 		}
 		return nil
 	})
@@ -25,6 +25,7 @@
 #include <ogrsf_frmts.h>
 #include <dlfcn.h>
 #include <cassert>
+#include <atomic>
 
 #include <gdal_utils.h>
 #include <gdal_alg.h>
@@ -35,11 +36,48 @@ extern "C" {
 	extern int _gogdalMultiReadCallback(char* key, int nRanges, void* pocbuffers, void* coffsets, void* clengths, char** errorString);
 	extern size_t _gogdalReadCallback(char* key, void* buffer, size_t off, size_t clen, char** errorString);
 	extern int goErrorHandler(int loggerID, CPLErr lvl, int code, const char *msg);
+	extern void goGlobalErrorHandler(CPLErr lvl, int code, const char *msg);
+}
+
+static std::atomic<int> godalGlobalHandlerSet(0);
+static thread_local int godalCaptureIdx = 0;
+
+static void godalCaptureErrorHandler(CPLErr e, CPLErrorNum n, const char* msg) {
+	goErrorHandler(godalCaptureIdx, e, n, msg);
+}
+
+int godalPushCaptureHandler(int handlerIdx) {
+	int previous = godalCaptureIdx;
+	godalCaptureIdx = handlerIdx;
+	CPLPushErrorHandlerEx(&godalCaptureErrorHandler, nullptr);
+	return previous;
+}
+
+void godalPopCaptureHandler(int previousIdx) {
+	CPLPopErrorHandler();
+	godalCaptureIdx = previousIdx;
+}
+
+static void godalGlobalErrorHandler(CPLErr e, CPLErrorNum n, const char* msg) {
+	goGlobalErrorHandler(e, n, msg);
+}
+
+void godalSetGlobalErrorHandler(int enable) {
+	if (enable) {
+		CPLSetErrorHandler(&godalGlobalErrorHandler);
+		godalGlobalHandlerSet.store(1);
+	} else {
+		godalGlobalHandlerSet.store(0);
+		CPLSetErrorHandler(&CPLDefaultErrorHandler);
+	}
 }
 
 static void godalErrorHandler(CPLErr e, CPLErrorNum n, const char* msg) {
 	cctx *ctx = (cctx*)CPLGetErrorHandlerUserData();
 	assert(ctx!=nullptr);
+	if (godalCaptureIdx != 0) {
+		goErrorHandler(godalCaptureIdx, e, n, msg);
+	}
 	if (ctx->handlerIdx !=0) {
 		int ret = goErrorHandler(ctx->handlerIdx, e, n, msg);
 		if(ret!=0 && ctx->failed==0) {
@@ -49,19 +87,30 @@ static void godalErrorHandler(CPLErr e, CPLErrorNum n, const char* msg) {
 		//let's be strict and treat all warnings as errors
 		if (e < CE_Warning)
 		{
-			fprintf(stderr, "GDAL: %s\n", msg);
+			if (godalGlobalHandlerSet.load()) {
+				goGlobalErrorHandler(e, n, msg);
+			} else {
+				fprintf(stderr, "GDAL: %s\n", msg);
+			}
 			return;
 		}
 		if (ctx->errMessage == nullptr)
 		{
 			ctx->errMessage = (char *)malloc(strlen(msg) + 1);
 			strcpy(ctx->errMessage, msg);
+			ctx->errCategory = e;
+			ctx->errCode = n;
 		}
 		else
 		{
 			ctx->errMessage = (char *)realloc(ctx->errMessage, strlen(ctx->errMessage) + strlen(msg) + 3);
 			strcat(ctx->errMessage, "\n");
 			strcat(ctx->errMessage, msg);
+			if (e > ctx->errCategory)
+			{
+				ctx->errCategory = e;
+				ctx->errCode = n;
+			}
 		}
 	}
 }
@@ -162,6 +211,14 @@ GDALDatasetH godalOpen(cctx *ctx, const char *name, unsigned int nOpenFlags, con
 	return ret;
 }
 
+GDALDriverH godalIdentifyDriver(cctx *ctx, const char *name, unsigned int nIdentifyFlags, const char *const *papszAllowedDrivers,
+					const char *const *papszSiblingFiles) {
+	godalWrap(ctx);
+	GDALDriverH ret = GDALIdentifyDriverEx(name,nIdentifyFlags,papszAllowedDrivers,papszSiblingFiles);
+	godalUnwrap();
+	return ret;
+}
+
 void godalClose(cctx *ctx, GDALDatasetH ds) {
 	godalWrap(ctx);
 	GDALClose(ds);
@@ -296,6 +353,50 @@ char *godalExportToWKT(cctx *ctx, OGRSpatialReferenceH sr) {
 	return pszSRS;
 }
 
+char *godalExportToProj4(cctx *ctx, OGRSpatialReferenceH sr) {
+	godalWrap(ctx);
+	char *pszProj4 = nullptr;
+	OGRErr gret = OSRExportToProj4(sr, &pszProj4);
+	if (gret != OGRERR_NONE) {
+		forceOGRError(ctx, gret);
+		CPLFree(pszProj4);
+		pszProj4 = nullptr;
+	}
+	godalUnwrap();
+	return pszProj4;
+}
+
+char *godalExportToESRIWKT(cctx *ctx, OGRSpatialReferenceH sr) {
+	godalWrap(ctx);
+	char *pszSRS = nullptr;
+	const char *const options[] = {"FORMAT=WKT1_ESRI", nullptr};
+	OGRErr gret = OSRExportToWktEx(sr, &pszSRS, options);
+	if (gret != OGRERR_NONE) {
+		forceOGRError(ctx, gret);
+		CPLFree(pszSRS);
+		pszSRS = nullptr;
+	}
+	godalUnwrap();
+	return pszSRS;
+}
+
+char *godalExportToPROJJSON(cctx *ctx, OGRSpatialReferenceH sr, char **options) {
+	godalWrap(ctx);
+	char *pszJSON = nullptr;
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 1, 0)
+	OGRErr gret = OSRExportToPROJJSON(sr, &pszJSON, options);
+	if (gret != OGRERR_NONE) {
+		forceOGRError(ctx, gret);
+		CPLFree(pszJSON);
+		pszJSON = nullptr;
+	}
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "OSRExportToPROJJSON is only supported in GDAL version >= 3.1");
+#endif
+	godalUnwrap();
+	return pszJSON;
+}
+
 OGRSpatialReferenceH godalCreateWKTSpatialRef(cctx *ctx, char *wkt){
 	godalWrap(ctx);
 	OGRSpatialReferenceH sr = OSRNewSpatialReference(nullptr);
@@ -328,6 +429,23 @@ OGRSpatialReferenceH godalCreateProj4SpatialRef(cctx *ctx, char *proj) {
 	return sr;
 }
 
+OGRSpatialReferenceH godalCreateESRISpatialRef(cctx *ctx, char *esriWKT) {
+	godalWrap(ctx);
+	OGRSpatialReferenceH sr = OSRNewSpatialReference(nullptr);
+	OSRSetAxisMappingStrategy(sr, OAMS_TRADITIONAL_GIS_ORDER);
+	char *lines[2] = {esriWKT, nullptr};
+	OGRErr gret = OSRImportFromESRI(sr, lines);
+	if(gret!=0) {
+		forceOGRError(ctx,gret);
+	}
+	godalUnwrap();
+	if( failed(ctx) ) {
+		OSRDestroySpatialReference(sr);
+		return nullptr;
+	}
+	return sr;
+}
+
 OGRSpatialReferenceH godalCreateEPSGSpatialRef(cctx *ctx, int epsgCode) {
 	godalWrap(ctx);
 	OGRSpatialReferenceH sr = OSRNewSpatialReference(nullptr);
@@ -369,16 +487,115 @@ void godalValidateSpatialRef(cctx *ctx, OGRSpatialReferenceH sr) {
 	godalUnwrap();
 }
 
-OGRCoordinateTransformationH godalNewCoordinateTransformation(cctx *ctx, OGRSpatialReferenceH src, OGRSpatialReferenceH dst) {
+OGRSpatialReferenceH *godalFindMatches(cctx *ctx, OGRSpatialReferenceH sr, int *nMatches, int **confidences) {
 	godalWrap(ctx);
-	OGRCoordinateTransformationH tr = OCTNewCoordinateTransformation(src,dst);
-	if ( tr == nullptr ) {
-		forceError(ctx);
+	*nMatches = 0;
+	*confidences = nullptr;
+	OGRSpatialReferenceH *ret = OSRFindMatches(sr, nullptr, nMatches, confidences);
+	for(int i=0; i<*nMatches; i++) {
+		OSRSetAxisMappingStrategy(ret[i], OAMS_TRADITIONAL_GIS_ORDER);
+	}
+	godalUnwrap();
+	return ret;
+}
+
+OGRCoordinateTransformationH godalNewCoordinateTransformation(cctx *ctx, OGRSpatialReferenceH src, OGRSpatialReferenceH dst,
+															  double *areaOfInterest, int ballparkAllowed, int traditionalGISOrder) {
+	godalWrap(ctx);
+	OGRCoordinateTransformationOptionsH opts = OCTNewCoordinateTransformationOptions();
+	if ( areaOfInterest != nullptr ) {
+		if ( !OCTCoordinateTransformationOptionsSetAreaOfInterest(opts, areaOfInterest[0], areaOfInterest[1],
+																	areaOfInterest[2], areaOfInterest[3]) ) {
+			forceError(ctx);
+		}
 	}
+	if ( !ballparkAllowed ) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 3, 0)
+		OCTCoordinateTransformationOptionsSetBallparkAllowed(opts, FALSE);
+#else
+		CPLError(CE_Failure, CPLE_NotSupported, "Disallowing ballpark transformations is only supported in GDAL version >= 3.3");
+#endif
+	}
+	OGRSpatialReferenceH tsrc = src, tdst = dst;
+	if ( traditionalGISOrder ) {
+		//work on copies so that the mapping of the caller's spatial references is left untouched
+		tsrc = OSRClone(src);
+		tdst = OSRClone(dst);
+		OSRSetAxisMappingStrategy(tsrc, OAMS_TRADITIONAL_GIS_ORDER);
+		OSRSetAxisMappingStrategy(tdst, OAMS_TRADITIONAL_GIS_ORDER);
+	}
+	OGRCoordinateTransformationH tr = nullptr;
+	if ( !failed(ctx) ) {
+		tr = OCTNewCoordinateTransformationEx(tsrc,tdst,opts);
+		if ( tr == nullptr ) {
+			forceError(ctx);
+		}
+	}
+	if ( traditionalGISOrder ) {
+		OSRRelease(tsrc);
+		OSRRelease(tdst);
+	}
+	OCTDestroyCoordinateTransformationOptions(opts);
 	godalUnwrap();
 	return tr;
 }
 
+void *godalCreateGenImgProjTransformer(cctx *ctx, GDALDatasetH src, GDALDatasetH dst, char **options) {
+	godalWrap(ctx);
+	void *trn = GDALCreateGenImgProjTransformer2(src,dst,options);
+	if ( trn == nullptr ) {
+		forceError(ctx);
+	}
+	godalUnwrap();
+	return trn;
+}
+
+void godalGenImgProjTransform(cctx *ctx, void *trn, int inverse, int n, double *x, double *y) {
+	godalWrap(ctx);
+	double *z = (double*)CPLCalloc(n,sizeof(double));
+	int *success = (int*)CPLCalloc(n,sizeof(int));
+	int ret = GDALGenImgProjTransform(trn,inverse,n,x,y,z,success);
+	if ( !ret ) {
+		forceError(ctx);
+	} else {
+		for (int i=0; i<n; i++) {
+			if (!success[i]) {
+				CPLError(CE_Failure, CPLE_AppDefined, "failed to transform point %d", i);
+				break;
+			}
+		}
+	}
+	CPLFree(z);
+	CPLFree(success);
+	godalUnwrap();
+}
+
+void godalDatasetPixelGeoTransform(cctx *ctx, GDALDatasetH ds, char **options, int inverse, int n, double *x, double *y) {
+	void *trn = godalCreateGenImgProjTransformer(ctx, ds, nullptr, options);
+	if ( trn == nullptr ) {
+		return;
+	}
+	godalGenImgProjTransform(ctx, trn, inverse, n, x, y);
+	GDALDestroyGenImgProjTransformer(trn);
+}
+
+void godalTransformBounds(cctx *ctx, OGRCoordinateTransformationH trn, double *bounds, int densifyPts) {
+	godalWrap(ctx);
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 4, 0)
+	double out[4];
+	int ret = OCTTransformBounds(trn,bounds[0],bounds[1],bounds[2],bounds[3],
+		&out[0],&out[1],&out[2],&out[3],densifyPts);
+	if ( !ret ) {
+		forceError(ctx);
+	} else {
+		memcpy(bounds,out,4*sizeof(double));
+	}
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "OCTTransformBounds is only supported in GDAL version >= 3.4");
+#endif
+	godalUnwrap();
+}
+
 void godalSetGeoTransform(cctx *ctx, GDALDatasetH ds, double *gt){
 	godalWrap(ctx);
 	CPLErr ret = GDALSetGeoTransform(ds,gt);
@@ -524,6 +741,24 @@ void godalSetRasterScaleOffset(cctx *ctx, GDALRasterBandH bnd, double scale, dou
 	godalUnwrap();
 }
 
+void godalSetRasterCategoryNames(cctx *ctx, GDALRasterBandH bnd, char **names) {
+	godalWrap(ctx);
+	CPLErr ret = GDALSetRasterCategoryNames(bnd, names);
+	if(ret!=0){
+		forceCPLError(ctx,ret);
+	}
+	godalUnwrap();
+}
+
+void godalSetRasterUnitType(cctx *ctx, GDALRasterBandH bnd, char *unit) {
+	godalWrap(ctx);
+	CPLErr ret = GDALSetRasterUnitType(bnd, unit);
+	if(ret!=0){
+		forceCPLError(ctx,ret);
+	}
+	godalUnwrap();
+}
+
 GDALRasterBandH godalCreateMaskBand(cctx *ctx, GDALRasterBandH bnd, int flags) {
 	godalWrap(ctx);
 	CPLErr ret = GDALCreateMaskBand(bnd, flags);
@@ -539,6 +774,22 @@ GDALRasterBandH godalCreateMaskBand(cctx *ctx, GDALRasterBandH bnd, int flags) {
 	godalUnwrap();
 	return mbnd;
 }
+GDALRasterBandH godalAddBand(cctx *ctx, GDALDatasetH ds, GDALDataType dtype, char **opts) {
+	godalWrap(ctx);
+	CPLErr ret = GDALAddBand(ds, dtype, opts);
+	if(ret!=0) {
+		forceCPLError(ctx, ret);
+		godalUnwrap();
+		return nullptr;
+	}
+	GDALRasterBandH bnd = GDALGetRasterBand(ds, GDALGetRasterCount(ds));
+	if( bnd == nullptr ) {
+		forceError(ctx);
+	}
+	godalUnwrap();
+	return bnd;
+}
+
 GDALRasterBandH godalCreateDatasetMaskBand(cctx *ctx, GDALDatasetH ds, int flags) {
 	godalWrap(ctx);
 	if (GDALGetRasterCount(ds) == 0) {
@@ -578,6 +829,28 @@ GDALDatasetH godalTranslate(cctx *ctx, char *dstName, GDALDatasetH ds, char **sw
 	return ret;
 }
 
+char *godalMultiDimInfo(cctx *ctx, GDALDatasetH ds, char **switches) {
+	godalWrap(ctx);
+	char *ret = nullptr;
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 1, 0)
+	GDALMultiDimInfoOptions *infoopts = GDALMultiDimInfoOptionsNew(switches,nullptr);
+	if(failed(ctx)) {
+		GDALMultiDimInfoOptionsFree(infoopts);
+		godalUnwrap();
+		return nullptr;
+	}
+	ret = GDALMultiDimInfo(ds, infoopts);
+	GDALMultiDimInfoOptionsFree(infoopts);
+	if(ret==nullptr) {
+		forceError(ctx);
+	}
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "GDALMultiDimInfo is only supported in GDAL version >= 3.1");
+#endif
+	godalUnwrap();
+	return ret;
+}
+
 GDALDatasetH godalDatasetWarp(cctx *ctx, char *dstName, int nSrcCount, GDALDatasetH *srcDS, char **switches) {
 	godalWrap(ctx);
 	GDALWarpAppOptions *warpopts = GDALWarpAppOptionsNew(switches,nullptr);
@@ -640,13 +913,48 @@ void godalClearOverviews(cctx *ctx, GDALDatasetH ds) {
 	godalUnwrap();
 }
 
-void godalBuildOverviews(cctx *ctx, GDALDatasetH ds, const char *resampling, int nLevels, int *levels,
-						  int nBands, int *bands) {
+void godalBuildOverviews(cctx *ctx, GDALDatasetH ds, const char *resampling, const char *maskResampling,
+						  int nLevels, int *levels, int nBands, int *bands) {
 	godalWrap(ctx);
 	CPLErr ret = GDALBuildOverviews(ds,resampling,nLevels,levels,nBands,bands,nullptr,nullptr);
 	if(ret!=0){
 		forceCPLError(ctx,ret);
 	}
+	if(maskResampling!=nullptr && !failed(ctx)) {
+		//regenerate the overviews of the masks with their own resampling algorithm
+		bool perDatasetDone = false;
+		int count = nBands > 0 ? nBands : GDALGetRasterCount(ds);
+		for(int i=0; i<count && !failed(ctx); i++) {
+			GDALRasterBandH band = GDALGetRasterBand(ds, nBands > 0 ? bands[i] : i+1);
+			if(band == nullptr) {
+				continue;
+			}
+			int flags = GDALGetMaskFlags(band);
+			if(flags & (GMF_ALL_VALID|GMF_NODATA|GMF_ALPHA)) {
+				continue;
+			}
+			if(flags & GMF_PER_DATASET) {
+				if(perDatasetDone) {
+					continue;
+				}
+				perDatasetDone = true;
+			}
+			GDALRasterBandH mask = GDALGetMaskBand(band);
+			int nOvr = GDALGetOverviewCount(mask);
+			if(nOvr == 0) {
+				continue;
+			}
+			GDALRasterBandH *ovrs = (GDALRasterBandH*)malloc(nOvr*sizeof(GDALRasterBandH));
+			for(int o=0; o<nOvr; o++) {
+				ovrs[o] = GDALGetOverview(mask, o);
+			}
+			ret = GDALRegenerateOverviews(mask, nOvr, ovrs, maskResampling, nullptr, nullptr);
+			free(ovrs);
+			if(ret!=0){
+				forceCPLError(ctx,ret);
+			}
+		}
+	}
 	godalUnwrap();
 }
 
@@ -686,13 +994,20 @@ void godalBandStructure(GDALRasterBandH bnd, int *sx, int *sy, int *bsx, int *bs
 }
 
 void godalBandRasterIO(cctx *ctx, GDALRasterBandH bnd, GDALRWFlag rw, int nDSXOff, int nDSYOff, int nDSXSize, int nDSYSize, void *pBuffer,
-		int nBXSize, int nBYSize, GDALDataType eBDataType, int nPixelSpace, int nLineSpace, GDALRIOResampleAlg alg) {
+		int nBXSize, int nBYSize, GDALDataType eBDataType, int nPixelSpace, int nLineSpace, GDALRIOResampleAlg alg, double *srcWinF) {
 	godalWrap(ctx);
 	GDALRasterIOExtraArg exargs;
 	INIT_RASTERIO_EXTRA_ARG(exargs);
 	if (alg != GRIORA_NearestNeighbour) {
 		exargs.eResampleAlg = alg;
 	}
+	if (srcWinF != nullptr) {
+		exargs.bFloatingPointWindowValidity = TRUE;
+		exargs.dfXOff = srcWinF[0];
+		exargs.dfYOff = srcWinF[1];
+		exargs.dfXSize = srcWinF[2];
+		exargs.dfYSize = srcWinF[3];
+	}
 	CPLErr ret = GDALRasterIOEx(bnd, rw, nDSXOff, nDSYOff, nDSXSize, nDSYSize, pBuffer, nBXSize, nBYSize,
 									 eBDataType, nPixelSpace, nLineSpace, &exargs);
 	if(ret!=0){
@@ -703,13 +1018,20 @@ void godalBandRasterIO(cctx *ctx, GDALRasterBandH bnd, GDALRWFlag rw, int nDSXOf
 
 void godalDatasetRasterIO(cctx *ctx, GDALDatasetH ds, GDALRWFlag rw, int nDSXOff, int nDSYOff, int nDSXSize, int nDSYSize, void *pBuffer,
 		int nBXSize, int nBYSize, GDALDataType eBDataType, int nBandCount, int *panBandCount,
-		int nPixelSpace, int nLineSpace, int nBandSpace, GDALRIOResampleAlg alg) {
+		int nPixelSpace, int nLineSpace, int nBandSpace, GDALRIOResampleAlg alg, double *srcWinF) {
 	godalWrap(ctx);
 	GDALRasterIOExtraArg exargs;
 	INIT_RASTERIO_EXTRA_ARG(exargs);
 	if (alg != GRIORA_NearestNeighbour) {
 		exargs.eResampleAlg = alg;
 	}
+	if (srcWinF != nullptr) {
+		exargs.bFloatingPointWindowValidity = TRUE;
+		exargs.dfXOff = srcWinF[0];
+		exargs.dfYOff = srcWinF[1];
+		exargs.dfXSize = srcWinF[2];
+		exargs.dfYSize = srcWinF[3];
+	}
 	CPLErr ret = GDALDatasetRasterIOEx(ds, rw, nDSXOff, nDSYOff, nDSXSize, nDSYSize, pBuffer, nBXSize, nBYSize,
 									 eBDataType, nBandCount, panBandCount, nPixelSpace, nLineSpace, nBandSpace, &exargs);
 	if(ret!=0){
@@ -742,6 +1064,19 @@ void godalPolygonize(cctx *ctx, GDALRasterBandH in, GDALRasterBandH mask, OGRLay
 	godalUnwrap();
 }
 
+void godalContourGenerate(cctx *ctx, GDALRasterBandH in, OGRLayerH layer, char **opts) {
+	godalWrap(ctx);
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(2, 4, 0)
+	CPLErr ret = GDALContourGenerateEx(in,layer,opts,nullptr,nullptr);
+	if(ret!=0){
+		forceCPLError(ctx,ret);
+	}
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "GDALContourGenerateEx is only supported in GDAL version >= 2.4");
+#endif
+	godalUnwrap();
+}
+
 void godalSieveFilter(cctx *ctx, GDALRasterBandH bnd, GDALRasterBandH mask, GDALRasterBandH dst, int sizeThreshold, int connectedNess) {
 	godalWrap(ctx);
 	CPLErr ret = GDALSieveFilter(bnd,mask,dst,sizeThreshold,connectedNess,nullptr,nullptr,nullptr);
@@ -760,6 +1095,25 @@ void godalFillNoData(cctx *ctx, GDALRasterBandH in, GDALRasterBandH mask, int ma
 	godalUnwrap();
 }
 
+int godalChecksumImage(cctx *ctx, GDALRasterBandH bnd, int xOff, int yOff, int xSize, int ySize) {
+	godalWrap(ctx);
+	int ret = GDALChecksumImage(bnd,xOff,yOff,xSize,ySize);
+	if(ret<0){
+		forceError(ctx);
+	}
+	godalUnwrap();
+	return ret;
+}
+
+void godalComputeProximity(cctx *ctx, GDALRasterBandH in, GDALRasterBandH dst, char **opts) {
+	godalWrap(ctx);
+	CPLErr ret = GDALComputeProximity(in,dst,opts,nullptr,nullptr);
+	if(ret!=0){
+		forceCPLError(ctx,ret);
+	}
+	godalUnwrap();
+}
+
 GDALDatasetH godalRasterize(cctx *ctx, char *dstName, GDALDatasetH dstDS, GDALDatasetH ds, char **switches) {
 	godalWrap(ctx);
 	GDALRasterizeOptions *ropts = GDALRasterizeOptionsNew(switches,nullptr);
@@ -807,6 +1161,33 @@ void godalLayerDeleteFeature(cctx *ctx, OGRLayerH layer, OGRFeatureH feat) {
 	godalUnwrap();
 }
 
+void godalLayerAlterFieldDefn(cctx *ctx, OGRLayerH layer, int fieldIndex, OGRFieldDefnH fdefn, int flags) {
+	godalWrap(ctx);
+	OGRErr gret = OGR_L_AlterFieldDefn(layer,fieldIndex,fdefn,flags);
+	if(gret!=0){
+		forceOGRError(ctx,gret);
+	}
+	godalUnwrap();
+}
+
+void godalLayerCreateField(cctx *ctx, OGRLayerH layer, OGRFieldDefnH fdefn) {
+	godalWrap(ctx);
+	OGRErr gret = OGR_L_CreateField(layer,fdefn,TRUE);
+	if(gret!=0){
+		forceOGRError(ctx,gret);
+	}
+	godalUnwrap();
+}
+
+void godalLayerDeleteField(cctx *ctx, OGRLayerH layer, int fieldIndex) {
+	godalWrap(ctx);
+	OGRErr gret = OGR_L_DeleteField(layer,fieldIndex);
+	if(gret!=0){
+		forceOGRError(ctx,gret);
+	}
+	godalUnwrap();
+}
+
 void godalLayerSetFeature(cctx *ctx, OGRLayerH layer, OGRFeatureH feat) {
 	godalWrap(ctx);
 	OGRErr gret = OGR_L_SetFeature(layer,feat);
@@ -848,6 +1229,19 @@ void godalFeatureSetGeometry(cctx *ctx, OGRFeatureH feat, OGRGeometryH geom) {
 	godalUnwrap();
 }
 
+void godalFeatureSetGeometryByIndex(cctx *ctx, OGRFeatureH feat, int index, OGRGeometryH geom) {
+	godalWrap(ctx);
+	if(index < 0 || index >= OGR_F_GetGeomFieldCount(feat)) {
+		CPLError(CE_Failure, CPLE_AppDefined, "invalid geometry field index %d", index);
+	} else {
+		OGRErr gret = OGR_F_SetGeomField(feat,index,geom);
+		if(gret!=0){
+			forceOGRError(ctx,gret);
+		}
+	}
+	godalUnwrap();
+}
+
 void godalFeatureSetFieldInteger(cctx *ctx, OGRFeatureH feat, int fieldIndex, int value) {
 	godalWrap(ctx);
 	OGR_F_SetFieldInteger(feat, fieldIndex, value);
@@ -908,6 +1302,27 @@ void godalFeatureSetFieldBinary(cctx *ctx, OGRFeatureH feat, int fieldIndex, int
 	godalUnwrap();
 }
 
+void godalFeatureSetFieldNull(cctx *ctx, OGRFeatureH feat, int fieldIndex) {
+	godalWrap(ctx);
+	OGR_F_SetFieldNull(feat, fieldIndex);
+	godalUnwrap();
+}
+
+void godalFeatureUnsetField(cctx *ctx, OGRFeatureH feat, int fieldIndex) {
+	godalWrap(ctx);
+	OGR_F_UnsetField(feat, fieldIndex);
+	godalUnwrap();
+}
+
+void godalFeatureSetFrom(cctx *ctx, OGRFeatureH feat, OGRFeatureH other, int forgiving) {
+	godalWrap(ctx);
+	OGRErr gret = OGR_F_SetFrom(feat, other, forgiving);
+	if (gret != 0) {
+		forceOGRError(ctx,gret);
+	}
+	godalUnwrap();
+}
+
 void godal_OGR_G_AddGeometry(cctx *ctx, OGRGeometryH geom, OGRGeometryH subGeom) {
 	godalWrap(ctx);
 	OGRErr gret = OGR_G_AddGeometry(geom,subGeom);
@@ -964,6 +1379,48 @@ int godal_OGR_G_Intersects(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2) {
 	return ret;
 }
 
+int godal_OGR_G_Touches(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2) {
+	godalWrap(ctx);
+	int ret = OGR_G_Touches(geom1, geom2);
+	godalUnwrap();
+	return ret;
+}
+
+int godal_OGR_G_Crosses(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2) {
+	godalWrap(ctx);
+	int ret = OGR_G_Crosses(geom1, geom2);
+	godalUnwrap();
+	return ret;
+}
+
+int godal_OGR_G_Within(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2) {
+	godalWrap(ctx);
+	int ret = OGR_G_Within(geom1, geom2);
+	godalUnwrap();
+	return ret;
+}
+
+int godal_OGR_G_Overlaps(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2) {
+	godalWrap(ctx);
+	int ret = OGR_G_Overlaps(geom1, geom2);
+	godalUnwrap();
+	return ret;
+}
+
+int godal_OGR_G_Disjoint(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2) {
+	godalWrap(ctx);
+	int ret = OGR_G_Disjoint(geom1, geom2);
+	godalUnwrap();
+	return ret;
+}
+
+int godal_OGR_G_Equals(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2) {
+	godalWrap(ctx);
+	int ret = OGR_G_Equals(geom1, geom2);
+	godalUnwrap();
+	return ret;
+}
+
 OGRGeometryH godal_OGR_G_Intersection(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2) {
 	godalWrap(ctx);
 	OGRGeometryH ret = OGR_G_Intersection(geom1, geom2);
@@ -984,9 +1441,114 @@ OGRGeometryH godal_OGR_G_Union(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2
 	return ret;
 }
 
-OGRLayerH godalCreateLayer(cctx *ctx, GDALDatasetH ds, char *name, OGRSpatialReferenceH sr, OGRwkbGeometryType gtype) {
+OGRGeometryH godal_OGR_G_UnaryUnion(cctx *ctx, int nGeoms, OGRGeometryH *geoms) {
+	godalWrap(ctx);
+	OGRGeometryH ret = nullptr;
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 7, 0)
+	OGRGeometryH coll = OGR_G_CreateGeometry(wkbGeometryCollection);
+	OGRErr gret = OGRERR_NONE;
+	for(int i=0; i<nGeoms && gret==OGRERR_NONE; i++) {
+		gret = OGR_G_AddGeometry(coll, geoms[i]);
+	}
+	if(gret!=OGRERR_NONE) {
+		forceOGRError(ctx,gret);
+	} else {
+		ret = OGR_G_UnaryUnion(coll);
+		if(ret==nullptr) {
+			forceError(ctx);
+		}
+	}
+	OGR_G_DestroyGeometry(coll);
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "UnaryUnion is only supported in GDAL version >= 3.7");
+#endif
+	godalUnwrap();
+	return ret;
+}
+
+OGRGeometryH godal_OGR_G_Polygonize(cctx *ctx, OGRGeometryH in) {
+	godalWrap(ctx);
+	OGRGeometryH ret = OGR_G_Polygonize(in);
+	if(ret==nullptr) {
+		forceError(ctx);
+	}
+	godalUnwrap();
+	return ret;
+}
+
+OGRGeometryH godal_OGR_G_ConvexHull(cctx *ctx, OGRGeometryH in) {
+	godalWrap(ctx);
+	OGRGeometryH ret = OGR_G_ConvexHull(in);
+	if(ret==nullptr) {
+		forceError(ctx);
+	}
+	godalUnwrap();
+	return ret;
+}
+
+OGRGeometryH godal_OGR_G_Boundary(cctx *ctx, OGRGeometryH in) {
+	godalWrap(ctx);
+	OGRGeometryH ret = OGR_G_Boundary(in);
+	if(ret==nullptr) {
+		forceError(ctx);
+	}
+	godalUnwrap();
+	return ret;
+}
+
+OGRGeometryH godal_OGR_G_PointOnSurface(cctx *ctx, OGRGeometryH in) {
+	godalWrap(ctx);
+	OGRGeometryH ret = OGR_G_PointOnSurface(in);
+	if(ret==nullptr) {
+		forceError(ctx);
+	}
+	godalUnwrap();
+	return ret;
+}
+
+OGRGeometryH godal_OGR_G_MakeValid(cctx *ctx, OGRGeometryH in, char **options) {
+	godalWrap(ctx);
+	OGRGeometryH ret = nullptr;
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 4, 0)
+	ret = OGR_G_MakeValidEx(in, options);
+#else
+	if(options != nullptr && *options != nullptr) {
+		CPLError(CE_Failure, CPLE_NotSupported, "MakeValid options are only supported in GDAL version >= 3.4");
+	} else {
+		ret = OGR_G_MakeValid(in);
+	}
+#endif
+	if(ret==nullptr) {
+		forceError(ctx);
+	}
+	godalUnwrap();
+	return ret;
+}
+
+void godal_OGR_G_Segmentize(cctx *ctx, OGRGeometryH in, double maxLength) {
+	godalWrap(ctx);
+	OGR_G_Segmentize(in, maxLength);
+	godalUnwrap();
+}
+
+OGRGeometryH godal_OGR_G_SetPrecision(cctx *ctx, OGRGeometryH in, double gridSize, int flags) {
+	godalWrap(ctx);
+	OGRGeometryH ret = nullptr;
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 9, 0)
+	ret = OGR_G_SetPrecision(in, gridSize, flags);
+	if(ret==nullptr) {
+		forceError(ctx);
+	}
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "OGR_G_SetPrecision is only supported in GDAL version >= 3.9");
+#endif
+	godalUnwrap();
+	return ret;
+}
+
+OGRLayerH godalCreateLayer(cctx *ctx, GDALDatasetH ds, char *name, OGRSpatialReferenceH sr, OGRwkbGeometryType gtype, char **options) {
 	godalWrap(ctx);
-	OGRLayerH ret = OGR_DS_CreateLayer(ds,name,sr,gtype,nullptr);
+	OGRLayerH ret = OGR_DS_CreateLayer(ds,name,sr,gtype,options);
 	if(ret==nullptr) {
 		forceError(ctx);
 	}
@@ -1045,9 +1607,9 @@ void godalCommitTransaction(cctx *ctx, GDALDatasetH ds) {
 	godalUnwrap();
 }
 
-void godalLayerGetExtent(cctx *ctx, OGRLayerH layer, OGREnvelope *envelope) {
+void godalLayerGetExtent(cctx *ctx, OGRLayerH layer, OGREnvelope *envelope, int force) {
 	godalWrap(ctx);
-	OGRErr gret = OGR_L_GetExtent(layer, envelope, 1);
+	OGRErr gret = OGR_L_GetExtent(layer, envelope, force);
 	if (gret != OGRERR_NONE) {
 		forceOGRError(ctx,gret);
 	} else if(envelope==nullptr) {
@@ -1056,6 +1618,27 @@ void godalLayerGetExtent(cctx *ctx, OGRLayerH layer, OGREnvelope *envelope) {
 	godalUnwrap();
 }
 
+void godalLayerGetExtent3D(cctx *ctx, OGRLayerH layer, double *extent, int force) {
+	godalWrap(ctx);
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 9, 0)
+	OGREnvelope3D env;
+	OGRErr gret = OGR_L_GetExtent3D(layer, 0, &env, force);
+	if (gret != OGRERR_NONE) {
+		forceOGRError(ctx,gret);
+	} else {
+		extent[0] = env.MinX;
+		extent[1] = env.MinY;
+		extent[2] = env.MinZ;
+		extent[3] = env.MaxX;
+		extent[4] = env.MaxY;
+		extent[5] = env.MaxZ;
+	}
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "OGR_L_GetExtent3D is only supported in GDAL version >= 3.9");
+#endif
+	godalUnwrap();
+}
+
 void godalLayerFeatureCount(cctx *ctx, OGRLayerH layer, int *count) {
 	godalWrap(ctx);
 	GIntBig gcount = OGR_L_GetFeatureCount(layer, 1);
@@ -1107,9 +1690,9 @@ void godalSetColorTable(cctx *ctx, GDALRasterBandH bnd, GDALPaletteInterp interp
 	godalUnwrap();
 }
 
-VSILFILE *godalVSIOpen(cctx *ctx, const char *name) {
+VSILFILE *godalVSIOpen(cctx *ctx, const char *name, const char *mode) {
 	godalWrap(ctx);
-	VSILFILE *fp = VSIFOpenExL(name,"r",1);
+	VSILFILE *fp = VSIFOpenExL(name,mode,1);
 	if(fp==nullptr) {
 		forceError(ctx);
 	}
@@ -1130,6 +1713,103 @@ void godalVSIUnlink(cctx *ctx, const char *fname) {
 	godalUnwrap();
 }
 
+void godalVSIMemCreate(cctx *ctx, const char *fname, const void *data, size_t len) {
+	godalWrap(ctx);
+	//the buffer is released by gdal with VSIFree, so it must have been allocated with VSIMalloc
+	GByte *buf = (GByte*)VSIMalloc(len > 0 ? len : 1);
+	if(buf==nullptr) {
+		CPLError(CE_Failure, CPLE_OutOfMemory, "failed to allocate %llu bytes", (unsigned long long)len);
+		godalUnwrap();
+		return;
+	}
+	if(len > 0) {
+		memcpy(buf, data, len);
+	}
+	VSILFILE *fp = VSIFileFromMemBuffer(fname, buf, len, TRUE);
+	if(fp==nullptr) {
+		VSIFree(buf);
+		forceError(ctx);
+	} else {
+		VSIFCloseL(fp);
+	}
+	godalUnwrap();
+}
+
+void *godalVSIMemSteal(cctx *ctx, const char *fname, size_t *len) {
+	godalWrap(ctx);
+	*len = 0;
+	VSIStatBufL st;
+	if(VSIStatL(fname, &st)!=0 || !VSI_ISREG(st.st_mode)) {
+		CPLError(CE_Failure, CPLE_FileIO, "%s: no such /vsimem/ file", fname);
+		godalUnwrap();
+		return nullptr;
+	}
+	vsi_l_offset l = 0;
+	//also unlinks the file. The returned buffer may be null for an empty file
+	GByte *ret = VSIGetMemFileBuffer(fname, &l, TRUE);
+	*len = (size_t)l;
+	godalUnwrap();
+	return ret;
+}
+
+void godalVSISetPathSpecificOption(cctx *ctx, const char *prefix, const char *key, const char *value, int credential) {
+	godalWrap(ctx);
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 6, 0)
+	VSISetPathSpecificOption(prefix, key, value);
+#elif GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 5, 0)
+	if (credential) {
+		VSISetCredential(prefix, key, value);
+	} else {
+		CPLError(CE_Failure, CPLE_NotSupported, "VSISetPathSpecificOption is only supported in GDAL version >= 3.6");
+	}
+#else
+	if (credential) {
+		CPLError(CE_Failure, CPLE_NotSupported, "VSISetCredential is only supported in GDAL version >= 3.5");
+	} else {
+		CPLError(CE_Failure, CPLE_NotSupported, "VSISetPathSpecificOption is only supported in GDAL version >= 3.6");
+	}
+#endif
+	godalUnwrap();
+}
+
+void godalVSIClearPathSpecificOptions(cctx *ctx, const char *prefix) {
+	godalWrap(ctx);
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 6, 0)
+	VSIClearPathSpecificOptions(prefix);
+#elif GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 5, 0)
+	VSIClearCredentials(prefix);
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "VSIClearCredentials is only supported in GDAL version >= 3.5");
+#endif
+	godalUnwrap();
+}
+
+void godalCreateSOZip(cctx *ctx, const char *zipName, char **archiveNames, char **inputNames, char **options) {
+	godalWrap(ctx);
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 7, 0)
+	void *hZip = CPLCreateZip(zipName, nullptr);
+	if (hZip == nullptr) {
+		forceError(ctx);
+		godalUnwrap();
+		return;
+	}
+	for (int i = 0; archiveNames[i] != nullptr; i++) {
+		CPLErr ret = CPLAddFileInZip(hZip, archiveNames[i], inputNames[i], nullptr, options, nullptr, nullptr);
+		if (ret != CE_None) {
+			forceCPLError(ctx, ret);
+			break;
+		}
+	}
+	CPLErr ret = CPLCloseZip(hZip);
+	if (ret != CE_None) {
+		forceCPLError(ctx, ret);
+	}
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "SOZip creation is only supported in GDAL version >= 3.7");
+#endif
+	godalUnwrap();
+}
+
 char* godalVSIClose(VSILFILE *f) {
 	cctx ctx{nullptr,0,0,nullptr};
 	godalWrap(&ctx);
@@ -1141,6 +1821,17 @@ char* godalVSIClose(VSILFILE *f) {
 	return ctx.errMessage;
 }
 
+char* godalVSIFlush(VSILFILE *f) {
+	cctx ctx{nullptr,0,0,nullptr};
+	godalWrap(&ctx);
+	int ret = VSIFFlushL(f);
+	if(ret!=0) {
+		forceError(&ctx);
+	}
+	godalUnwrap();
+	return ctx.errMessage;
+}
+
 size_t godalVSIRead(VSILFILE *f, void *buf, int len, char **errmsg) {
 	cctx ctx{nullptr,0,0,nullptr};
 	godalWrap(&ctx);
@@ -1150,6 +1841,18 @@ size_t godalVSIRead(VSILFILE *f, void *buf, int len, char **errmsg) {
 	return read;
 }
 
+size_t godalVSIWrite(VSILFILE *f, const void *buf, int len, char **errmsg) {
+	cctx ctx{nullptr,0,0,nullptr};
+	godalWrap(&ctx);
+	size_t written = VSIFWriteL(buf,1,len,f);
+	if((int)written!=len) {
+		forceError(&ctx);
+	}
+	godalUnwrap();
+	*errmsg=ctx.errMessage;
+	return written;
+}
+
 void godalRasterHistogram(cctx *ctx, GDALRasterBandH bnd, double *min, double *max, int *buckets,
 						   unsigned long long **values, int bIncludeOutOfRange, int bApproxOK) {
 	godalWrap(ctx);
@@ -1166,6 +1869,34 @@ void godalRasterHistogram(cctx *ctx, GDALRasterBandH bnd, double *min, double *m
 	godalUnwrap();
 }
 
+void godalComputeRasterMinMax(cctx *ctx, GDALRasterBandH bnd, int bApproxOK, double *minmax) {
+	godalWrap(ctx);
+	//return type changed from void to CPLErr in gdal 3.x, rely on the emitted errors instead
+	GDALComputeRasterMinMax(bnd,bApproxOK,minmax);
+	godalUnwrap();
+}
+
+int godalGetDefaultHistogram(cctx *ctx, GDALRasterBandH bnd, double *min, double *max, int *buckets,
+							 unsigned long long **values) {
+	godalWrap(ctx);
+	CPLErr ret = GDALGetDefaultHistogramEx(bnd,min,max,buckets,values,0,nullptr,nullptr);
+	if (ret != 0 && ret != CE_Warning) {
+		forceCPLError(ctx,ret);
+	}
+	godalUnwrap();
+	return (ret == 0);
+}
+
+void godalSetDefaultHistogram(cctx *ctx, GDALRasterBandH bnd, double min, double max, int buckets,
+							  unsigned long long *values) {
+	godalWrap(ctx);
+	CPLErr ret = GDALSetDefaultHistogramEx(bnd,min,max,buckets,values);
+	if (ret != 0) {
+		forceCPLError(ctx,ret);
+	}
+	godalUnwrap();
+}
+
 void godalComputeRasterStatistics(cctx *ctx, GDALRasterBandH bnd, int bApproxOK, double *pdfMin, double *pdfMax, double *pdfMean, double *pdfStdDev){
   godalWrap(ctx);
   CPLErr ret = CE_None;
@@ -1274,7 +2005,7 @@ char* godalExportGeometryWKT(cctx *ctx, OGRGeometryH in) {
 	return wkt;
 }
 
-void godalExportGeometryWKB(cctx *ctx, void **wkb, int *wkbLen, OGRGeometryH in) {
+void godalExportGeometryWKB(cctx *ctx, void **wkb, int *wkbLen, OGRGeometryH in, int xdr, int extended) {
 	godalWrap(ctx);
 	*wkbLen=OGR_G_WkbSize(in);
 	if (*wkbLen == 0) {
@@ -1283,7 +2014,13 @@ void godalExportGeometryWKB(cctx *ctx, void **wkb, int *wkbLen, OGRGeometryH in)
 		return;
 	}
 	*wkb = malloc(*wkbLen);
-	OGRErr gret = OGR_G_ExportToIsoWkb(in,wkbNDR,(unsigned char*)*wkb);
+	OGRwkbByteOrder order = xdr ? wkbXDR : wkbNDR;
+	OGRErr gret;
+	if (extended) {
+		gret = OGR_G_ExportToWkb(in,order,(unsigned char*)*wkb);
+	} else {
+		gret = OGR_G_ExportToIsoWkb(in,order,(unsigned char*)*wkb);
+	}
 	if (gret != 0) {
 		forceOGRError(ctx,gret);
 		free(*wkb);
@@ -1345,6 +2082,32 @@ void godalGeometryTransform(cctx *ctx, OGRGeometryH geom, OGRCoordinateTransform
 	godalUnwrap();
 }
 
+static int godalGeometryTotalPointCount(OGRGeometryH geom) {
+	int n = OGR_G_GetGeometryCount(geom);
+	if (n == 0) {
+		return OGR_G_GetPointCount(geom);
+	}
+	int count = 0;
+	for (int i = 0; i < n; i++) {
+		count += godalGeometryTotalPointCount(OGR_G_GetGeometryRef(geom, i));
+	}
+	return count;
+}
+
+int godalGeometryTransformPartial(cctx *ctx, OGRGeometryH geom, OGRCoordinateTransformationH trn, OGRSpatialReferenceH dst) {
+	godalWrap(ctx);
+	int before = godalGeometryTotalPointCount(geom);
+	OGRErr gret = OGR_G_Transform(geom,trn);
+	if (gret != 0) {
+		forceOGRError(ctx,gret);
+		godalUnwrap();
+		return 0;
+	}
+	OGR_G_AssignSpatialReference(geom, dst);
+	godalUnwrap();
+	return before - godalGeometryTotalPointCount(geom);
+}
+
 void godalLayerCreateFeature(cctx *ctx, OGRLayerH layer, OGRFeatureH feat) {
 	godalWrap(ctx);
 	OGRErr oe = OGR_L_CreateFeature(layer,feat);
@@ -1354,6 +2117,53 @@ void godalLayerCreateFeature(cctx *ctx, OGRLayerH layer, OGRFeatureH feat) {
 	godalUnwrap();
 }
 
+void godalLayerRename(cctx *ctx, OGRLayerH layer, char *name) {
+	godalWrap(ctx);
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 5, 0)
+	if(!OGR_L_TestCapability(layer, OLCRename)) {
+		CPLError(CE_Failure, CPLE_NotSupported, "layer %s cannot be renamed", OGR_L_GetName(layer));
+		godalUnwrap();
+		return;
+	}
+	OGRErr oe = OGR_L_Rename(layer, name);
+	if(oe != OGRERR_NONE) {
+		forceOGRError(ctx,oe);
+	}
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "OGR_L_Rename is only supported in GDAL version >= 3.5");
+#endif
+	godalUnwrap();
+}
+
+void godalLayerCreateFeatureBatch(cctx *ctx, OGRLayerH layer, OGRFeatureH *feats, int count) {
+	godalWrap(ctx);
+	if(!OGR_L_TestCapability(layer, OLCTransactions)) {
+		CPLError(CE_Failure, CPLE_NotSupported, "layer does not support transactions");
+		godalUnwrap();
+		return;
+	}
+	OGRErr oe = OGR_L_StartTransaction(layer);
+	if(oe != OGRERR_NONE) {
+		forceOGRError(ctx,oe);
+		godalUnwrap();
+		return;
+	}
+	for(int i=0; i<count; i++) {
+		oe = OGR_L_CreateFeature(layer,feats[i]);
+		if(oe != OGRERR_NONE) {
+			forceOGRError(ctx,oe);
+			OGR_L_RollbackTransaction(layer);
+			godalUnwrap();
+			return;
+		}
+	}
+	oe = OGR_L_CommitTransaction(layer);
+	if(oe != OGRERR_NONE) {
+		forceOGRError(ctx,oe);
+	}
+	godalUnwrap();
+}
+
 OGRFeatureH godalLayerNewFeature(cctx *ctx, OGRLayerH layer, OGRGeometryH geom) {
 	godalWrap(ctx);
 	OGRFeatureH hFeature = OGR_F_Create( OGR_L_GetLayerDefn( layer ) );
@@ -1804,6 +2614,12 @@ void test_godal_error_handling(cctx *ctx) {
     godalUnwrap();
 }
 
+void test_godal_global_error_handling() {
+    CPLDebug("godal","this is a global debug message");
+    CPLError(CE_Warning, CPLE_AppDefined, "this is a global warning message");
+    CPLError(CE_Failure, CPLE_FileIO, "this is a global failure message");
+}
+
 void godalGridCreate(cctx *ctx, char *pszAlgorithm, GDALGridAlgorithm eAlgorithm, GUInt32 nPoints, const double *padfX, const double *padfY, const double *padfZ, double dfXMin,
 					double dfXMax, double dfYMin, double dfYMax, GUInt32 nXSize, GUInt32 nYSize, GDALDataType eType, void *pData) {
 	godalWrap(ctx);
@@ -1954,6 +2770,33 @@ void godalSetGCPs2(cctx *ctx, GDALDatasetH hSrcDS, int numGCPs, goGCPList GCPLis
 	return;
 }
 
+void godalDeleteDataset(cctx *ctx, GDALDriverH drv, const char *name) {
+	godalWrap(ctx);
+	CPLErr ret = GDALDeleteDataset(drv, name);
+	if(ret!=0) {
+		forceCPLError(ctx, ret);
+	}
+	godalUnwrap();
+}
+
+void godalRenameDataset(cctx *ctx, GDALDriverH drv, const char *newName, const char *oldName) {
+	godalWrap(ctx);
+	CPLErr ret = GDALRenameDataset(drv, newName, oldName);
+	if(ret!=0) {
+		forceCPLError(ctx, ret);
+	}
+	godalUnwrap();
+}
+
+void godalClearGCPs(cctx *ctx, GDALDatasetH hSrcDS) {
+	godalWrap(ctx);
+	CPLErr ret = GDALSetGCPs(hSrcDS, 0, nullptr, "");
+	if(ret!=0) {
+		forceCPLError(ctx, ret);
+	}
+	godalUnwrap();
+}
+
 GDAL_GCP *goGCPListToGDALGCP(goGCPList GCPList, int numGCPs) {
 	GDAL_GCP *ret = static_cast<GDAL_GCP *>(CPLCalloc(numGCPs, sizeof(GDAL_GCP)));
 	GDALInitGCPs(numGCPs, ret);
@@ -1987,3 +2830,11 @@ void godalGCPListToGeoTransform(cctx *ctx, goGCPList GCPList, int numGCPs, doubl
 
 	godalUnwrap();
 }
+
+int godalGetRPCInfo(GDALDatasetH hDS, GDALRPCInfoV2 *rpc) {
+	char **md = GDALGetMetadata(hDS, "RPC");
+	if (md == nullptr) {
+		return 0;
+	}
+	return GDALExtractRPCInfoV2(md, rpc);
+}
@@ -27,10 +27,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unsafe"
 )
@@ -84,6 +88,44 @@ const (
 	CE_Fatal = ErrorCategory(C.CE_Fatal)
 )
 
+// GDAL error codes, as carried by GDALError.Code or passed to an ErrorHandler
+const (
+	// CPLE_None is no error
+	CPLE_None = int(C.CPLE_None)
+	// CPLE_AppDefined is a generic application defined error
+	CPLE_AppDefined = int(C.CPLE_AppDefined)
+	// CPLE_OutOfMemory is an out of memory error
+	CPLE_OutOfMemory = int(C.CPLE_OutOfMemory)
+	// CPLE_FileIO is a file I/O error
+	CPLE_FileIO = int(C.CPLE_FileIO)
+	// CPLE_OpenFailed is an open failure
+	CPLE_OpenFailed = int(C.CPLE_OpenFailed)
+	// CPLE_IllegalArg is an illegal argument error
+	CPLE_IllegalArg = int(C.CPLE_IllegalArg)
+	// CPLE_NotSupported is a not supported error
+	CPLE_NotSupported = int(C.CPLE_NotSupported)
+	// CPLE_AssertionFailed is an assertion failure
+	CPLE_AssertionFailed = int(C.CPLE_AssertionFailed)
+	// CPLE_NoWriteAccess is a no write access error
+	CPLE_NoWriteAccess = int(C.CPLE_NoWriteAccess)
+	// CPLE_UserInterrupt is a user interruption
+	CPLE_UserInterrupt = int(C.CPLE_UserInterrupt)
+	// CPLE_ObjectNull is a NULL object error
+	CPLE_ObjectNull = int(C.CPLE_ObjectNull)
+	// CPLE_HttpResponse is an HTTP response error
+	CPLE_HttpResponse = int(C.CPLE_HttpResponse)
+	// CPLE_AWSBucketNotFound is an AWS bucket not found error
+	CPLE_AWSBucketNotFound = int(C.CPLE_AWSBucketNotFound)
+	// CPLE_AWSObjectNotFound is an AWS object not found error
+	CPLE_AWSObjectNotFound = int(C.CPLE_AWSObjectNotFound)
+	// CPLE_AWSAccessDenied is an AWS access denied error
+	CPLE_AWSAccessDenied = int(C.CPLE_AWSAccessDenied)
+	// CPLE_AWSInvalidCredentials is an AWS invalid credentials error
+	CPLE_AWSInvalidCredentials = int(C.CPLE_AWSInvalidCredentials)
+	// CPLE_AWSSignatureDoesNotMatch is an AWS signature mismatch error
+	CPLE_AWSSignatureDoesNotMatch = int(C.CPLE_AWSSignatureDoesNotMatch)
+)
+
 // String implements Stringer
 func (dtype DataType) String() string {
 	return C.GoString(C.GDALGetDataTypeName(C.GDALDataType(dtype)))
@@ -202,6 +244,62 @@ func (band Band) SetNoData(nd float64, opts ...SetNoDataOption) error {
 	return cgc.close()
 }
 
+// SetNoDataFromMask writes nodata into the pixels of the band that are masked out (i.e.
+// equal to 0) in mask, and then sets nodata as the band's nodata value. mask must have
+// the same size as the band, and is typically the band's or dataset's existing mask band.
+//
+// The band is processed block by block. Masked-out pixels are converted to the band's
+// datatype, so nodata should be representable in that datatype.
+func (band Band) SetNoDataFromMask(mask Band, nodata float64, opts ...SetNoDataFromMaskOption) error {
+	so := setNoDataFromMaskOpts{}
+	for _, opt := range opts {
+		opt.setSetNoDataFromMaskOpt(&so)
+	}
+	st := band.Structure()
+	mst := mask.Structure()
+	if st.SizeX != mst.SizeX || st.SizeY != mst.SizeY {
+		return fmt.Errorf("mask size %dx%d does not match band size %dx%d", mst.SizeX, mst.SizeY, st.SizeX, st.SizeY)
+	}
+	ioopts := []BandIOOption{}
+	if len(so.config) > 0 {
+		ioopts = append(ioopts, ConfigOption(so.config...))
+	}
+	if so.errorHandler != nil {
+		ioopts = append(ioopts, ErrLogger(so.errorHandler))
+	}
+	mbuf := make([]byte, st.BlockSizeX*st.BlockSizeY)
+	dbuf := make([]float64, st.BlockSizeX*st.BlockSizeY)
+	for blk, ok := st.FirstBlock(), true; ok; blk, ok = blk.Next() {
+		if err := mask.Read(blk.X0, blk.Y0, mbuf, blk.W, blk.H, ioopts...); err != nil {
+			return err
+		}
+		masked := false
+		for _, m := range mbuf[:blk.W*blk.H] {
+			if m == 0 {
+				masked = true
+				break
+			}
+		}
+		if !masked {
+			continue
+		}
+		if err := band.Read(blk.X0, blk.Y0, dbuf, blk.W, blk.H, ioopts...); err != nil {
+			return err
+		}
+		for i, m := range mbuf[:blk.W*blk.H] {
+			if m == 0 {
+				dbuf[i] = nodata
+			}
+		}
+		if err := band.Write(blk.X0, blk.Y0, dbuf, blk.W, blk.H, ioopts...); err != nil {
+			return err
+		}
+	}
+	cgc := createCGOContext(so.config, so.errorHandler)
+	C.godalSetRasterNoDataValue(cgc.cPointer(), band.handle(), C.double(nodata))
+	return cgc.close()
+}
+
 // ClearNoData clears the band's nodata value
 func (band Band) ClearNoData(opts ...SetNoDataOption) error {
 	sndo := &setNodataOpts{}
@@ -224,11 +322,62 @@ func (band Band) SetScaleOffset(scale, offset float64, opts ...SetScaleOffsetOpt
 	return cgc.close()
 }
 
+// ScaleOffset returns the band's scale and offset, along with flags telling whether they
+// are actually defined on the band. Contrary to BandStructure, this allows to distinguish an
+// unset scale from a scale explicitly set to 1 (resp. an offset explicitly set to 0).
+//
+// Note that some drivers always report the scale and offset as set.
+func (band Band) ScaleOffset() (scale, offset float64, scaleSet, offsetSet bool) {
+	var cScaleSet, cOffsetSet C.int
+	scale = float64(C.GDALGetRasterScale(band.handle(), &cScaleSet))
+	offset = float64(C.GDALGetRasterOffset(band.handle(), &cOffsetSet))
+	return scale, offset, cScaleSet != 0, cOffsetSet != 0
+}
+
 // ClearScaleOffset clears the band's scale and offset
 func (band Band) ClearScaleOffset(opts ...SetScaleOffsetOption) error {
 	return band.SetScaleOffset(1.0, 0.0, opts...)
 }
 
+// UnitType returns the band's unit, e.g. "m" or "K". An empty string is returned
+// if the band has no unit.
+func (band Band) UnitType() string {
+	return C.GoString(C.GDALGetRasterUnitType(band.handle()))
+}
+
+// SetUnitType sets the band's unit. Passing an empty string clears the unit.
+func (band Band) SetUnitType(unit string, opts ...SetUnitTypeOption) error {
+	so := &setUnitTypeOpts{}
+	for _, opt := range opts {
+		opt.setSetUnitTypeOpt(so)
+	}
+	cunit := C.CString(unit)
+	defer C.free(unsafe.Pointer(cunit))
+	cgc := createCGOContext(nil, so.errorHandler)
+	C.godalSetRasterUnitType(cgc.cPointer(), band.handle(), cunit)
+	return cgc.close()
+}
+
+// CategoryNames returns the names of the band's categories, i.e. the label of each
+// pixel value starting from 0. nil is returned if the band has no category names.
+func (band Band) CategoryNames() []string {
+	return cStringArrayToSlice(C.GDALGetRasterCategoryNames(band.handle()))
+}
+
+// SetCategoryNames sets the names of the band's categories, names[i] being the label of
+// pixels of value i. Passing an empty slice clears the category names.
+func (band Band) SetCategoryNames(names []string, opts ...SetCategoryNamesOption) error {
+	so := &setCategoryNamesOpts{}
+	for _, opt := range opts {
+		opt.setSetCategoryNamesOpt(so)
+	}
+	cnames := sliceToCStringArray(names)
+	defer cnames.free()
+	cgc := createCGOContext(nil, so.errorHandler)
+	C.godalSetRasterCategoryNames(cgc.cPointer(), band.handle(), cnames.cPointer())
+	return cgc.close()
+}
+
 // ColorInterp returns the band's color interpretation (defaults to Gray)
 func (band Band) ColorInterp() ColorInterp {
 	colorInterp := C.GDALGetRasterColorInterpretation(band.handle())
@@ -247,12 +396,50 @@ func (band Band) SetColorInterp(colorInterp ColorInterp, opts ...SetColorInterpO
 	return cgc.close()
 }
 
+// MaskFlags is a bitmask describing how the mask band of a band is obtained, as returned
+// by Band.MaskFlags.
+//
+// See https://gdal.org/development/rfc/rfc15_nodatabitmask.html for how these flags
+// should be interpreted
+type MaskFlags int
+
+const (
+	// GMF_ALL_VALID is set if all pixels of the band are valid, i.e. there is no mask
+	GMF_ALL_VALID MaskFlags = C.GMF_ALL_VALID
+	// GMF_PER_DATASET is set if the mask band is shared between all bands of the dataset
+	GMF_PER_DATASET MaskFlags = C.GMF_PER_DATASET
+	// GMF_ALPHA is set if the mask band is actually an alpha band
+	GMF_ALPHA MaskFlags = C.GMF_ALPHA
+	// GMF_NODATA is set if the mask is computed from the band's nodata value
+	GMF_NODATA MaskFlags = C.GMF_NODATA
+)
+
+// IsAllValid returns true if the GMF_ALL_VALID flag is set
+func (mf MaskFlags) IsAllValid() bool {
+	return mf&GMF_ALL_VALID != 0
+}
+
+// IsPerDataset returns true if the GMF_PER_DATASET flag is set
+func (mf MaskFlags) IsPerDataset() bool {
+	return mf&GMF_PER_DATASET != 0
+}
+
+// IsAlpha returns true if the GMF_ALPHA flag is set
+func (mf MaskFlags) IsAlpha() bool {
+	return mf&GMF_ALPHA != 0
+}
+
+// IsNodata returns true if the GMF_NODATA flag is set
+func (mf MaskFlags) IsNodata() bool {
+	return mf&GMF_NODATA != 0
+}
+
 // MaskFlags returns the mask flags associated with this band.
 //
 // See https://gdal.org/development/rfc/rfc15_nodatabitmask.html for how this flag
 // should be interpreted
-func (band Band) MaskFlags() int {
-	return int(C.GDALGetMaskFlags(band.handle()))
+func (band Band) MaskFlags() MaskFlags {
+	return MaskFlags(C.GDALGetMaskFlags(band.handle()))
 }
 
 // MaskBand returns the mask (nodata) band for this band. May be generated from nodata values.
@@ -265,7 +452,7 @@ func (band Band) MaskBand() Band {
 //
 // Any handle returned by a previous call to MaskBand() should not be used after a call to CreateMask
 // See https://gdal.org/development/rfc/rfc15_nodatabitmask.html for how flag should be used
-func (band Band) CreateMask(flags int, opts ...BandCreateMaskOption) (Band, error) {
+func (band Band) CreateMask(flags MaskFlags, opts ...BandCreateMaskOption) (Band, error) {
 	gopts := bandCreateMaskOpts{}
 	for _, opt := range opts {
 		opt.setBandCreateMaskOpt(&gopts)
@@ -311,8 +498,24 @@ func (band Band) IO(rw IOOperation, srcX, srcY int, buffer interface{}, bufWidth
 	if ro.dsWidth == 0 {
 		ro.dsWidth = bufWidth
 	}
+	var cWinF *C.double
+	if ro.srcWindowF != nil {
+		srcX, srcY, ro.dsWidth, ro.dsHeight = integerWindow(*ro.srcWindowF)
+		cWinF = cDoubleArray(ro.srcWindowF[:])
+	}
 	dtype := bufferType(buffer)
 	dsize := dtype.Size()
+	if ro.scaled && (rw != IORead || (dtype != Float32 && dtype != Float64)) {
+		return fmt.Errorf("scaled io is only supported for reads into float32 or float64 buffers")
+	}
+	if ro.mask != nil {
+		if rw != IORead {
+			return fmt.Errorf("mask io is only supported for reads")
+		}
+		if len(ro.mask) < bufWidth*bufHeight {
+			return fmt.Errorf("mask buffer too small: %d < %d", len(ro.mask), bufWidth*bufHeight)
+		}
+	}
 
 	pixelSpacing := dsize
 	if ro.pixelSpacing > 0 {
@@ -336,19 +539,308 @@ func (band Band) IO(rw IOOperation, srcX, srcY int, buffer interface{}, bufWidth
 	if err != nil {
 		return err
 	}
-	cgc := createCGOContext(ro.config, ro.errorHandler)
+	if ro.overviewUsed != nil {
+		*ro.overviewUsed = false
+		if rw == IORead && (bufWidth < ro.dsWidth || bufHeight < ro.dsHeight) {
+			st := band.Structure()
+			samples := (uint64(st.SizeX) * uint64(bufWidth) / uint64(ro.dsWidth)) *
+				(uint64(st.SizeY) * uint64(bufHeight) / uint64(ro.dsHeight))
+			ovr := C.GDALGetRasterSampleOverviewEx(band.handle(), C.GUIntBig(samples))
+			*ro.overviewUsed = ovr != band.handle()
+		}
+	}
+	cgc, err := createIOContext(ro.readContext, ro.config, ro.errorHandler)
+	if err != nil {
+		return err
+	}
 	C.godalBandRasterIO(cgc.cPointer(), band.handle(), C.GDALRWFlag(rw),
 		C.int(srcX), C.int(srcY), C.int(ro.dsWidth), C.int(ro.dsHeight),
 		cBuf,
 		C.int(bufWidth), C.int(bufHeight), C.GDALDataType(dtype),
-		C.int(pixelSpacing), C.int(lineSpacing), ralg)
-	return cgc.close()
+		C.int(pixelSpacing), C.int(lineSpacing), ralg, cWinF)
+	if err := cgc.close(); err != nil {
+		return err
+	}
+	if ro.scaled {
+		band.applyScaleOffset(buffer, bufWidth, bufHeight, pixelSpacing/dsize, lineSpacing/dsize)
+	}
+	if ro.mask != nil {
+		//read the mask over the exact same window as the data, which may be fractional
+		mopts := []BandIOOption{Window(ro.dsWidth, ro.dsHeight)}
+		if ro.srcWindowF != nil {
+			w := *ro.srcWindowF
+			mopts = []BandIOOption{SourceWindowF(w[0], w[1], w[2], w[3])}
+		}
+		if len(ro.config) > 0 {
+			mopts = append(mopts, ConfigOption(ro.config...))
+		}
+		if ro.errorHandler != nil {
+			mopts = append(mopts, ErrLogger(ro.errorHandler))
+		}
+		return band.MaskBand().IO(IORead, srcX, srcY, ro.mask, bufWidth, bufHeight, mopts...)
+	}
+	return nil
+}
+
+// applyScaleOffset converts the raw values read into buffer to physical values
+func (band Band) applyScaleOffset(buffer interface{}, bufWidth, bufHeight, pixelStride, lineStride int) {
+	scale := float64(C.GDALGetRasterScale(band.handle(), nil))
+	offset := float64(C.GDALGetRasterOffset(band.handle(), nil))
+	if scale == 1 && offset == 0 {
+		return
+	}
+	nd, hasNoData := band.NoData()
+	for y := 0; y < bufHeight; y++ {
+		for x := 0; x < bufWidth; x++ {
+			i := y*lineStride + x*pixelStride
+			switch buf := buffer.(type) {
+			case []float32:
+				if !hasNoData || float64(buf[i]) != float64(float32(nd)) {
+					buf[i] = float32(float64(buf[i])*scale + offset)
+				}
+			case []float64:
+				if !hasNoData || buf[i] != nd {
+					buf[i] = buf[i]*scale + offset
+				}
+			}
+		}
+	}
+}
+
+// ReadNative reads the w*h pixels of the window starting at srcX,srcY into a newly
+// allocated slice matching the band's datatype (e.g. []uint16 for a UInt16 band), and
+// returns it along with that datatype. Window and Resampling options can be used to read
+// a downsampled version of a larger window. Options altering the buffer layout (spacings
+// and strides) are not supported.
+//
+// Bands of complex integer types are not supported.
+func (band Band) ReadNative(srcX, srcY, w, h int, opts ...BandIOOption) (interface{}, DataType, error) {
+	ro := bandIOOpts{}
+	for _, opt := range opts {
+		opt.setBandIOOpt(&ro)
+	}
+	if ro.pixelSpacing > 0 || ro.lineSpacing > 0 || ro.pixelStride > 0 || ro.lineStride > 0 {
+		return nil, Unknown, fmt.Errorf("spacing and stride options are not supported")
+	}
+	dtype := band.Structure().DataType
+	buffer, err := newBuffer(dtype, w*h)
+	if err != nil {
+		return nil, Unknown, err
+	}
+	if err = band.Read(srcX, srcY, buffer, w, h, opts...); err != nil {
+		return nil, Unknown, err
+	}
+	return buffer, dtype, nil
+}
+
+// EachScanline reads the band one scanline at a time into a buffer of the band's native
+// datatype (as returned by ReadNative), and calls fn with the row index and that buffer.
+// Iteration stops at the first error returned by fn or by the underlying read, which
+// is returned.
+//
+// For striped rasters, the rows of each strip are read in a single call, so that the
+// band is read sequentially without loading it entirely in memory. row is reused
+// between calls to fn and must not be retained. opts are passed to each underlying
+// Band.Read call, and should not alter the buffer layout or the read window.
+func (band Band) EachScanline(fn func(y int, row interface{}) error, opts ...BandIOOption) error {
+	st := band.Structure()
+	rowsPerRead := 1
+	if st.BlockSizeX == st.SizeX && st.BlockSizeY > 1 {
+		rowsPerRead = st.BlockSizeY
+	}
+	buf, err := newBuffer(st.DataType, st.SizeX*rowsPerRead)
+	if err != nil {
+		return err
+	}
+	for y := 0; y < st.SizeY; y += rowsPerRead {
+		nrows := rowsPerRead
+		if y+nrows > st.SizeY {
+			nrows = st.SizeY - y
+		}
+		if err := band.Read(0, y, buf, st.SizeX, nrows, opts...); err != nil {
+			return err
+		}
+		for r := 0; r < nrows; r++ {
+			row := sliceBuffer(buf, r*st.SizeX, (r+1)*st.SizeX)
+			if err := fn(y+r, row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ProcessBlocks iterates over the blocks of the dataset in scanline order (i.e. starting
+// from Structure().FirstBlock()), reads each of them into a buffer of the dataset's native
+// datatype (as returned by ReadNative), and calls fn with the block and that buffer.
+// Iteration stops at the first error returned by fn or by the underlying reads and
+// writes, which is returned.
+//
+// The buffer passed to fn is sized for the actual block (i.e. block.W*block.H pixels for
+// each band, edge blocks being smaller than the nominal block size), and is pixel
+// interleaved unless the BandInterleaved option is used. It is reused between calls to
+// fn and must not be retained.
+//
+// With the WriteBack option, the buffer is written back to the dataset after each call
+// to fn, so that fn can modify it to transform the dataset in place.
+//
+// With the Parallel option, blocks are read and processed concurrently, in which case fn
+// (and the ErrLogger handler) must be safe for concurrent use.
+func (ds *Dataset) ProcessBlocks(fn func(block Block, data interface{}) error, opts ...ProcessBlocksOption) error {
+	po := processBlocksOpts{}
+	for _, opt := range opts {
+		opt.setProcessBlocksOpt(&po)
+	}
+	st := ds.Structure()
+	nBands := st.NBands
+	if len(po.bands) > 0 {
+		nBands = len(po.bands)
+	}
+	if nBands == 0 {
+		return fmt.Errorf("cannot process dataset with no bands")
+	}
+	buf, err := newBuffer(st.DataType, st.BlockSizeX*st.BlockSizeY*nBands)
+	if err != nil {
+		return err
+	}
+	ioopts := []DatasetIOOption{}
+	if len(po.bands) > 0 {
+		ioopts = append(ioopts, bandOpt{po.bands})
+	}
+	if po.bandInterleave {
+		ioopts = append(ioopts, BandInterleaved())
+	}
+	if len(po.config) > 0 {
+		ioopts = append(ioopts, ConfigOption(po.config...))
+	}
+	if po.errorHandler != nil {
+		ioopts = append(ioopts, ErrLogger(po.errorHandler))
+	}
+	if po.parallel > 1 {
+		return ds.processBlocksParallel(fn, po, ioopts, nBands)
+	}
+	for blk, ok := st.FirstBlock(), true; ok; blk, ok = blk.Next() {
+		data := sliceBuffer(buf, 0, blk.W*blk.H*nBands)
+		if err := ds.Read(blk.X0, blk.Y0, data, blk.W, blk.H, ioopts...); err != nil {
+			return err
+		}
+		if err := fn(blk, data); err != nil {
+			return err
+		}
+		if po.writeBack {
+			if err := ds.Write(blk.X0, blk.Y0, data, blk.W, blk.H, ioopts...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// processBlocksParallel dispatches the blocks of ds to po.parallel workers. As gdal
+// dataset handles cannot be used concurrently, each worker reads from its own read-only
+// handle on the dataset, and uses its own buffer.
+func (ds *Dataset) processBlocksParallel(fn func(block Block, data interface{}) error, po processBlocksOpts, ioopts []DatasetIOOption, nBands int) error {
+	if po.writeBack {
+		return fmt.Errorf("WriteBack cannot be used with Parallel")
+	}
+	st := ds.Structure()
+	handles := make([]*Dataset, 0, po.parallel)
+	defer func() {
+		for _, h := range handles {
+			_ = h.Close()
+		}
+	}()
+	vopts := []NewReadViewOption{}
+	if len(po.config) > 0 {
+		vopts = append(vopts, ConfigOption(po.config...))
+	}
+	if po.errorHandler != nil {
+		vopts = append(vopts, ErrLogger(po.errorHandler))
+	}
+	for i := 0; i < po.parallel; i++ {
+		h, err := ds.NewReadView(vopts...)
+		if err != nil {
+			return err
+		}
+		handles = append(handles, h)
+	}
+
+	var (
+		firstErr error
+		once     sync.Once
+		wg       sync.WaitGroup
+	)
+	blocks := make(chan Block)
+	done := make(chan struct{})
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			close(done)
+		})
+	}
+	for _, h := range handles {
+		wg.Add(1)
+		go func(h *Dataset) {
+			defer wg.Done()
+			buf, _ := newBuffer(st.DataType, st.BlockSizeX*st.BlockSizeY*nBands) //datatype checked by caller
+			for blk := range blocks {
+				select {
+				case <-done:
+					continue
+				default:
+				}
+				data := sliceBuffer(buf, 0, blk.W*blk.H*nBands)
+				if err := h.Read(blk.X0, blk.Y0, data, blk.W, blk.H, ioopts...); err != nil {
+					fail(err)
+					continue
+				}
+				if err := fn(blk, data); err != nil {
+					fail(err)
+				}
+			}
+		}(h)
+	}
+dispatch:
+	for blk, ok := st.FirstBlock(), true; ok; blk, ok = blk.Next() {
+		select {
+		case blocks <- blk:
+		case <-done:
+			break dispatch
+		}
+	}
+	close(blocks)
+	wg.Wait()
+	return firstErr
+}
+
+// NewReadView opens a new, independent, read-only handle on the dataset, by reopening
+// its name with the same driver. The returned dataset can be used from another goroutine
+// than ds, and must be closed independently.
+//
+// A gdal dataset handle must never be used concurrently by several goroutines: concurrent
+// reads should be done through one NewReadView per goroutine. Note that the view is not
+// opened with Shared(), as gdal may then return the handle already opened by the calling
+// thread. The view does not reflect modifications of ds that have not been flushed, and
+// open options used when opening ds (e.g. SiblingFiles or DriverOpenOption) must be passed
+// again in opts. In-memory datasets cannot be reopened and result in an error.
+func (ds *Dataset) NewReadView(opts ...NewReadViewOption) (*Dataset, error) {
+	nvo := newReadViewOpts{}
+	for _, opt := range opts {
+		opt.setNewReadViewOpt(&nvo)
+	}
+	name := ds.Description()
+	drv := ds.Driver()
+	if name == "" || drv.ShortName() == "MEM" {
+		return nil, fmt.Errorf("cannot reopen unnamed or in-memory dataset")
+	}
+	return Open(name, append(nvo.open, Drivers(drv.ShortName()))...)
 }
 
 // Polygonize wraps GDALPolygonize
 func (band Band) Polygonize(dstLayer Layer, opts ...PolygonizeOption) error {
 	popt := polygonizeOpts{
 		pixFieldIndex: -1,
+		connectedness: 4,
 	}
 	maskBand := band.MaskBand()
 	popt.mask = &maskBand
@@ -356,6 +848,19 @@ func (band Band) Polygonize(dstLayer Layer, opts ...PolygonizeOption) error {
 	for _, opt := range opts {
 		opt.setPolygonizeOpt(&popt)
 	}
+	switch popt.connectedness {
+	case 4:
+	case 8:
+		popt.options = append(popt.options, "8connected=yes")
+	default:
+		return fmt.Errorf("invalid connectedness %d, must be 4 or 8", popt.connectedness)
+	}
+	if popt.pixFieldName != "" {
+		popt.pixFieldIndex = dstLayer.FieldIndex(popt.pixFieldName)
+		if popt.pixFieldIndex < 0 {
+			return fmt.Errorf("layer has no field named %s", popt.pixFieldName)
+		}
+	}
 	copts := sliceToCStringArray(popt.options)
 	defer copts.free()
 	var cMaskBand C.GDALRasterBandH = nil
@@ -368,6 +873,51 @@ func (band Band) Polygonize(dstLayer Layer, opts ...PolygonizeOption) error {
 	return cgc.close()
 }
 
+// Polygonize creates polygons in dstLayer for the connected regions of pixels sharing
+// the same value in the dataset's band'th band (0-based, as for Bands()). It is
+// equivalent to ds.Bands()[band].Polygonize(dstLayer, opts...).
+//
+// Use PixelValueFieldName to write each polygon's pixel value into a named field of
+// dstLayer.
+func (ds *Dataset) Polygonize(band int, dstLayer Layer, opts ...PolygonizeOption) error {
+	bands := ds.Bands()
+	if band < 0 || band >= len(bands) {
+		return fmt.Errorf("invalid band %d for dataset with %d bands", band, len(bands))
+	}
+	return bands[band].Polygonize(dstLayer, opts...)
+}
+
+// Contour generates contour lines from the band and writes them as linestrings to
+// dstLayer. It wraps GDALContourGenerateEx(), and requires either the ContourInterval or
+// the FixedLevels option.
+//
+// The band's nodata value, if any, is used to ignore pixels unless the NoData option
+// is given.
+func (band Band) Contour(dstLayer Layer, opts ...ContourOption) error {
+	copt := contourOpts{
+		elevField: -1,
+		idField:   -1,
+	}
+	if nd, ok := band.NoData(); ok {
+		copt.nodata = &nd
+	}
+	for _, opt := range opts {
+		opt.setContourOpt(&copt)
+	}
+	if copt.interval == 0 && len(copt.fixedLevels) == 0 {
+		return fmt.Errorf("either ContourInterval or FixedLevels must be set")
+	}
+	if copt.interval < 0 {
+		return fmt.Errorf("invalid contour interval %g", copt.interval)
+	}
+	copts := sliceToCStringArray(copt.options())
+	defer copts.free()
+
+	cgc := createCGOContext(nil, copt.errorHandler)
+	C.godalContourGenerate(cgc.cPointer(), band.handle(), dstLayer.handle(), copts.cPointer())
+	return cgc.close()
+}
+
 // FillNoData wraps GDALFillNodata()
 func (band Band) FillNoData(opts ...FillNoDataOption) error {
 	popt := fillnodataOpts{
@@ -390,6 +940,84 @@ func (band Band) FillNoData(opts ...FillNoDataOption) error {
 	return cgc.close()
 }
 
+// Checksum computes a 16 bit checksum of the pixels of the band inside the window
+// starting at x,y and spanning w*h pixels. If w (resp. h) is 0, the window extends
+// to the right (resp. bottom) edge of the band, i.e. Checksum(0,0,0,0) computes the
+// checksum of the whole band.
+func (band Band) Checksum(x, y, w, h int, opts ...ChecksumOption) (int, error) {
+	co := checksumOpts{}
+	for _, opt := range opts {
+		opt.setChecksumOpt(&co)
+	}
+	if w == 0 || h == 0 {
+		st := band.Structure()
+		if w == 0 {
+			w = st.SizeX - x
+		}
+		if h == 0 {
+			h = st.SizeY - y
+		}
+	}
+	cgc := createCGOContext(nil, co.errorHandler)
+	ret := C.godalChecksumImage(cgc.cPointer(), band.handle(), C.int(x), C.int(y), C.int(w), C.int(h))
+	if err := cgc.close(); err != nil {
+		return 0, err
+	}
+	return int(ret), nil
+}
+
+// ProximityRaster computes a proximity (distance) map to the pixels of band whose value is
+// one of targetValues, and returns it as a single band Float32 in-memory dataset. If targetValues
+// is empty, the TargetValues option is used, and if not set either all non-zero pixels are
+// considered as targets.
+//
+// The geotransform and projection of the band's dataset are copied to the returned dataset.
+func (band Band) ProximityRaster(targetValues []float64, opts ...ProximityOption) (*Dataset, error) {
+	popt := proximityOpts{}
+	for _, opt := range opts {
+		opt.setProximityOpt(&popt)
+	}
+	st := band.Structure()
+	ds, err := Create(Memory, "", 1, Float32, st.SizeX, st.SizeY, ErrLogger(popt.errorHandler))
+	if err != nil {
+		return nil, err
+	}
+	if hsrc := C.GDALGetBandDataset(band.handle()); hsrc != nil {
+		src := Dataset{majorObject{C.GDALMajorObjectH(hsrc)}}
+		if gt, err := src.GeoTransform(); err == nil {
+			_ = ds.SetGeoTransform(gt)
+		}
+		if wkt := src.Projection(); wkt != "" {
+			_ = ds.SetProjection(wkt)
+		}
+	}
+
+	if len(targetValues) > 0 {
+		opts = append(opts[:len(opts):len(opts)], TargetValues(targetValues...))
+	}
+	if err := band.ComputeProximity(ds.Bands()[0], opts...); err != nil {
+		ds.Close()
+		return nil, err
+	}
+	return ds, nil
+}
+
+// ComputeProximity computes a proximity (distance) map to the target pixels of band (as
+// set by the TargetValues option, or all non-zero pixels by default) and writes it to
+// dstBand, which must have the same size as band. It wraps GDALComputeProximity.
+func (band Band) ComputeProximity(dstBand Band, opts ...ProximityOption) error {
+	popt := proximityOpts{}
+	for _, opt := range opts {
+		opt.setProximityOpt(&popt)
+	}
+	copts := sliceToCStringArray(popt.options())
+	defer copts.free()
+
+	cgc := createCGOContext(nil, popt.errorHandler)
+	C.godalComputeProximity(cgc.cPointer(), band.handle(), dstBand.handle(), copts.cPointer())
+	return cgc.close()
+}
+
 // SieveFilter wraps GDALSieveFilter
 func (band Band) SieveFilter(sizeThreshold int, opts ...SieveFilterOption) error {
 	sfopt := sieveFilterOpts{
@@ -402,6 +1030,9 @@ func (band Band) SieveFilter(sizeThreshold int, opts ...SieveFilterOption) error
 	for _, opt := range opts {
 		opt.setSieveFilterOpt(&sfopt)
 	}
+	if sfopt.connectedness != 4 && sfopt.connectedness != 8 {
+		return fmt.Errorf("invalid connectedness %d, must be 4 or 8", sfopt.connectedness)
+	}
 	var cMaskBand C.GDALRasterBandH = nil
 	if sfopt.mask != nil {
 		cMaskBand = sfopt.mask.handle()
@@ -460,47 +1091,222 @@ func (band Band) Histogram(opts ...HistogramOption) (Histogram, error) {
 	return h, nil
 }
 
-// GetStatistics returns if present and flag as true.
+// GetDefaultHistogram returns the default histogram of the band, if one has been
+// previously computed or stored (e.g. in an .aux.xml sidecar), and true.
 //
-// Only cached statistics are returned and no new statistics are computed.
-// Return false and no error if no statistics are availables.
-// Available options are:
-// - Aproximate() to allow the satistics to be computed on overviews or a subset of all tiles.
-// - ErrLogger
-func (band Band) GetStatistics(opts ...StatisticsOption) (Statistics, bool, error) {
-	sopt := statisticsOpts{}
-	for _, s := range opts {
-		s.setStatisticsOpt(&sopt)
+// No new histogram is computed. Returns false and no error if no default histogram
+// is available.
+func (band Band) GetDefaultHistogram(opts ...DefaultHistogramOption) (Histogram, bool, error) {
+	hopt := defaultHistogramOpts{}
+	for _, o := range opts {
+		o.setDefaultHistogramOpt(&hopt)
 	}
-	var min, max, mean, std C.double
-	cgc := createCGOContext(nil, sopt.errorHandler)
-	ret := C.godalGetRasterStatistics(cgc.cPointer(), band.handle(),
-		(C.int)(sopt.approx), &min, &max, &mean, &std)
+	var values *C.ulonglong = nil
+	// values is allocated by gdal, so it must be freed once the call has returned
+	defer func() { C.VSIFree(unsafe.Pointer(values)) }()
+	var min, max C.double
+	var buckets C.int
+
+	cgc := createCGOContext(nil, hopt.errorHandler)
+	ret := C.godalGetDefaultHistogram(cgc.cPointer(), band.handle(), &min, &max, &buckets, &values)
 	if err := cgc.close(); err != nil {
-		return Statistics{}, false, err
+		return Histogram{}, false, err
 	}
 	if ret == 0 {
-		return Statistics{}, false, nil
+		return Histogram{}, false, nil
 	}
-	var ap bool = sopt.approx != 0
-	s := Statistics{
-		Approximate: ap,
-		Min:         float64(min),
-		Max:         float64(max),
-		Mean:        float64(mean),
-		Std:         float64(std),
+	counts := (*[1 << 30]C.ulonglong)(unsafe.Pointer(values))
+	h := Histogram{
+		min:    float64(min),
+		max:    float64(max),
+		counts: make([]uint64, int(buckets)),
 	}
-	return s, true, nil
+	for i := 0; i < int(buckets); i++ {
+		h.counts[i] = uint64(counts[i])
+	}
+	return h, true, nil
 }
 
-// ComputeStatistics returns from exact computation or approximation.
-//
-// Band full scan might be necessary.
-// Available options are:
-// - Aproximate() to allow the satistics to be computed on overviews or a subset of all tiles.
-// - ErrLogger
-func (band Band) ComputeStatistics(opts ...StatisticsOption) (Statistics, error) {
-	sopt := statisticsOpts{}
+// SetDefaultHistogram sets the default histogram of the band. The histogram spans
+// [min,max] with len(counts) buckets. Depending on the driver, it will be persisted
+// in the dataset or in an .aux.xml sidecar file.
+func (band Band) SetDefaultHistogram(min, max float64, counts []uint64, opts ...SetDefaultHistogramOption) error {
+	if len(counts) == 0 {
+		return fmt.Errorf("cannot set empty histogram")
+	}
+	hopt := setDefaultHistogramOpts{}
+	for _, o := range opts {
+		o.setSetDefaultHistogramOpt(&hopt)
+	}
+	ccounts := make([]C.ulonglong, len(counts))
+	for i := range counts {
+		ccounts[i] = C.ulonglong(counts[i])
+	}
+	cgc := createCGOContext(nil, hopt.errorHandler)
+	C.godalSetDefaultHistogram(cgc.cPointer(), band.handle(), C.double(min), C.double(max),
+		C.int(len(counts)), (*C.ulonglong)(unsafe.Pointer(&ccounts[0])))
+	return cgc.close()
+}
+
+// AutoStretch computes contrast stretch bounds for the band, i.e. the values under which
+// lowPct percent (resp. over which 100-highPct percent) of the valid pixels fall.
+// Typical values for lowPct and highPct are 2 and 98.
+//
+// The bounds are derived from a histogram of the band which is exact for integer data
+// types spanning less than 65536 values, and uses 4096 buckets otherwise.
+func (band Band) AutoStretch(lowPct, highPct float64, opts ...AutoStretchOption) (min, max float64, err error) {
+	if lowPct < 0 || highPct > 100 || lowPct >= highPct {
+		return 0, 0, fmt.Errorf("invalid percentiles %g/%g", lowPct, highPct)
+	}
+	ao := autoStretchOpts{}
+	for _, o := range opts {
+		o.setAutoStretchOpt(&ao)
+	}
+	minmax := [2]C.double{}
+	cgc := createCGOContext(nil, ao.errorHandler)
+	C.godalComputeRasterMinMax(cgc.cPointer(), band.handle(), C.int(ao.approx), &minmax[0])
+	if err = cgc.close(); err != nil {
+		return 0, 0, err
+	}
+	vmin, vmax := float64(minmax[0]), float64(minmax[1])
+	if vmin == vmax {
+		return vmin, vmax, nil
+	}
+
+	var hopts []HistogramOption
+	switch band.Structure().DataType {
+	case Byte, Int8, UInt16, Int16, UInt32, Int32:
+		if vmax-vmin < 65536 {
+			//one bucket per integer value
+			hopts = append(hopts, Intervals(int(vmax-vmin)+1, vmin-0.5, vmax+0.5))
+			break
+		}
+		fallthrough
+	default:
+		hopts = append(hopts, Intervals(4096, vmin, vmax), IncludeOutOfRange())
+	}
+	if ao.approx != 0 {
+		hopts = append(hopts, Approximate())
+	}
+	if ao.errorHandler != nil {
+		hopts = append(hopts, ErrLogger(ao.errorHandler))
+	}
+	hist, err := band.Histogram(hopts...)
+	if err != nil {
+		return 0, 0, err
+	}
+	total := uint64(0)
+	for _, c := range hist.counts {
+		total += c
+	}
+	if total == 0 {
+		return 0, 0, fmt.Errorf("no valid pixels")
+	}
+	center := func(i int) float64 {
+		b := hist.Bucket(i)
+		return (b.Min + b.Max) / 2
+	}
+	lowCount, highCount := float64(total)*lowPct/100, float64(total)*highPct/100
+	min, max = vmin, vmax
+	cum := uint64(0)
+	lowFound := false
+	for i, c := range hist.counts {
+		cum += c
+		if !lowFound && float64(cum) > lowCount {
+			min = center(i)
+			lowFound = true
+		}
+		if float64(cum) >= highCount {
+			max = center(i)
+			break
+		}
+	}
+	return min, max, nil
+}
+
+// StretchToByte creates an in-memory Byte dataset suitable for display, where the values
+// of each band are linearly rescaled from the bounds returned by Band.AutoStretch(lowPct,highPct)
+// to [0,255]. Values outside the bounds are clipped.
+func (ds *Dataset) StretchToByte(lowPct, highPct float64, opts ...AutoStretchOption) (*Dataset, error) {
+	ao := autoStretchOpts{}
+	for _, o := range opts {
+		o.setAutoStretchOpt(&ao)
+	}
+	switches := []string{"-ot", "Byte"}
+	for i, band := range ds.Bands() {
+		min, max, err := band.AutoStretch(lowPct, highPct, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("band %d: %w", i+1, err)
+		}
+		switches = append(switches, fmt.Sprintf("-scale_%d", i+1),
+			strconv.FormatFloat(min, 'g', -1, 64), strconv.FormatFloat(max, 'g', -1, 64), "0", "255")
+	}
+	topts := []DatasetTranslateOption{Memory}
+	if ao.errorHandler != nil {
+		topts = append(topts, ErrLogger(ao.errorHandler))
+	}
+	return ds.Translate("", switches, topts...)
+}
+
+// AutoStretch creates an in-memory Byte visualization dataset where the values of each
+// band are linearly rescaled to [0,255] between their 2nd and 98th percentiles, as
+// computed from the band's histogram. The percentiles can be changed with the
+// Percentiles() option.
+//
+// It is equivalent to calling StretchToByte(2,98).
+func (ds *Dataset) AutoStretch(opts ...AutoStretchOption) (*Dataset, error) {
+	ao := autoStretchOpts{}
+	for _, o := range opts {
+		o.setAutoStretchOpt(&ao)
+	}
+	low, high := 2.0, 98.0
+	if ao.percentiles != nil {
+		low, high = ao.percentiles[0], ao.percentiles[1]
+	}
+	return ds.StretchToByte(low, high, opts...)
+}
+
+// GetStatistics returns if present and flag as true.
+//
+// Only cached statistics are returned and no new statistics are computed.
+// Return false and no error if no statistics are availables.
+// Available options are:
+// - Aproximate() to allow the satistics to be computed on overviews or a subset of all tiles.
+// - ErrLogger
+func (band Band) GetStatistics(opts ...StatisticsOption) (Statistics, bool, error) {
+	sopt := statisticsOpts{}
+	for _, s := range opts {
+		s.setStatisticsOpt(&sopt)
+	}
+	var min, max, mean, std C.double
+	cgc := createCGOContext(nil, sopt.errorHandler)
+	ret := C.godalGetRasterStatistics(cgc.cPointer(), band.handle(),
+		(C.int)(sopt.approx), &min, &max, &mean, &std)
+	if err := cgc.close(); err != nil {
+		return Statistics{}, false, err
+	}
+	if ret == 0 {
+		return Statistics{}, false, nil
+	}
+	var ap bool = sopt.approx != 0
+	s := Statistics{
+		Approximate: ap,
+		Min:         float64(min),
+		Max:         float64(max),
+		Mean:        float64(mean),
+		Std:         float64(std),
+	}
+	return s, true, nil
+}
+
+// ComputeStatistics returns from exact computation or approximation.
+//
+// Band full scan might be necessary.
+// Available options are:
+// - Aproximate() to allow the satistics to be computed on overviews or a subset of all tiles.
+// - ErrLogger
+func (band Band) ComputeStatistics(opts ...StatisticsOption) (Statistics, error) {
+	sopt := statisticsOpts{}
 	for _, s := range opts {
 		s.setStatisticsOpt(&sopt)
 	}
@@ -780,7 +1586,7 @@ func (ds *Dataset) Bounds(opts ...BoundsOption) ([4]float64, error) {
 	if bo.sr != nil {
 		srcsr := ds.SpatialRef()
 		defer srcsr.Close()
-		ret, err = reprojectBounds(ret, srcsr, bo.sr)
+		ret, err = reprojectBounds(ret, srcsr, bo.sr, bo.densifyPts)
 		if err != nil {
 			return ret, err
 		}
@@ -798,7 +1604,7 @@ func (ds *Dataset) Bounds(opts ...BoundsOption) ([4]float64, error) {
 //
 // Any handle returned by a previous call to Band.MaskBand() should not be used after a call to CreateMaskBand
 // See https://gdal.org/development/rfc/rfc15_nodatabitmask.html for how flag should be used
-func (ds *Dataset) CreateMaskBand(flags int, opts ...DatasetCreateMaskOption) (Band, error) {
+func (ds *Dataset) CreateMaskBand(flags MaskFlags, opts ...DatasetCreateMaskOption) (Band, error) {
 	gopts := dsCreateMaskOpts{}
 	for _, opt := range opts {
 		opt.setDatasetCreateMaskOpt(&gopts)
@@ -811,11 +1617,64 @@ func (ds *Dataset) CreateMaskBand(flags int, opts ...DatasetCreateMaskOption) (B
 	return Band{majorObject{C.GDALMajorObjectH(hndl)}}, nil
 }
 
+// AddBand appends a new band of type dtype to the dataset, and returns it.
+//
+// Only some drivers (e.g. MEM, VRT or HFA) support adding bands to an existing
+// dataset, an error is returned for the others. Driver specific band options
+// (e.g. DATAPOINTER for MEM datasets) can be passed with CreationOption.
+func (ds *Dataset) AddBand(dtype DataType, opts ...AddBandOption) (Band, error) {
+	abo := addBandOpts{}
+	for _, opt := range opts {
+		opt.setAddBandOpt(&abo)
+	}
+	copts := sliceToCStringArray(abo.creation)
+	defer copts.free()
+	cgc := createCGOContext(abo.config, abo.errorHandler)
+	hndl := C.godalAddBand(cgc.cPointer(), ds.handle(), C.GDALDataType(dtype), copts.cPointer())
+	if err := cgc.close(); err != nil {
+		return Band{}, err
+	}
+	return Band{majorObject{C.GDALMajorObjectH(hndl)}}, nil
+}
+
 // Driver returns dataset driver.
 func (ds *Dataset) Driver() Driver {
 	return Driver{majorObject{C.GDALMajorObjectH(C.GDALGetDatasetDriver(ds.handle()))}}
 }
 
+// Subdataset is a subdataset of a container dataset (e.g. a variable of a netCDF or
+// HDF5 file), as advertised in the SUBDATASETS metadata domain.
+type Subdataset struct {
+	// Name is the gdal dataset name of the subdataset, e.g. NETCDF:"file.nc":var
+	Name string
+	// Desc is a human readable description of the subdataset
+	Desc string
+}
+
+// Open opens the subdataset. See the global Open function for the available options.
+func (sd Subdataset) Open(opts ...OpenOption) (*Dataset, error) {
+	return Open(sd.Name, opts...)
+}
+
+// Subdatasets returns the subdatasets of the dataset, parsed from the
+// SUBDATASET_N_NAME and SUBDATASET_N_DESC items of the SUBDATASETS metadata domain.
+// It returns nil if the dataset has no subdatasets.
+func (ds *Dataset) Subdatasets() []Subdataset {
+	md := ds.Metadatas(Domain("SUBDATASETS"))
+	var sds []Subdataset
+	for i := 1; ; i++ {
+		name, ok := md[fmt.Sprintf("SUBDATASET_%d_NAME", i)]
+		if !ok {
+			break
+		}
+		sds = append(sds, Subdataset{
+			Name: name,
+			Desc: md[fmt.Sprintf("SUBDATASET_%d_DESC", i)],
+		})
+	}
+	return sds
+}
+
 // Projection returns the WKT projection of the dataset. May be empty.
 func (ds *Dataset) Projection() string {
 	str := C.GDALGetProjectionRef(ds.handle())
@@ -906,6 +1765,54 @@ func (ds *Dataset) SetNoData(nd float64, opts ...SetNoDataOption) error {
 	return cgc.close()
 }
 
+// SetBandsNoData sets the nodata value of each of the dataset's bands individually, which
+// is more reliable than SetNoData for drivers that ignore dataset level nodata values.
+//
+// All bands are processed even if some of them fail, in which case the returned error
+// combines the errors of each failing band.
+func (ds *Dataset) SetBandsNoData(nd float64, opts ...SetNoDataOption) error {
+	var err error
+	for i, bnd := range ds.Bands() {
+		if berr := bnd.SetNoData(nd, opts...); berr != nil {
+			err = combine(err, fmt.Errorf("band %d: %w", i+1, berr))
+		}
+	}
+	return err
+}
+
+// ClearBandsNoData clears the nodata value of each of the dataset's bands.
+//
+// All bands are processed even if some of them fail, in which case the returned error
+// combines the errors of each failing band.
+func (ds *Dataset) ClearBandsNoData(opts ...SetNoDataOption) error {
+	var err error
+	for i, bnd := range ds.Bands() {
+		if berr := bnd.ClearNoData(opts...); berr != nil {
+			err = combine(err, fmt.Errorf("band %d: %w", i+1, berr))
+		}
+	}
+	return err
+}
+
+// SetColorInterps sets the color interpretation of all the dataset's bands. The number
+// of provided color interpretations must match the number of bands.
+//
+// The first warning or error raised when setting a band's color interpretation is returned,
+// and the remaining bands are left unchanged. Use an ErrLogger option to change which
+// messages are considered as errors.
+func (ds *Dataset) SetColorInterps(cis []ColorInterp, opts ...SetColorInterpOption) error {
+	bands := ds.Bands()
+	if len(cis) != len(bands) {
+		return fmt.Errorf("got %d color interpretations for %d bands", len(cis), len(bands))
+	}
+	for i, ci := range cis {
+		if err := bands[i].SetColorInterp(ci, opts...); err != nil {
+			return fmt.Errorf("band %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
 // SetScaleOffset sets the band's scale and offset
 func (ds *Dataset) SetScaleOffset(scale, offset float64, opts ...SetScaleOffsetOption) error {
 	setterOpts := &setScaleOffsetOpts{}
@@ -938,6 +1845,13 @@ func (ds *Dataset) Translate(dstDS string, switches []string, opts ...DatasetTra
 	for _, opt := range opts {
 		opt.setDatasetTranslateOpt(&gopts)
 	}
+	if gopts.compression != nil {
+		copts, err := gopts.compression.creationOptions(outputDriverName(gopts.driver, switches))
+		if err != nil {
+			return nil, err
+		}
+		gopts.creation = append(gopts.creation, copts...)
+	}
 	for _, copt := range gopts.creation {
 		switches = append(switches, "-co", copt)
 	}
@@ -961,6 +1875,242 @@ func (ds *Dataset) Translate(dstDS string, switches []string, opts ...DatasetTra
 	return &Dataset{majorObject{C.GDALMajorObjectH(hndl)}}, nil
 }
 
+var vsimemCounter uint64
+
+// TranslateToBytes runs the library version of gdal_translate like Translate, but
+// instead of writing to a named dataset it returns the content of the encoded file,
+// e.g. a PNG or JPEG image. The output driver should be given either in the switches
+// or with a DriverName option, and defaults to GTiff.
+//
+// The file is written to a uniquely named /vsimem/ location which is removed, along
+// with any sidecar file, before returning.
+func (ds *Dataset) TranslateToBytes(switches []string, opts ...DatasetTranslateOption) ([]byte, error) {
+	dir := fmt.Sprintf("/vsimem/godal_translate_%d", atomic.AddUint64(&vsimemCounter, 1))
+	cdir := C.CString(dir)
+	defer C.free(unsafe.Pointer(cdir))
+	defer C.VSIRmdirRecursive(cdir)
+
+	fname := dir + "/out"
+	tds, err := ds.Translate(fname, switches, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = tds.Close(); err != nil {
+		return nil, err
+	}
+	return VSIMemSteal(fname)
+}
+
+// Retile splits the dataset into tiles of tileW*tileH pixels (smaller on the
+// right and bottom edges) written inside outputDir, in the manner of gdal_retile.
+// It returns the names of the written files, in scanline order.
+//
+// Tiles are written with the GTiff driver unless a DriverName option is given, in
+// which case the naming pattern should also be adapted with TileNamePattern.
+func (ds *Dataset) Retile(outputDir string, tileW, tileH int, opts ...RetileOption) ([]string, error) {
+	ro := retileOpts{
+		driver:  GTiff,
+		pattern: "tile_%d_%d.tif",
+	}
+	for _, opt := range opts {
+		opt.setRetileOpt(&ro)
+	}
+	if tileW <= 0 || tileH <= 0 {
+		return nil, fmt.Errorf("invalid tile size %dx%d", tileW, tileH)
+	}
+	if ro.overlap < 0 || ro.overlap >= tileW || ro.overlap >= tileH {
+		return nil, fmt.Errorf("invalid overlap %d for tile size %dx%d", ro.overlap, tileW, tileH)
+	}
+	topts := []DatasetTranslateOption{ro.driver}
+	if len(ro.creation) > 0 {
+		topts = append(topts, CreationOption(ro.creation...))
+	}
+	if len(ro.config) > 0 {
+		topts = append(topts, ConfigOption(ro.config...))
+	}
+	if ro.errorHandler != nil {
+		topts = append(topts, ErrLogger(ro.errorHandler))
+	}
+
+	st := ds.Structure()
+	files := []string{}
+	stepX, stepY := tileW-ro.overlap, tileH-ro.overlap
+	bl := BlockIterator(st.SizeX, st.SizeY, stepX, stepY)
+	for ok := true; ok; bl, ok = bl.Next() {
+		// with an overlap, the previous tile may already reach the raster edge, in which
+		// case this one would be entirely contained in it
+		if (bl.X0 > 0 && bl.X0-stepX+tileW >= st.SizeX) || (bl.Y0 > 0 && bl.Y0-stepY+tileH >= st.SizeY) {
+			continue
+		}
+		w, h := tileW, tileH
+		if bl.X0+w > st.SizeX {
+			w = st.SizeX - bl.X0
+		}
+		if bl.Y0+h > st.SizeY {
+			h = st.SizeY - bl.Y0
+		}
+		fname := filepath.Join(outputDir, fmt.Sprintf(ro.pattern, bl.j, bl.i))
+		tile, err := ds.Translate(fname, []string{
+			"-srcwin", strconv.Itoa(bl.X0), strconv.Itoa(bl.Y0), strconv.Itoa(w), strconv.Itoa(h),
+		}, topts...)
+		if err != nil {
+			return files, fmt.Errorf("tile %s: %w", fname, err)
+		}
+		if err = tile.Close(); err != nil {
+			return files, fmt.Errorf("close tile %s: %w", fname, err)
+		}
+		files = append(files, fname)
+	}
+	return files, nil
+}
+
+// SplitBands returns one single-band dataset per band of ds, sharing its georeferencing
+// and metadata.
+//
+// By default the returned datasets are VRTs referencing ds, which must therefore not
+// be closed before them. Use the Memory DriverName to obtain in-memory copies instead.
+// The returned datasets must be closed by the caller.
+func (ds *Dataset) SplitBands(opts ...SplitBandsOption) ([]*Dataset, error) {
+	so := splitBandsOpts{
+		driver: VRT,
+	}
+	for _, opt := range opts {
+		opt.setSplitBandsOpt(&so)
+	}
+	if so.driver != VRT && so.driver != Memory {
+		return nil, fmt.Errorf("unsupported driver %s", so.driver)
+	}
+	topts := []DatasetTranslateOption{so.driver}
+	if len(so.config) > 0 {
+		topts = append(topts, ConfigOption(so.config...))
+	}
+	if so.errorHandler != nil {
+		topts = append(topts, ErrLogger(so.errorHandler))
+	}
+	nBands := len(ds.Bands())
+	dss := make([]*Dataset, 0, nBands)
+	for b := 1; b <= nBands; b++ {
+		bds, err := ds.Translate("", []string{"-b", strconv.Itoa(b)}, topts...)
+		if err != nil {
+			for _, d := range dss {
+				_ = d.Close()
+			}
+			return nil, fmt.Errorf("band %d: %w", b, err)
+		}
+		dss = append(dss, bds)
+	}
+	return dss, nil
+}
+
+// ExpandPaletteToRGBA expands the color table of the first band of a paletted dataset
+// into a 4-band RGBA dataset, i.e. it is the equivalent of "gdal_translate -expand rgba".
+//
+// The returned dataset is a VRT (the default) or an in-memory copy if the Memory
+// DriverName option is passed. An error is returned if the first band has no color table.
+func (ds *Dataset) ExpandPaletteToRGBA(opts ...ExpandPaletteOption) (*Dataset, error) {
+	eo := expandPaletteOpts{
+		driver: VRT,
+	}
+	for _, opt := range opts {
+		opt.setExpandPaletteOpt(&eo)
+	}
+	if eo.driver != VRT && eo.driver != Memory {
+		return nil, fmt.Errorf("unsupported driver %s", eo.driver)
+	}
+	bands := ds.Bands()
+	if len(bands) == 0 || len(bands[0].ColorTable().Entries) == 0 {
+		return nil, fmt.Errorf("dataset has no color table")
+	}
+	topts := []DatasetTranslateOption{eo.driver}
+	if len(eo.config) > 0 {
+		topts = append(topts, ConfigOption(eo.config...))
+	}
+	if eo.errorHandler != nil {
+		topts = append(topts, ErrLogger(eo.errorHandler))
+	}
+	return ds.Translate("", []string{"-b", "1", "-expand", "rgba"}, topts...)
+}
+
+// MultiDimInfo runs the library version of gdalmdiminfo, and returns the JSON description of
+// the groups, arrays, dimensions and attributes of a multidimensional dataset.
+// See the gdalmdiminfo doc page to determine the valid flags that can be set in switches.
+//
+// ds must have been opened with the Multidimensional() OpenOption.
+//
+// Requires GDAL >= 3.1
+func (ds *Dataset) MultiDimInfo(switches []string, opts ...MultiDimInfoOption) (string, error) {
+	mo := multiDimInfoOpts{}
+	for _, opt := range opts {
+		opt.setMultiDimInfoOpt(&mo)
+	}
+	cswitches := sliceToCStringArray(switches)
+	defer cswitches.free()
+	cgc := createCGOContext(mo.config, mo.errorHandler)
+	cinfo := C.godalMultiDimInfo(cgc.cPointer(), ds.handle(), cswitches.cPointer())
+	if err := cgc.close(); err != nil {
+		return "", err
+	}
+	info := C.GoString(cinfo)
+	C.VSIFree(unsafe.Pointer(cinfo))
+	return info, nil
+}
+
+// switches writes the cutline geometries to a temporary /vsimem/ GeoJSON file and returns
+// the corresponding gdalwarp switches, along with a function removing that file
+func (co *cutlineOpt) switches() ([]string, func(), error) {
+	fname := fmt.Sprintf("/vsimem/godal_cutline_%d.json", atomic.AddUint64(&vsimemCounter, 1))
+	cleanup := func() { _ = VSIUnlink(fname) }
+	switches := []string{"-cutline", fname}
+	if co.geom == nil && co.ds == nil {
+		return nil, nil, fmt.Errorf("cutline geometry or dataset is nil")
+	}
+	if co.geom != nil {
+		if co.geom.handle == nil {
+			return nil, nil, fmt.Errorf("cutline geometry is empty")
+		}
+		var sr *SpatialRef
+		if srh := C.OGR_G_GetSpatialReference(co.geom.handle); srh != nil {
+			sr = &SpatialRef{handle: srh}
+			wkt, err := sr.WKT()
+			if err != nil {
+				return nil, nil, err
+			}
+			switches = append(switches, "-cutline_srs", wkt)
+		}
+		vds, err := CreateVector(GeoJSON, fname)
+		if err != nil {
+			return nil, nil, err
+		}
+		lyr, err := vds.CreateLayer("cutline", sr, GTUnknown)
+		if err == nil {
+			var feat *Feature
+			if feat, err = lyr.NewFeature(co.geom); err == nil {
+				feat.Close()
+			}
+		}
+		if cerr := vds.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		return switches, cleanup, nil
+	}
+	if co.ds.LayerByName(co.layer) == nil {
+		return nil, nil, fmt.Errorf("cutline layer %s not found", co.layer)
+	}
+	vds, err := co.ds.VectorTranslate(fname, []string{"-f", "GeoJSON", co.layer})
+	if err == nil {
+		err = vds.Close()
+	}
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return switches, cleanup, nil
+}
+
 // Warp runs the library version of gdalwarp
 // See the gdalwarp doc page to determine the valid flags/opts that can be set in switches.
 //
@@ -1002,6 +2152,13 @@ func Warp(dstDS string, sourceDS []*Dataset, switches []string, opts ...DatasetW
 	for _, opt := range opts {
 		opt.setDatasetWarpOpt(&gopts)
 	}
+	if gopts.compression != nil {
+		copts, err := gopts.compression.creationOptions(outputDriverName(gopts.driver, switches))
+		if err != nil {
+			return nil, err
+		}
+		gopts.creation = append(gopts.creation, copts...)
+	}
 
 	for _, copt := range gopts.creation {
 		switches = append(switches, "-co", copt)
@@ -1014,6 +2171,30 @@ func Warp(dstDS string, sourceDS []*Dataset, switches []string, opts ...DatasetW
 		}
 		switches = append(switches, "-of", dname)
 	}
+	wsw, err := warpSwitches(gopts.nodata, gopts.srcNoData, gopts.dstNoData, gopts.resampling)
+	if err != nil {
+		return nil, err
+	}
+	switches = append(switches, wsw...)
+	psw, err := warpPerformanceSwitches(gopts.memoryLimit, gopts.threads)
+	if err != nil {
+		return nil, err
+	}
+	switches = append(switches, psw...)
+	if gopts.cutline != nil {
+		csw, cleanup, err := gopts.cutline.switches()
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		switches = append(switches, csw...)
+	}
+	if gopts.crop {
+		if gopts.cutline == nil {
+			return nil, fmt.Errorf("CropToCutline requires a Cutline or CutlineLayer option")
+		}
+		switches = append(switches, "-crop_to_cutline")
+	}
 
 	srcDS := make([]C.GDALDatasetH, len(sourceDS))
 	for i, dataset := range sourceDS {
@@ -1046,6 +2227,24 @@ func (ds *Dataset) WarpInto(sourceDS []*Dataset, switches []string, opts ...Data
 	for _, opt := range opts {
 		opt.setDatasetWarpIntoOpt(&gopts)
 	}
+	wsw, err := warpSwitches(gopts.nodata, gopts.srcNoData, gopts.dstNoData, gopts.resampling)
+	if err != nil {
+		return err
+	}
+	switches = append(switches, wsw...)
+	psw, err := warpPerformanceSwitches(gopts.memoryLimit, gopts.threads)
+	if err != nil {
+		return err
+	}
+	switches = append(switches, psw...)
+	if gopts.cutline != nil {
+		csw, cleanup, err := gopts.cutline.switches()
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		switches = append(switches, csw...)
+	}
 
 	cswitches := sliceToCStringArray(switches)
 	defer cswitches.free()
@@ -1068,11 +2267,20 @@ func (ds *Dataset) WarpInto(sourceDS []*Dataset, switches []string, opts ...Data
 // BuildOverviews computes overviews for the dataset.
 //
 // If neither Levels() or MinSize() is specified, will compute overview
-// levels such that the smallest overview is just under the block size.
+// levels such that the smallest overview is just under the block size. For datasets
+// whose bands have different block sizes, the largest block size is used.
 //
 // Not Setting OvrLevels() or OvrMinSize() if the dataset is not internally tiled
 // is not an error but will probably not create the expected result (i.e. only a
 // single overview will be created).
+//
+// If KeepExisting() is set, only the requested levels that are not already present
+// are computed. An error is returned if the processed bands do not have the same
+// existing overview levels, as the missing levels could then not be computed uniformly.
+//
+// If External() is set, the dataset is reopened read-only and overviews are written
+// to a .ovr sidecar file instead of inside the main file. The block size of the
+// external overviews can be controlled with ConfigOption("GDAL_TIFF_OVR_BLOCKSIZE=256").
 func (ds *Dataset) BuildOverviews(opts ...BuildOverviewsOption) error {
 	bands := ds.Bands()
 	if len(bands) == 0 {
@@ -1080,19 +2288,37 @@ func (ds *Dataset) BuildOverviews(opts ...BuildOverviewsOption) error {
 	}
 	oopts := buildOvrOpts{
 		resampling: Average,
+		minSize:    -1,
+	}
+	for _, opt := range opts {
+		opt.setBuildOverviewsOpt(&oopts)
 	}
 
-	structure := bands[0].Structure()
-
-	//default size is to stop when just under the blocksize (so the band contains a single block)
-	if structure.BlockSizeX > structure.BlockSizeY {
-		oopts.minSize = structure.BlockSizeX
-	} else {
-		oopts.minSize = structure.BlockSizeY
+	ovrBands := bands
+	if len(oopts.bands) > 0 {
+		ovrBands = make([]Band, len(oopts.bands))
+		for i, b := range oopts.bands {
+			if b < 1 || b > len(bands) {
+				return fmt.Errorf("invalid band %d", b-1)
+			}
+			ovrBands[i] = bands[b-1]
+		}
 	}
+	structure := ovrBands[0].Structure()
 
-	for _, opt := range opts {
-		opt.setBuildOverviewsOpt(&oopts)
+	if oopts.minSize == -1 {
+		//default size is to stop when just under the blocksize (so the band contains a single block).
+		//bands may have different block sizes, in which case the largest one is used
+		oopts.minSize = 0
+		for _, bnd := range ovrBands {
+			st := bnd.Structure()
+			if st.BlockSizeX > oopts.minSize {
+				oopts.minSize = st.BlockSizeX
+			}
+			if st.BlockSizeY > oopts.minSize {
+				oopts.minSize = st.BlockSizeY
+			}
+		}
 	}
 
 	if len(oopts.levels) == 0 { //levels need to be computed automatically
@@ -1105,6 +2331,32 @@ func (ds *Dataset) BuildOverviews(opts ...BuildOverviewsOption) error {
 			sy /= 2
 		}
 	}
+	if oopts.keepExisting {
+		existing := map[int]bool{}
+		for i, bnd := range ovrBands {
+			bandExisting := map[int]bool{}
+			for _, ovr := range bnd.Overviews() {
+				ost := ovr.Structure()
+				bandExisting[int(math.Round(float64(structure.SizeX)/float64(ost.SizeX)))] = true
+			}
+			if i == 0 {
+				existing = bandExisting
+				continue
+			}
+			for _, l := range oopts.levels {
+				if existing[l] != bandExisting[l] {
+					return fmt.Errorf("overview level %d exists on some bands only, cannot compute missing levels uniformly", l)
+				}
+			}
+		}
+		missing := []int{}
+		for _, l := range oopts.levels {
+			if !existing[l] {
+				missing = append(missing, l)
+			}
+		}
+		oopts.levels = missing
+	}
 	if len(oopts.levels) == 0 {
 		return nil //nothing to do
 	}
@@ -1122,24 +2374,76 @@ func (ds *Dataset) BuildOverviews(opts ...BuildOverviewsOption) error {
 	}
 	cResample := unsafe.Pointer(C.CString(oopts.resampling.String()))
 	defer C.free(cResample)
+	cMaskResample := (*C.char)(nil)
+	if oopts.maskResample != nil {
+		cMaskResample = C.CString(oopts.maskResample.String())
+		defer C.free(unsafe.Pointer(cMaskResample))
+	}
+
+	ovrds := ds
+	if oopts.external {
+		desc := ds.Description()
+		if desc == "" {
+			return fmt.Errorf("cannot build external overviews on a dataset with no filename")
+		}
+		//make sure pending writes are visible to the read-only handle
+		C.GDALFlushCache(ds.handle())
+		oo := []OpenOption{RasterOnly(), SiblingFiles(), ConfigOption(oopts.config...)}
+		if oopts.errorHandler != nil {
+			oo = append(oo, ErrLogger(oopts.errorHandler))
+		}
+		rods, err := Open(desc, oo...)
+		if err != nil {
+			return fmt.Errorf("reopen %s read-only: %w", desc, err)
+		}
+		defer rods.Close()
+		ovrds = rods
+	}
 
 	cgc := createCGOContext(oopts.config, oopts.errorHandler)
-	C.godalBuildOverviews(cgc.cPointer(), ds.handle(), (*C.char)(cResample), nLevels, cLevels,
-		nBands, cBands)
+	C.godalBuildOverviews(cgc.cPointer(), ovrds.handle(), (*C.char)(cResample), cMaskResample,
+		nLevels, cLevels, nBands, cBands)
 	return cgc.close()
 }
 
 // ClearOverviews deletes all dataset overviews
+//
+// If KeepExternal() is set and the overviews currently exposed by the dataset are read
+// from an external .ovr file, they are left untouched.
 func (ds *Dataset) ClearOverviews(opts ...ClearOverviewsOption) error {
 	co := &clearOvrOpts{}
 	for _, o := range opts {
 		o.setClearOverviewsOpt(co)
 	}
+	if co.keepExternal && ds.hasExternalOverviews() {
+		return nil
+	}
 	cgc := createCGOContext(nil, co.errorHandler)
 	C.godalClearOverviews(cgc.cPointer(), ds.handle())
 	return cgc.close()
 }
 
+// hasExternalOverviews returns true if the overviews of the dataset are read from
+// an external .ovr file
+func (ds *Dataset) hasExternalOverviews() bool {
+	bands := ds.Bands()
+	if len(bands) == 0 {
+		return false
+	}
+	ovrs := bands[0].Overviews()
+	if len(ovrs) == 0 {
+		return false
+	}
+	hovrds := C.GDALGetBandDataset(ovrs[0].handle())
+	if hovrds == nil {
+		return false
+	}
+	ovrds := Dataset{majorObject{C.GDALMajorObjectH(hovrds)}}
+	ovrname := ovrds.Description()
+	return ovrname != "" && ovrname != ds.Description() &&
+		strings.EqualFold(filepath.Ext(ovrname), ".ovr")
+}
+
 // ClearStatistics delete dataset statisitics
 //
 // Since GDAL 3.2
@@ -1185,6 +2489,52 @@ func (ds *Dataset) Write(srcX, srcY int, buffer interface{}, bufWidth, bufHeight
 	return ds.IO(IOWrite, srcX, srcY, buffer, bufWidth, bufHeight, opts...)
 }
 
+// WriteBands writes one buffer per band into the supplied window, i.e. buffers[i] holds
+// the bufWidth*bufHeight pixels of the i'th band (or of the i'th band given with the
+// Bands option). All buffers must be of the same type (e.g. []float32) and hold at least
+// bufWidth*bufHeight elements. The number of buffers must match the number of bands of the
+// dataset, or the number of bands given with the Bands option.
+//
+// The buffers are copied into a single band interleaved buffer, which is written with a
+// single call to GDALDatasetRasterIO. Options altering the buffer layout (BandInterleaved,
+// spacings and strides) are not supported.
+func (ds *Dataset) WriteBands(srcX, srcY int, buffers []interface{}, bufWidth, bufHeight int, opts ...DatasetIOOption) error {
+	ro := datasetIOOpts{}
+	for _, opt := range opts {
+		opt.setDatasetIOOpt(&ro)
+	}
+	if ro.pixelSpacing > 0 || ro.lineSpacing > 0 || ro.bandSpacing > 0 ||
+		ro.pixelStride > 0 || ro.lineStride > 0 || ro.bandStride > 0 || ro.bandInterleave {
+		return fmt.Errorf("spacing, stride and interleaving options are not supported")
+	}
+	nBands := len(ro.bands)
+	if nBands == 0 {
+		nBands = ds.Structure().NBands
+	}
+	if len(buffers) == 0 {
+		return fmt.Errorf("no buffers to write")
+	}
+	if len(buffers) != nBands {
+		return fmt.Errorf("got %d buffers for %d bands", len(buffers), nBands)
+	}
+	dtype := bufferType(buffers[0])
+	bandSize := bufWidth * bufHeight
+	buf, err := newBuffer(dtype, nBands*bandSize)
+	if err != nil {
+		return err
+	}
+	for i, b := range buffers {
+		if bt := bufferType(b); bt != dtype {
+			return fmt.Errorf("buffer %d is of type %s, expected %s", i, bt, dtype)
+		}
+		if l := bufferLen(b); l < bandSize {
+			return fmt.Errorf("buffer %d len=%d less than min=%d", i, l, bandSize)
+		}
+		copyBuffer(sliceBuffer(buf, i*bandSize, (i+1)*bandSize), b)
+	}
+	return ds.Write(srcX, srcY, buf, bufWidth, bufHeight, append(opts[:len(opts):len(opts)], BandInterleaved())...)
+}
+
 // IO reads or writes the pixels contained in the supplied window
 func (ds *Dataset) IO(rw IOOperation, srcX, srcY int, buffer interface{}, bufWidth, bufHeight int, opts ...DatasetIOOption) error {
 	var bands []Band
@@ -1198,6 +2548,11 @@ func (ds *Dataset) IO(rw IOOperation, srcX, srcY int, buffer interface{}, bufWid
 	if ro.dsWidth == 0 {
 		ro.dsWidth = bufWidth
 	}
+	var cWinF *C.double
+	if ro.srcWindowF != nil {
+		srcX, srcY, ro.dsWidth, ro.dsHeight = integerWindow(*ro.srcWindowF)
+		cWinF = cDoubleArray(ro.srcWindowF[:])
+	}
 	if ro.bands == nil {
 		bands = ds.Bands()
 		if len(bands) == 0 {
@@ -1247,13 +2602,16 @@ func (ds *Dataset) IO(rw IOOperation, srcX, srcY int, buffer interface{}, bufWid
 	if err != nil {
 		return err
 	}
-	cgc := createCGOContext(ro.config, ro.errorHandler)
+	cgc, err := createIOContext(ro.readContext, ro.config, ro.errorHandler)
+	if err != nil {
+		return err
+	}
 	C.godalDatasetRasterIO(cgc.cPointer(), ds.handle(), C.GDALRWFlag(rw),
 		C.int(srcX), C.int(srcY), C.int(ro.dsWidth), C.int(ro.dsHeight),
 		cBuf,
 		C.int(bufWidth), C.int(bufHeight), C.GDALDataType(dtype),
 		C.int(len(ro.bands)), cIntArray(ro.bands),
-		C.int(pixelSpacing), C.int(lineSpacing), C.int(bandSpacing), ralg)
+		C.int(pixelSpacing), C.int(lineSpacing), C.int(bandSpacing), ralg, cWinF)
 	return cgc.close()
 }
 
@@ -1398,6 +2756,67 @@ func (drv Driver) ShortName() string {
 	return C.GoString(C.GDALGetDriverShortName(drv.handle()))
 }
 
+// Extensions returns the file extensions (without the leading dot) handled by the driver,
+// as advertised by its DMD_EXTENSIONS (or DMD_EXTENSION) metadata item.
+func (drv Driver) Extensions() []string {
+	exts := drv.Metadata("DMD_EXTENSIONS")
+	if exts == "" {
+		exts = drv.Metadata("DMD_EXTENSION")
+	}
+	return strings.Fields(exts)
+}
+
+// MimeType returns the mime type of the files created by the driver, or an empty string
+// if the driver does not advertise one.
+func (drv Driver) MimeType() string {
+	return drv.Metadata("DMD_MIMETYPE")
+}
+
+// RegisteredDrivers returns all the currently registered drivers, raster and vector.
+//
+// It is not named Drivers as the Drivers() option already exists to restrict the drivers
+// used by Open().
+func RegisteredDrivers() []Driver {
+	n := int(C.GDALGetDriverCount())
+	drivers := make([]Driver, 0, n)
+	for i := 0; i < n; i++ {
+		if hndl := C.GDALGetDriver(C.int(i)); hndl != nil {
+			drivers = append(drivers, Driver{majorObject{C.GDALMajorObjectH(hndl)}})
+		}
+	}
+	return drivers
+}
+
+// Delete deletes the named dataset and all its associated files (e.g. sidecar .aux.xml,
+// .ovr or .msk files, or the .shx/.dbf/.prj files of a shapefile). It wraps GDALDeleteDataset.
+func (drv Driver) Delete(name string, opts ...DriverDeleteOption) error {
+	do := driverDeleteOpts{}
+	for _, opt := range opts {
+		opt.setDriverDeleteOpt(&do)
+	}
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cgc := createCGOContext(nil, do.errorHandler)
+	C.godalDeleteDataset(cgc.cPointer(), drv.handle(), cname)
+	return cgc.close()
+}
+
+// Rename renames the oldName dataset and all its associated files to newName. It wraps
+// GDALRenameDataset.
+func (drv Driver) Rename(newName, oldName string, opts ...DriverRenameOption) error {
+	ro := driverRenameOpts{}
+	for _, opt := range opts {
+		opt.setDriverRenameOpt(&ro)
+	}
+	cnew := C.CString(newName)
+	defer C.free(unsafe.Pointer(cnew))
+	cold := C.CString(oldName)
+	defer C.free(unsafe.Pointer(cold))
+	cgc := createCGOContext(nil, ro.errorHandler)
+	C.godalRenameDataset(cgc.cPointer(), drv.handle(), cnew, cold)
+	return cgc.close()
+}
+
 // VectorDriver returns a Driver by name. It returns false if the named driver does
 // not exist
 func VectorDriver(name DriverName) (Driver, bool) {
@@ -1449,6 +2868,13 @@ func Create(driver DriverName, name string, nBands int, dtype DataType, width, h
 	for _, opt := range opts {
 		opt.setDatasetCreateOpt(&gopts)
 	}
+	if gopts.compression != nil {
+		copts, err := gopts.compression.creationOptions(drvname)
+		if err != nil {
+			return nil, err
+		}
+		gopts.creation = append(gopts.creation, copts...)
+	}
 	createOpts := sliceToCStringArray(gopts.creation)
 	cname := C.CString(name)
 	defer createOpts.free()
@@ -1543,7 +2969,47 @@ func Open(name string, options ...OpenOption) (*Dataset, error) {
 	if err := cgc.close(); err != nil {
 		return nil, err
 	}
-	return &Dataset{majorObject{C.GDALMajorObjectH(retds)}}, nil
+	ds := &Dataset{majorObject{C.GDALMajorObjectH(retds)}}
+	if len(oopts.openedBy) > 0 {
+		drv := ds.Driver().ShortName()
+		for _, dn := range oopts.openedBy {
+			if dn.matches(drv) {
+				return ds, nil
+			}
+		}
+		ds.Close()
+		return nil, fmt.Errorf("%s was opened by driver %s, which is not one of the allowed drivers %v", name, drv, oopts.openedBy)
+	}
+	return ds, nil
+}
+
+// IdentifyDriver returns the driver that would be used to open name, without actually
+// opening the dataset. It wraps GDALIdentifyDriverEx, and returns false if no driver
+// recognizes name.
+//
+// As for Open, name may be a filename or any string supported by gdal, and the
+// SiblingFiles option controls which sidecar files may be probed (none by default).
+func IdentifyDriver(name string, opts ...IdentifyDriverOption) (Driver, bool) {
+	ido := identifyDriverOpts{
+		siblingFiles: []string{filepath.Base(name)},
+	}
+	for _, opt := range opts {
+		opt.setIdentifyDriverOpt(&ido)
+	}
+	csiblings := sliceToCStringArray(ido.siblingFiles)
+	cdrivers := sliceToCStringArray(ido.drivers)
+	defer csiblings.free()
+	defer cdrivers.free()
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	cgc := createCGOContext(ido.config, ido.errorHandler)
+	hndl := C.godalIdentifyDriver(cgc.cPointer(), cname, C.uint(ido.flags),
+		cdrivers.cPointer(), csiblings.cPointer())
+	if err := cgc.close(); err != nil || hndl == nil {
+		return Driver{}, false
+	}
+	return Driver{majorObject{C.GDALMajorObjectH(hndl)}}, true
 }
 
 // Close releases the dataset
@@ -1617,6 +3083,155 @@ func goErrorHandler(loggerID C.int, ec C.int, code C.int, msg *C.char) C.int {
 	return 0
 }
 
+var (
+	defaultErrorHandlerMu sync.RWMutex
+	defaultErrorHandler   ErrorHandler
+	defaultLogLevel       = CE_Debug
+)
+
+// SetDefaultErrorHandler installs eh as the process-wide gdal error handler, which receives
+// all the messages emitted by gdal outside of a godal function call (e.g. from background
+// threads or when closing datasets), as well as the debug messages emitted inside godal
+// calls made without an ErrLogger option (which are otherwise printed to stderr).
+//
+// Messages emitted inside godal calls made with an ErrLogger option are still routed to
+// that ErrLogger only. The error returned by eh is ignored, as there is no caller to return
+// it to. Passing a nil eh restores gdal's default handler which prints messages to stderr.
+//
+// The previously installed handler (nil for gdal's default one) is returned, so that it
+// can be restored once done:
+//
+//	prev := godal.SetDefaultErrorHandler(myHandler)
+//	defer godal.SetDefaultErrorHandler(prev)
+//
+// Note that gdal only emits debug messages when the CPL_DEBUG config option is set.
+func SetDefaultErrorHandler(eh ErrorHandler) (previous ErrorHandler) {
+	defaultErrorHandlerMu.Lock()
+	defer defaultErrorHandlerMu.Unlock()
+	previous = defaultErrorHandler
+	defaultErrorHandler = eh
+	if eh != nil {
+		C.godalSetGlobalErrorHandler(1)
+	} else {
+		C.godalSetGlobalErrorHandler(0)
+	}
+	return previous
+}
+
+// SetLogLevel sets the minimum category of the messages passed to the handler installed
+// by SetDefaultErrorHandler, e.g. CE_Warning to drop debug messages. Defaults to CE_Debug.
+func SetLogLevel(level ErrorCategory) {
+	defaultErrorHandlerMu.Lock()
+	defer defaultErrorHandlerMu.Unlock()
+	defaultLogLevel = level
+}
+
+//export goGlobalErrorHandler
+func goGlobalErrorHandler(ec C.int, code C.int, msg *C.char) {
+	defaultErrorHandlerMu.RLock()
+	fn, level := defaultErrorHandler, defaultLogLevel
+	defaultErrorHandlerMu.RUnlock()
+	if fn == nil || ErrorCategory(ec) < level {
+		return
+	}
+	_ = fn(ErrorCategory(ec), int(code), C.GoString(msg))
+}
+
+// CaptureErrors runs fn and returns its result, along with all the warnings and errors
+// emitted by gdal while fn was running, i.e. including the ones emitted outside of godal
+// function calls (e.g. by a deferred block decompression) which would otherwise be sent
+// to the default error handler.
+//
+// Messages emitted during godal calls are captured in addition to being handled as usual,
+// i.e. a failing godal call inside fn still returns its error to fn. The capture is bound
+// to the calling goroutine, which is locked to its OS thread for the duration of fn:
+// messages emitted by goroutines started from fn are not captured.
+func CaptureErrors(fn func() error) (result error, logs []GDALError) {
+	idx := registerErrorHandler(func(ec ErrorCategory, code int, msg string) error {
+		if ec >= CE_Warning {
+			logs = append(logs, GDALError{Category: ec, Code: code, Message: msg})
+		}
+		return nil
+	})
+	defer unregisterErrorHandler(idx)
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	previous := C.godalPushCaptureHandler(C.int(idx))
+	defer C.godalPopCaptureHandler(previous)
+	result = fn()
+	return result, logs
+}
+
+// PushConfigOption sets a gdal configuration option for the current OS thread, e.g. to
+// set global behaviors such as AWS_REGION or PROJ networking that cannot be passed with
+// per-call ConfigOptions. The option must be reset with PopConfigOption once done.
+//
+// As goroutines may be moved between OS threads, the caller must have locked its
+// goroutine to the current thread with runtime.LockOSThread. WithConfig should be
+// preferred as it takes care of this.
+//
+// Note that a ConfigOption passed to a godal call for the same key overrides the value
+// set here, and unsets it when the call returns.
+func PushConfigOption(key, value string) {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	cval := C.CString(value)
+	defer C.free(unsafe.Pointer(cval))
+	C.CPLSetThreadLocalConfigOption(ckey, cval)
+}
+
+// PopConfigOption unsets a configuration option set with PushConfigOption for the
+// current OS thread.
+func PopConfigOption(key string) {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	C.CPLSetThreadLocalConfigOption(ckey, nil)
+}
+
+// threadLocalConfigOption returns the value of a configuration option set for the
+// current OS thread
+func threadLocalConfigOption(key string) (string, bool) {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	cval := C.CPLGetThreadLocalConfigOption(ckey, nil)
+	if cval == nil {
+		return "", false
+	}
+	return C.GoString(cval), true
+}
+
+// WithConfig runs fn with the given gdal configuration options set for the calling
+// goroutine, which is locked to its OS thread for the duration of fn. The previous
+// thread-local values are restored when fn returns. As with CaptureErrors, godal calls
+// made by goroutines started from fn do not see the options.
+func WithConfig(kv map[string]string, fn func() error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	previous := make(map[string]*string, len(kv))
+	for k, v := range kv {
+		if pv, ok := threadLocalConfigOption(k); ok {
+			previous[k] = &pv
+		} else {
+			previous[k] = nil
+		}
+		PushConfigOption(k, v)
+	}
+	defer func() {
+		for k, pv := range previous {
+			if pv != nil {
+				PushConfigOption(k, *pv)
+			} else {
+				PopConfigOption(k)
+			}
+		}
+	}()
+	return fn()
+}
+
+func testGlobalErrorHandling() {
+	C.test_godal_global_error_handling()
+}
+
 func testErrorAndLogging(opts ...errorAndLoggingOption) error {
 	ealo := errorAndLoggingOpts{}
 	for _, o := range opts {
@@ -1680,7 +3295,8 @@ const (
 	Q1
 	// Q3 resampling
 	Q3
-	//RMS gdal >=3.3
+	// RMS (quadratic mean) resampling, gdal >=3.3
+	RMS
 )
 
 func (ra ResamplingAlg) String() string {
@@ -1701,8 +3317,8 @@ func (ra ResamplingAlg) String() string {
 		return "gauss"
 	case Mode:
 		return "mode"
-	//case RMS:
-	//	return "rms"
+	case RMS:
+		return "rms"
 	case Q1:
 		return "Q1"
 	case Q3:
@@ -1720,6 +3336,28 @@ func (ra ResamplingAlg) String() string {
 	}
 }
 
+// warpName returns the name of the resampling algorithm as expected by gdalwarp's -r switch
+func (ra ResamplingAlg) warpName() (string, error) {
+	switch ra {
+	case Nearest:
+		return "near", nil
+	case Bilinear, Cubic, CubicSpline, Lanczos, Average, Mode, Max, Min, Median, Q1, Q3:
+		return ra.String(), nil
+	case Sum:
+		if !CheckMinVersion(3, 1, 0) {
+			return "", fmt.Errorf("sum resampling requires gdal >= 3.1")
+		}
+		return ra.String(), nil
+	case RMS:
+		if !CheckMinVersion(3, 3, 0) {
+			return "", fmt.Errorf("rms resampling requires gdal >= 3.3")
+		}
+		return ra.String(), nil
+	default:
+		return "", fmt.Errorf("%s resampling not supported for warping", ra.String())
+	}
+}
+
 func (ra ResamplingAlg) rioAlg() (C.GDALRIOResampleAlg, error) {
 	switch ra {
 	case Nearest:
@@ -1738,8 +3376,11 @@ func (ra ResamplingAlg) rioAlg() (C.GDALRIOResampleAlg, error) {
 		return C.GRIORA_Gauss, nil
 	case Mode:
 		return C.GRIORA_Mode, nil
-	//case RMS:
-	//	return C.GRIORA_RMS, nil
+	case RMS:
+		if !CheckMinVersion(3, 3, 0) {
+			return C.GRIORA_NearestNeighbour, fmt.Errorf("rms resampling requires gdal >= 3.3")
+		}
+		return C.GRIORA_RMS, nil
 	default:
 		return C.GRIORA_NearestNeighbour, fmt.Errorf("%s resampling not supported for IO", ra.String())
 
@@ -1802,6 +3443,119 @@ func bufferType(buffer interface{}) DataType {
 	}
 }
 
+// newBuffer allocates a slice of n elements of type dtype
+func newBuffer(dtype DataType, n int) (interface{}, error) {
+	switch dtype {
+	case Byte:
+		return make([]byte, n), nil
+	case Int8:
+		return make([]int8, n), nil
+	case Int16:
+		return make([]int16, n), nil
+	case UInt16:
+		return make([]uint16, n), nil
+	case Int32:
+		return make([]int32, n), nil
+	case UInt32:
+		return make([]uint32, n), nil
+	case Float32:
+		return make([]float32, n), nil
+	case Float64:
+		return make([]float64, n), nil
+	case CFloat32:
+		return make([]complex64, n), nil
+	case CFloat64:
+		return make([]complex128, n), nil
+	default:
+		return nil, fmt.Errorf("unsupported datatype %s", dtype)
+	}
+}
+
+// sliceBuffer returns buffer[start:end] for a buffer allocated by newBuffer
+func sliceBuffer(buffer interface{}, start, end int) interface{} {
+	switch buf := buffer.(type) {
+	case []byte:
+		return buf[start:end]
+	case []int8:
+		return buf[start:end]
+	case []int16:
+		return buf[start:end]
+	case []uint16:
+		return buf[start:end]
+	case []int32:
+		return buf[start:end]
+	case []uint32:
+		return buf[start:end]
+	case []float32:
+		return buf[start:end]
+	case []float64:
+		return buf[start:end]
+	case []complex64:
+		return buf[start:end]
+	case []complex128:
+		return buf[start:end]
+	default:
+		panic("unsupported type")
+	}
+}
+
+// copyBuffer copies src into dst, which must be slices of the same type, and returns
+// the number of copied elements
+func copyBuffer(dst, src interface{}) int {
+	switch buf := dst.(type) {
+	case []byte:
+		return copy(buf, src.([]byte))
+	case []int8:
+		return copy(buf, src.([]int8))
+	case []int16:
+		return copy(buf, src.([]int16))
+	case []uint16:
+		return copy(buf, src.([]uint16))
+	case []int32:
+		return copy(buf, src.([]int32))
+	case []uint32:
+		return copy(buf, src.([]uint32))
+	case []float32:
+		return copy(buf, src.([]float32))
+	case []float64:
+		return copy(buf, src.([]float64))
+	case []complex64:
+		return copy(buf, src.([]complex64))
+	case []complex128:
+		return copy(buf, src.([]complex128))
+	default:
+		panic("unsupported type")
+	}
+}
+
+// bufferLen returns the number of elements of buffer
+func bufferLen(buffer interface{}) int {
+	switch buf := buffer.(type) {
+	case []byte:
+		return len(buf)
+	case []int8:
+		return len(buf)
+	case []int16:
+		return len(buf)
+	case []uint16:
+		return len(buf)
+	case []int32:
+		return len(buf)
+	case []uint32:
+		return len(buf)
+	case []float32:
+		return len(buf)
+	case []float64:
+		return len(buf)
+	case []complex64:
+		return len(buf)
+	case []complex128:
+		return len(buf)
+	default:
+		panic("unsupported type")
+	}
+}
+
 // cBuffer returns the type of an individual element, and a pointer to the
 // underlying memory array
 func cBuffer(buffer interface{}, minsize int) unsafe.Pointer {
@@ -1969,24 +3723,47 @@ type vectorOnlyOpt struct{}
 // VectorOnly limits drivers to vector ones (incompatible with RasterOnly() )
 func VectorOnly() interface {
 	OpenOption
+	IdentifyDriverOption
 } {
 	return vectorOnlyOpt{}
 }
 func (vectorOnlyOpt) setOpenOpt(oo *openOpts) {
 	oo.flags |= C.GDAL_OF_VECTOR
 }
+func (vectorOnlyOpt) setIdentifyDriverOpt(ido *identifyDriverOpts) {
+	ido.flags |= C.GDAL_OF_VECTOR
+}
+
+type multidimOpt struct{}
+
+// Multidimensional opens the dataset in multidimensional raster mode, e.g. to inspect
+// the structure of a netCDF or Zarr dataset with Dataset.MultiDimInfo.
+//
+// Requires GDAL >= 3.1
+func Multidimensional() interface {
+	OpenOption
+} {
+	return multidimOpt{}
+}
+func (multidimOpt) setOpenOpt(oo *openOpts) {
+	oo.flags |= C.GDAL_OF_MULTIDIM_RASTER
+}
 
 type rasterOnlyOpt struct{}
 
 // RasterOnly limits drivers to vector ones (incompatible with VectorOnly() )
 func RasterOnly() interface {
 	OpenOption
+	IdentifyDriverOption
 } {
 	return rasterOnlyOpt{}
 }
 func (rasterOnlyOpt) setOpenOpt(oo *openOpts) {
 	oo.flags |= C.GDAL_OF_RASTER
 }
+func (rasterOnlyOpt) setIdentifyDriverOpt(ido *identifyDriverOpts) {
+	ido.flags |= C.GDAL_OF_RASTER
+}
 
 // SpatialRef is a wrapper around OGRSpatialReferenceH
 type SpatialRef struct {
@@ -2010,6 +3787,70 @@ func (sr *SpatialRef) WKT(opts ...WKTExportOption) (string, error) {
 	return wkt, nil
 }
 
+// ESRIWKT returns the spatial reference as ESRI flavored WKT, i.e. as expected in the
+// .prj sidecar of a shapefile consumed by Esri software. The SpatialRef itself is left
+// unmodified.
+func (sr *SpatialRef) ESRIWKT(opts ...WKTExportOption) (string, error) {
+	wo := &srWKTOpts{}
+	for _, o := range opts {
+		o.setWKTExportOpt(wo)
+	}
+	cgc := createCGOContext(nil, wo.errorHandler)
+	cwkt := C.godalExportToESRIWKT(cgc.cPointer(), sr.handle)
+	if err := cgc.close(); err != nil {
+		return "", err
+	}
+	wkt := C.GoString(cwkt)
+	C.CPLFree(unsafe.Pointer(cwkt))
+	return wkt, nil
+}
+
+// Proj4 returns the spatial reference as a PROJ.4 string
+func (sr *SpatialRef) Proj4(opts ...Proj4ExportOption) (string, error) {
+	po := &proj4Opts{}
+	for _, o := range opts {
+		o.setProj4ExportOpt(po)
+	}
+	cgc := createCGOContext(nil, po.errorHandler)
+	cproj := C.godalExportToProj4(cgc.cPointer(), sr.handle)
+	if err := cgc.close(); err != nil {
+		return "", err
+	}
+	proj := C.GoString(cproj)
+	C.CPLFree(unsafe.Pointer(cproj))
+	return proj, nil
+}
+
+// PROJJSON returns the spatial reference as a PROJJSON string
+//
+// Requires GDAL >= 3.1
+func (sr *SpatialRef) PROJJSON(opts ...PROJJSONExportOption) (string, error) {
+	po := &projJSONOpts{}
+	for _, o := range opts {
+		o.setPROJJSONExportOpt(po)
+	}
+	coptions := sliceToCStringArray(po.options)
+	defer coptions.free()
+	cgc := createCGOContext(nil, po.errorHandler)
+	cjson := C.godalExportToPROJJSON(cgc.cPointer(), sr.handle, coptions.cPointer())
+	if err := cgc.close(); err != nil {
+		return "", err
+	}
+	json := C.GoString(cjson)
+	C.CPLFree(unsafe.Pointer(cjson))
+	return json, nil
+}
+
+// Clone returns a copy of the spatial reference. The returned SpatialRef is owned by
+// the caller and remains valid after the object sr was obtained from (e.g. a Dataset)
+// is closed. It must be released with Close.
+func (sr *SpatialRef) Clone() *SpatialRef {
+	if sr == nil || sr.handle == nil {
+		return nil
+	}
+	return &SpatialRef{handle: C.OSRClone(sr.handle), isOwned: true}
+}
+
 // Close releases memory
 func (sr *SpatialRef) Close() {
 	if sr.handle == nil {
@@ -2058,6 +3899,23 @@ func NewSpatialRefFromWKT(wkt string, opts ...CreateSpatialRefOption) (*SpatialR
 	return &SpatialRef{handle: hndl, isOwned: true}, nil
 }
 
+// NewSpatialRefFromESRI creates a SpatialRef from an ESRI flavored WKT string, e.g. as
+// found in the .prj sidecar of a shapefile produced by Esri software
+func NewSpatialRefFromESRI(esriWKT string, opts ...CreateSpatialRefOption) (*SpatialRef, error) {
+	cso := &createSpatialRefOpts{}
+	for _, o := range opts {
+		o.setCreateSpatialRefOpt(cso)
+	}
+	cstr := C.CString(esriWKT)
+	defer C.free(unsafe.Pointer(cstr))
+	cgc := createCGOContext(nil, cso.errorHandler)
+	hndl := C.godalCreateESRISpatialRef(cgc.cPointer(), (*C.char)(unsafe.Pointer(cstr)))
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	return &SpatialRef{handle: hndl, isOwned: true}, nil
+}
+
 // NewSpatialRefFromProj4 creates a SpatialRef from a proj4 string
 func NewSpatialRefFromProj4(proj string, opts ...CreateSpatialRefOption) (*SpatialRef, error) {
 	cso := &createSpatialRefOpts{}
@@ -2088,6 +3946,43 @@ func NewSpatialRefFromEPSG(code int, opts ...CreateSpatialRefOption) (*SpatialRe
 	return &SpatialRef{handle: hndl, isOwned: true}, nil
 }
 
+// NewSpatialRefFromCRSURL creates a SpatialRef from a CRS URI as found in STAC or
+// OGC API responses, e.g. "http://www.opengis.net/def/crs/EPSG/0/4326",
+// "http://www.opengis.net/def/crs/OGC/1.3/CRS84" or "urn:ogc:def:crs:EPSG::4326".
+//
+// OGC URIs and URNs are resolved locally. Other http(s) URLs are fetched from the
+// network, and are rejected unless the AllowNetworkAccess option is passed.
+func NewSpatialRefFromCRSURL(uri string, opts ...CreateSpatialRefOption) (*SpatialRef, error) {
+	cso := &createSpatialRefOpts{}
+	for _, o := range opts {
+		o.setCreateSpatialRefOpt(cso)
+	}
+	luri := strings.ToLower(uri)
+	switch {
+	case strings.HasPrefix(luri, "urn:ogc:def:crs"):
+	case strings.HasPrefix(luri, "http://www.opengis.net/def/crs"),
+		strings.HasPrefix(luri, "http://opengis.net/def/crs"):
+	case strings.HasPrefix(luri, "https://www.opengis.net/def/crs"),
+		strings.HasPrefix(luri, "https://opengis.net/def/crs"):
+		//older gdal versions only recognize the http scheme for OGC URIs
+		uri = "http" + uri[5:]
+	case strings.HasPrefix(luri, "http://"), strings.HasPrefix(luri, "https://"):
+		if !cso.allowNetwork {
+			return nil, fmt.Errorf("%s is not an OGC CRS URI and network access is not allowed", uri)
+		}
+	default:
+		return nil, fmt.Errorf("%s is not a CRS URL", uri)
+	}
+	cstr := C.CString(uri)
+	defer C.free(unsafe.Pointer(cstr))
+	cgc := createCGOContext(nil, cso.errorHandler)
+	hndl := C.godalCreateUserSpatialRef(cgc.cPointer(), (*C.char)(unsafe.Pointer(cstr)))
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	return &SpatialRef{handle: hndl, isOwned: true}, nil
+}
+
 // IsSame returns whether two SpatiaRefs describe the same projection.
 func (sr *SpatialRef) IsSame(other *SpatialRef) bool {
 	ret := C.OSRIsSame(sr.handle, other.handle)
@@ -2107,8 +4002,20 @@ func NewTransform(src, dst *SpatialRef, opts ...TransformOption) (*Transform, er
 		o.setTransformOpt(to)
 	}
 	cgc := createCGOContext(nil, to.errorHandler)
-	hndl := C.godalNewCoordinateTransformation(cgc.cPointer(), src.handle, dst.handle)
-	if err := cgc.close(); err != nil {
+	var caoi *C.double
+	if to.areaOfInterest != nil {
+		caoi = cDoubleArray(to.areaOfInterest[:])
+	}
+	cballpark := C.int(1)
+	if to.disallowBallpark {
+		cballpark = 0
+	}
+	ctrad := C.int(0)
+	if to.traditionalGISOrder {
+		ctrad = 1
+	}
+	hndl := C.godalNewCoordinateTransformation(cgc.cPointer(), src.handle, dst.handle, caoi, cballpark, ctrad)
+	if err := cgc.close(); err != nil {
 		return nil, err
 	}
 	return &Transform{handle: hndl, dst: dst.handle}, nil
@@ -2179,12 +4086,180 @@ func (trn *Transform) TransformEx(x []float64, y []float64, z []float64, success
 	return nil
 }
 
+// DatasetTransform transforms pixel/line coordinates of a source dataset into the pixel/line
+// coordinates of a destination dataset, taking into account their respective georeferencing.
+type DatasetTransform struct {
+	handle unsafe.Pointer
+}
+
+// NewDatasetTransform creates a transformer mapping pixel/line coordinates of src to
+// pixel/line coordinates of dst. Both datasets must be georeferenced (with a geotransform,
+// GCPs or RPCs). It wraps GDALCreateGenImgProjTransformer2.
+//
+// The returned DatasetTransform must be released with Close.
+func NewDatasetTransform(src, dst *Dataset, opts ...DatasetTransformOption) (*DatasetTransform, error) {
+	to := &datasetTransformOpts{}
+	for _, o := range opts {
+		o.setDatasetTransformOpt(to)
+	}
+	cgc := createCGOContext(nil, to.errorHandler)
+	hndl := C.godalCreateGenImgProjTransformer(cgc.cPointer(), src.handle(), dst.handle(), nil)
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	return &DatasetTransform{handle: hndl}, nil
+}
+
+// Close releases the DatasetTransform object
+func (dt *DatasetTransform) Close() {
+	if dt.handle == nil {
+		return
+	}
+	C.GDALDestroyGenImgProjTransformer(dt.handle)
+	dt.handle = nil
+}
+
+// Transform transforms the x/y pixel/line coordinates in place, from the source dataset to the
+// destination dataset, or from the destination dataset to the source dataset if inverse is true.
+//
+// x and y must be of the same length. An error is returned if any of the points failed to be
+// transformed.
+func (dt *DatasetTransform) Transform(x, y []float64, inverse bool, opts ...TransformPixelsOption) error {
+	to := &transformPixelsOpts{}
+	for _, o := range opts {
+		o.setTransformPixelsOpt(to)
+	}
+	if len(x) != len(y) {
+		return fmt.Errorf("x and y must be of the same length")
+	}
+	if len(x) == 0 {
+		return nil
+	}
+	cx := make([]C.double, len(x))
+	cy := make([]C.double, len(x))
+	for i := range x {
+		cx[i] = C.double(x[i])
+		cy[i] = C.double(y[i])
+	}
+	cinverse := C.int(0)
+	if inverse {
+		cinverse = 1
+	}
+	cgc := createCGOContext(nil, to.errorHandler)
+	C.godalGenImgProjTransform(cgc.cPointer(), dt.handle, cinverse, C.int(len(x)),
+		(*C.double)(unsafe.Pointer(&cx[0])), (*C.double)(unsafe.Pointer(&cy[0])))
+	for i := range x {
+		x[i] = float64(cx[i])
+		y[i] = float64(cy[i])
+	}
+	return cgc.close()
+}
+
+// PixelToGeo converts the pixel/line coordinates of the dataset to georeferenced coordinates,
+// expressed in the dataset's spatial reference (or in WGS84 for RPCs). The georeferencing
+// is taken from the dataset's geotransform, GCPs or RPCs, in that order of preference,
+// i.e. as done by GDALCreateGenImgProjTransformer2. When using RPCs, the RPCDEM option
+// can be used to take the terrain elevation into account.
+//
+// pixels and lines must be of the same length.
+func (ds *Dataset) PixelToGeo(pixels, lines []float64, opts ...PixelToGeoOption) (xs, ys []float64, err error) {
+	po := pixelToGeoOpts{}
+	for _, o := range opts {
+		o.setPixelToGeoOpt(&po)
+	}
+	return ds.pixelGeoTransform(pixels, lines, false, po.transformer, po.errorHandler)
+}
+
+// GeoToPixel is the inverse of PixelToGeo, converting georeferenced coordinates to
+// pixel/line coordinates of the dataset.
+//
+// xs and ys must be of the same length.
+func (ds *Dataset) GeoToPixel(xs, ys []float64, opts ...GeoToPixelOption) (pixels, lines []float64, err error) {
+	po := geoToPixelOpts{}
+	for _, o := range opts {
+		o.setGeoToPixelOpt(&po)
+	}
+	return ds.pixelGeoTransform(xs, ys, true, po.transformer, po.errorHandler)
+}
+
+func (ds *Dataset) pixelGeoTransform(x, y []float64, inverse bool, trnopts []string, eh ErrorHandler) ([]float64, []float64, error) {
+	if len(x) != len(y) {
+		return nil, nil, fmt.Errorf("coordinate slices must be of the same length")
+	}
+	if len(x) == 0 {
+		return []float64{}, []float64{}, nil
+	}
+	cx := make([]C.double, len(x))
+	cy := make([]C.double, len(x))
+	for i := range x {
+		cx[i] = C.double(x[i])
+		cy[i] = C.double(y[i])
+	}
+	cinverse := C.int(0)
+	if inverse {
+		cinverse = 1
+	}
+	copts := sliceToCStringArray(trnopts)
+	defer copts.free()
+	cgc := createCGOContext(nil, eh)
+	C.godalDatasetPixelGeoTransform(cgc.cPointer(), ds.handle(), copts.cPointer(), cinverse, C.int(len(x)),
+		(*C.double)(unsafe.Pointer(&cx[0])), (*C.double)(unsafe.Pointer(&cy[0])))
+	if err := cgc.close(); err != nil {
+		return nil, nil, err
+	}
+	ox := make([]float64, len(x))
+	oy := make([]float64, len(x))
+	for i := range x {
+		ox[i] = float64(cx[i])
+		oy[i] = float64(cy[i])
+	}
+	return ox, oy, nil
+}
+
+// TransformBounds reprojects the bounding box bounds (i.e. [minX,minY,maxX,maxY]) and returns
+// the bounding box containing it in the target spatial reference. densifyPts points are added
+// along each edge of the box before transforming, in order to account for the curvature of the
+// edges in the target projection. 21 is a reasonable value for most use cases.
+//
+// Requires GDAL >= 3.4
+func (trn *Transform) TransformBounds(bounds [4]float64, densifyPts int, opts ...TransformBoundsOption) ([4]float64, error) {
+	to := &transformBoundsOpts{}
+	for _, o := range opts {
+		o.setTransformBoundsOpt(to)
+	}
+	cbnds := [4]C.double{C.double(bounds[0]), C.double(bounds[1]), C.double(bounds[2]), C.double(bounds[3])}
+	cgc := createCGOContext(nil, to.errorHandler)
+	C.godalTransformBounds(cgc.cPointer(), trn.handle, &cbnds[0], C.int(densifyPts))
+	if err := cgc.close(); err != nil {
+		return [4]float64{}, err
+	}
+	return [4]float64{float64(cbnds[0]), float64(cbnds[1]), float64(cbnds[2]), float64(cbnds[3])}, nil
+}
+
 // EPSGTreatsAsLatLong returns TRUE if EPSG feels the SpatialRef should be treated as having lat/long coordinate ordering.
 func (sr *SpatialRef) EPSGTreatsAsLatLong() bool {
 	ret := C.OSREPSGTreatsAsLatLong(sr.handle)
 	return ret != 0
 }
 
+// AxisMappingStrategy defines how the axes of a SpatialRef are mapped to the
+// coordinates passed to and returned by transformations
+type AxisMappingStrategy int
+
+const (
+	// TraditionalGISOrder uses longitude/latitude and easting/northing order, whatever the
+	// authority definition. This is the strategy of all the SpatialRefs created by godal.
+	TraditionalGISOrder AxisMappingStrategy = C.OAMS_TRADITIONAL_GIS_ORDER
+	// AuthorityCompliant uses the axis order of the authority definition, e.g.
+	// latitude/longitude for EPSG:4326
+	AuthorityCompliant AxisMappingStrategy = C.OAMS_AUTHORITY_COMPLIANT
+)
+
+// SetAxisMappingStrategy sets the axis mapping strategy of the SpatialRef
+func (sr *SpatialRef) SetAxisMappingStrategy(strategy AxisMappingStrategy) {
+	C.OSRSetAxisMappingStrategy(sr.handle, C.OSRAxisMappingStrategy(strategy))
+}
+
 // Geographic returns wether the SpatialRef is geographic
 func (sr *SpatialRef) Geographic() bool {
 	ret := C.OSRIsGeographic(sr.handle)
@@ -2263,6 +4338,88 @@ func (sr *SpatialRef) AuthorityCode(target string) string {
 	return ""
 }
 
+// AreaOfUse returns the area of use of the SpatialRef, as longitude/latitude bounds in
+// degrees and a textual description of the area. ok is false if the SpatialRef has no
+// known area of use, e.g. for a custom proj string.
+//
+// Note that west may be greater than east for areas crossing the antimeridian.
+func (sr *SpatialRef) AreaOfUse() (west, south, east, north float64, name string, ok bool) {
+	var cw, cs, ce, cn C.double
+	var cname *C.char
+	if C.OSRGetAreaOfUse(sr.handle, &cw, &cs, &ce, &cn, &cname) == 0 {
+		return 0, 0, 0, 0, "", false
+	}
+	if cname != nil {
+		name = C.GoString(cname)
+	}
+	return float64(cw), float64(cs), float64(ce), float64(cn), name, true
+}
+
+// Axis returns the name and orientation (e.g. "North", "East", "Up") of the i-th (0 based)
+// axis of the target node, i.e. "GEOGCS" or "PROJCS", or "" for the root node. Empty
+// strings are returned if no such axis exists.
+func (sr *SpatialRef) Axis(target string, i int) (name string, orientation string) {
+	cstr := (*C.char)(nil)
+	if len(target) > 0 {
+		cstr = C.CString(target)
+		defer C.free(unsafe.Pointer(cstr))
+	}
+	var corient C.OGRAxisOrientation
+	cret := C.OSRGetAxis(sr.handle, cstr, C.int(i), &corient)
+	if cret == nil {
+		return "", ""
+	}
+	return C.GoString(cret), C.GoString(C.OSRAxisEnumToName(corient))
+}
+
+// SpatialRefMatch is a candidate returned by SpatialRef.FindMatches
+type SpatialRefMatch struct {
+	// SpatialRef is the matched definition from the PROJ database. It must be closed
+	// by the caller.
+	SpatialRef *SpatialRef
+	// Confidence is the likelihood of the match, from 0 to 100. A value of 100 means
+	// that the SpatialRef is equivalent to the matched definition.
+	Confidence int
+}
+
+// FindMatches searches the PROJ database for the definitions matching the SpatialRef,
+// and returns them sorted by decreasing confidence. Contrary to AutoIdentifyEPSG, it
+// is able to identify definitions that slightly differ from the official ones, e.g.
+// when parameters or names are non-standard.
+//
+// An empty slice is returned if no candidate was found.
+func (sr *SpatialRef) FindMatches(opts ...FindMatchesOption) ([]SpatialRefMatch, error) {
+	fo := findMatchesOpts{}
+	for _, opt := range opts {
+		opt.setFindMatchesOpt(&fo)
+	}
+	var cn C.int
+	var cconf *C.int
+	cgc := createCGOContext(nil, fo.errorHandler)
+	cmatches := C.godalFindMatches(cgc.cPointer(), sr.handle, &cn, &cconf)
+	if err := cgc.close(); err != nil {
+		C.OSRFreeSRSArray(cmatches)
+		C.CPLFree(unsafe.Pointer(cconf))
+		return nil, err
+	}
+	n := int(cn)
+	matches := make([]SpatialRefMatch, n)
+	if n > 0 {
+		hndls := (*[1 << 30]C.OGRSpatialReferenceH)(unsafe.Pointer(cmatches))[:n:n]
+		confs := (*[1 << 30]C.int)(unsafe.Pointer(cconf))[:n:n]
+		for i := range matches {
+			matches[i] = SpatialRefMatch{
+				SpatialRef: &SpatialRef{handle: hndls[i], isOwned: true},
+				Confidence: int(confs[i]),
+			}
+		}
+	}
+	// ownership of the individual SpatialRefs has been transferred, only free the arrays
+	C.CPLFree(unsafe.Pointer(cmatches))
+	C.CPLFree(unsafe.Pointer(cconf))
+	return matches, nil
+}
+
 // AutoIdentifyEPSG sets EPSG authority info if possible.
 func (sr *SpatialRef) AutoIdentifyEPSG() error {
 	ogrerr := C.OSRAutoIdentifyEPSG(sr.handle)
@@ -2447,14 +4604,58 @@ const (
 type FieldDefinition struct {
 	name  string
 	ftype FieldType
+	fieldDefinitionOpts
 }
 
 // NewFieldDefinition creates a FieldDefinition
-func NewFieldDefinition(name string, fdtype FieldType) *FieldDefinition {
-	return &FieldDefinition{
+//
+// Available FieldDefinitionOptions are:
+//   - FieldWidth
+//   - FieldPrecision
+//   - FieldNullable
+//   - FieldDefault
+func NewFieldDefinition(name string, fdtype FieldType, opts ...FieldDefinitionOption) *FieldDefinition {
+	fd := &FieldDefinition{
 		name:  name,
 		ftype: fdtype,
 	}
+	for _, o := range opts {
+		o.setFieldDefinitionOpt(&fd.fieldDefinitionOpts)
+	}
+	return fd
+}
+
+// Name returns the name of the field
+func (fd *FieldDefinition) Name() string {
+	return fd.name
+}
+
+// Type returns the type of the field
+func (fd *FieldDefinition) Type() FieldType {
+	return fd.ftype
+}
+
+// Width returns the width of the field, or 0 if unset
+func (fd *FieldDefinition) Width() int {
+	return fd.width
+}
+
+// Precision returns the precision of the field, or 0 if unset
+func (fd *FieldDefinition) Precision() int {
+	return fd.precision
+}
+
+// Nullable returns false if the field has a not-null constraint
+func (fd *FieldDefinition) Nullable() bool {
+	return fd.nullable == nil || *fd.nullable
+}
+
+// Default returns the default value of the field, if any
+func (fd *FieldDefinition) Default() (string, bool) {
+	if fd.defaultValue == nil {
+		return "", false
+	}
+	return *fd.defaultValue, true
 }
 
 func (fd *FieldDefinition) setCreateLayerOpt(o *createLayerOpts) {
@@ -2465,6 +4666,20 @@ func (fd *FieldDefinition) createHandle() C.OGRFieldDefnH {
 	cfname := unsafe.Pointer(C.CString(fd.name))
 	defer C.free(cfname)
 	cfd := C.OGR_Fld_Create((*C.char)(cfname), C.OGRFieldType(fd.ftype))
+	if fd.width > 0 {
+		C.OGR_Fld_SetWidth(cfd, C.int(fd.width))
+	}
+	if fd.precision > 0 {
+		C.OGR_Fld_SetPrecision(cfd, C.int(fd.precision))
+	}
+	if fd.nullable != nil && !*fd.nullable {
+		C.OGR_Fld_SetNullable(cfd, C.int(0))
+	}
+	if fd.defaultValue != nil {
+		cdef := C.CString(*fd.defaultValue)
+		C.OGR_Fld_SetDefault(cfd, cdef)
+		C.free(unsafe.Pointer(cdef))
+	}
 	return cfd
 }
 
@@ -2534,14 +4749,22 @@ func (layer Layer) Type() GeometryType {
 }
 
 // Bounds returns the layer's envelope in the order minx,miny,maxx,maxy
+//
+// By default the extent is computed by scanning all features if the driver has no
+// cheaper way to obtain it. Use ForceExtent(false) to only return an extent that is
+// readily available (e.g. stored in a file header), or an error otherwise.
 func (layer Layer) Bounds(opts ...BoundsOption) ([4]float64, error) {
 	bo := boundsOpts{}
 	for _, o := range opts {
 		o.setBoundsOpt(&bo)
 	}
 	var env C.OGREnvelope
+	cforce := C.int(1)
+	if bo.noForce {
+		cforce = 0
+	}
 	cgc := createCGOContext(nil, bo.errorHandler)
-	C.godalLayerGetExtent(cgc.cPointer(), layer.handle(), &env)
+	C.godalLayerGetExtent(cgc.cPointer(), layer.handle(), &env, cforce)
 	if err := cgc.close(); err != nil {
 		return [4]float64{}, err
 	}
@@ -2556,13 +4779,34 @@ func (layer Layer) Bounds(opts ...BoundsOption) ([4]float64, error) {
 	}
 	sr := layer.SpatialRef()
 	defer sr.Close()
-	bnds, err := reprojectBounds(bnds, sr, bo.sr)
+	bnds, err := reprojectBounds(bnds, sr, bo.sr, bo.densifyPts)
 	if err != nil {
 		return [4]float64{}, err
 	}
 	return bnds, nil
 }
 
+// Extent3D returns the layer's 3D envelope in the order minx,miny,minz,maxx,maxy,maxz
+//
+// Requires GDAL >= 3.9
+func (layer Layer) Extent3D(opts ...Extent3DOption) ([6]float64, error) {
+	eo := extent3DOpts{}
+	for _, o := range opts {
+		o.setExtent3DOpt(&eo)
+	}
+	var ext [6]float64
+	cforce := C.int(1)
+	if eo.noForce {
+		cforce = 0
+	}
+	cgc := createCGOContext(nil, eo.errorHandler)
+	C.godalLayerGetExtent3D(cgc.cPointer(), layer.handle(), (*C.double)(unsafe.Pointer(&ext[0])), cforce)
+	if err := cgc.close(); err != nil {
+		return [6]float64{}, err
+	}
+	return ext, nil
+}
+
 // FeatureCount returns the number of features in the layer
 func (layer Layer) FeatureCount(opts ...FeatureCountOption) (int, error) {
 	fco := &featureCountOpts{}
@@ -2616,6 +4860,41 @@ func (g *Geometry) Area() float64 {
 	return float64(C.OGR_G_Area(g.handle))
 }
 
+// AreaInCRS returns the area of the geometry once reprojected to crs, expressed in the
+// squared linear units of crs. The geometry must have an associated SpatialRef and is
+// left untouched, the reprojection being done on a copy.
+//
+// For accurate areas of geographic geometries, crs should be an equal-area projection,
+// e.g. the one returned by NewEqualAreaSpatialRef.
+func (g *Geometry) AreaInCRS(crs *SpatialRef, opts ...AreaInCRSOption) (float64, error) {
+	ao := areaInCRSOpts{}
+	for _, o := range opts {
+		o.setAreaInCRSOpt(&ao)
+	}
+	if g == nil || g.handle == nil {
+		return 0, fmt.Errorf("geometry is empty")
+	}
+	if C.OGR_G_GetSpatialReference(g.handle) == nil {
+		return 0, fmt.Errorf("geometry has no spatial reference")
+	}
+	clone := &Geometry{
+		isOwned: true,
+		handle:  C.OGR_G_Clone(g.handle),
+	}
+	defer clone.Close()
+	if err := clone.Reproject(crs, ErrLogger(ao.errorHandler)); err != nil {
+		return 0, err
+	}
+	return clone.Area(), nil
+}
+
+// NewEqualAreaSpatialRef returns a world-wide equal-area SpatialRef, suitable for computing
+// areas in square meters with Geometry.AreaInCRS. It is the "WGS 84 / NSIDC EASE-Grid 2.0
+// Global" cylindrical equal-area projection (EPSG:6933).
+func NewEqualAreaSpatialRef(opts ...CreateSpatialRefOption) (*SpatialRef, error) {
+	return NewSpatialRefFromEPSG(6933, opts...)
+}
+
 // Name fetch WKT name for geometry type.
 func (g *Geometry) Name() string {
 	return C.GoString(C.OGR_G_GetGeometryName(g.handle))
@@ -2629,6 +4908,37 @@ func (g *Geometry) GeometryCount() int {
 	return int(C.OGR_G_GetGeometryCount(g.handle))
 }
 
+// PointCount returns the number of vertices of a point, linestring or linearring
+// geometry. Other geometry types (including polygons) will silently return 0, their
+// vertices must be accessed through SubGeometry.
+func (g *Geometry) PointCount() int {
+	return int(C.OGR_G_GetPointCount(g.handle))
+}
+
+// Point returns the coordinates of the i-th vertex of a point, linestring or linearring
+// geometry. z is 0 for 2D geometries.
+func (g *Geometry) Point(i int) (x, y, z float64) {
+	var cx, cy, cz C.double
+	C.OGR_G_GetPoint(g.handle, C.int(i), &cx, &cy, &cz)
+	return float64(cx), float64(cy), float64(cz)
+}
+
+// Points returns the x,y,z coordinates of all the vertices of a point, linestring or
+// linearring geometry. z is 0 for 2D geometries.
+func (g *Geometry) Points() [][3]float64 {
+	n := g.PointCount()
+	if n == 0 {
+		return nil
+	}
+	pts := make([][3]float64, n)
+	stride := C.int(unsafe.Sizeof(pts[0]))
+	C.OGR_G_GetPoints(g.handle,
+		unsafe.Pointer(&pts[0][0]), stride,
+		unsafe.Pointer(&pts[0][1]), stride,
+		unsafe.Pointer(&pts[0][2]), stride)
+	return pts
+}
+
 // Type fetch geometry type.
 func (g *Geometry) Type() GeometryType {
 	return GeometryType(C.OGR_G_GetGeometryType(g.handle))
@@ -2718,7 +5028,30 @@ func (g *Geometry) ForceToPolygon() *Geometry {
 	}
 }
 
+// AddPoint appends a 3D vertex to a linestring or linearring geometry, or sets the
+// coordinates of a point geometry.
+func (g *Geometry) AddPoint(x, y, z float64) {
+	C.OGR_G_AddPoint(g.handle, C.double(x), C.double(y), C.double(z))
+}
+
+// AddPoint2D appends a 2D vertex to a linestring or linearring geometry, or sets the
+// coordinates of a point geometry.
+func (g *Geometry) AddPoint2D(x, y float64) {
+	C.OGR_G_AddPoint_2D(g.handle, C.double(x), C.double(y))
+}
+
+// CloseRings forces the rings of a polygon (or multipolygon) to be closed, by adding
+// a copy of their first vertex at their end if needed.
+func (g *Geometry) CloseRings() {
+	C.OGR_G_CloseRings(g.handle)
+}
+
 // SubGeometry Fetch geometry from a geometry container.
+//
+// The returned geometry is a reference to the internal geometry of g, not a copy: modifying
+// it (e.g. with AddPoint) modifies g, and it must not be used after g has been closed.
+// Calling Close on it is not required, and will never free the memory owned by g. Use
+// SubGeometryClone to obtain an independent copy.
 func (g *Geometry) SubGeometry(subGeomIndex int, opts ...SubGeometryOption) (*Geometry, error) {
 	so := &subGeometryOpts{}
 	for _, o := range opts {
@@ -2735,6 +5068,20 @@ func (g *Geometry) SubGeometry(subGeomIndex int, opts ...SubGeometryOption) (*Ge
 	}, nil
 }
 
+// SubGeometryClone returns an independent copy of the subGeomIndex'th geometry of a
+// geometry container. Contrary to SubGeometry, the returned geometry can be modified
+// without affecting g, outlives g, and must be closed by the caller.
+func (g *Geometry) SubGeometryClone(subGeomIndex int, opts ...SubGeometryOption) (*Geometry, error) {
+	sub, err := g.SubGeometry(subGeomIndex, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Geometry{
+		isOwned: true,
+		handle:  C.OGR_G_Clone(sub.handle),
+	}, nil
+}
+
 // Intersects determines whether two geometries intersect. If GEOS is enabled, then
 // this is done in rigorous fashion otherwise TRUE is returned if the
 // envelopes (bounding boxes) of the two geometries overlap.
@@ -2751,6 +5098,73 @@ func (g *Geometry) Intersects(other *Geometry, opts ...IntersectsOption) (bool,
 	return ret != 0, nil
 }
 
+// predicate evaluates a binary spatial predicate between g and other
+func (g *Geometry) predicate(other *Geometry, opts []PredicateOption, fn func(ctx *C.cctx, g1, g2 C.OGRGeometryH) C.int) (bool, error) {
+	// If other geometry is nil, GDAL crashes
+	if g.handle == nil || other == nil || other.handle == nil {
+		return false, errors.New("geometry is empty")
+	}
+	po := &predicateOpts{}
+	for _, o := range opts {
+		o.setPredicateOpt(po)
+	}
+	cgc := createCGOContext(nil, po.errorHandler)
+	ret := fn(cgc.cPointer(), g.handle, other.handle)
+	if err := cgc.close(); err != nil {
+		return false, err
+	}
+	return ret != 0, nil
+}
+
+// Touches tests if the geometries have at least one point in common, but their interiors
+// do not intersect. GEOS support is required.
+func (g *Geometry) Touches(other *Geometry, opts ...PredicateOption) (bool, error) {
+	return g.predicate(other, opts, func(ctx *C.cctx, g1, g2 C.OGRGeometryH) C.int {
+		return C.godal_OGR_G_Touches(ctx, g1, g2)
+	})
+}
+
+// Crosses tests if the geometries have some but not all interior points in common, e.g.
+// a line crossing a polygon's boundary. GEOS support is required.
+func (g *Geometry) Crosses(other *Geometry, opts ...PredicateOption) (bool, error) {
+	return g.predicate(other, opts, func(ctx *C.cctx, g1, g2 C.OGRGeometryH) C.int {
+		return C.godal_OGR_G_Crosses(ctx, g1, g2)
+	})
+}
+
+// Within tests if this geometry lies inside the other geometry, i.e. is the converse of
+// Contains. GEOS support is required.
+func (g *Geometry) Within(other *Geometry, opts ...PredicateOption) (bool, error) {
+	return g.predicate(other, opts, func(ctx *C.cctx, g1, g2 C.OGRGeometryH) C.int {
+		return C.godal_OGR_G_Within(ctx, g1, g2)
+	})
+}
+
+// Overlaps tests if the geometries are of the same dimension and share some but not all
+// of their points, and their intersection is of the same dimension. GEOS support is required.
+func (g *Geometry) Overlaps(other *Geometry, opts ...PredicateOption) (bool, error) {
+	return g.predicate(other, opts, func(ctx *C.cctx, g1, g2 C.OGRGeometryH) C.int {
+		return C.godal_OGR_G_Overlaps(ctx, g1, g2)
+	})
+}
+
+// Disjoint tests if the geometries have no point in common, i.e. is the negation of
+// Intersects. GEOS support is required.
+func (g *Geometry) Disjoint(other *Geometry, opts ...PredicateOption) (bool, error) {
+	return g.predicate(other, opts, func(ctx *C.cctx, g1, g2 C.OGRGeometryH) C.int {
+		return C.godal_OGR_G_Disjoint(ctx, g1, g2)
+	})
+}
+
+// Equals tests if the geometries are structurally equal, i.e. are of the same type and have
+// the same vertices in the same order (as SQL/MM ST_OrderingEquals). Geometries covering
+// the same points with a different vertex order or starting point are not equal.
+func (g *Geometry) Equals(other *Geometry, opts ...PredicateOption) (bool, error) {
+	return g.predicate(other, opts, func(ctx *C.cctx, g1, g2 C.OGRGeometryH) C.int {
+		return C.godal_OGR_G_Equals(ctx, g1, g2)
+	})
+}
+
 // Intersection generates a new geometry which is the region of intersection of the two geometries operated on.
 func (g *Geometry) Intersection(other *Geometry, opts ...IntersectionOption) (*Geometry, error) {
 	// If other geometry is nil, GDAL crashes
@@ -2793,6 +5207,201 @@ func (g *Geometry) Union(other *Geometry, opts ...UnionOption) (*Geometry, error
 	}, nil
 }
 
+// UnaryUnion computes the union of all the given geometries in a single pass, which
+// is much faster than calling Union pairwise when dissolving many polygons. The
+// input geometries are left untouched. GDAL >= 3.7 built with GEOS support is required.
+func UnaryUnion(geoms []*Geometry, opts ...UnaryUnionOption) (*Geometry, error) {
+	if len(geoms) == 0 {
+		return nil, errors.New("no geometries to union")
+	}
+	hndls := make([]C.OGRGeometryH, len(geoms))
+	for i, g := range geoms {
+		if g == nil || g.handle == nil {
+			return nil, fmt.Errorf("geometry %d is empty", i)
+		}
+		hndls[i] = g.handle
+	}
+	uo := &unaryUnionOpts{}
+	for _, o := range opts {
+		o.setUnaryUnionOpt(uo)
+	}
+	cgc := createCGOContext(nil, uo.errorHandler)
+	hndl := C.godal_OGR_G_UnaryUnion(cgc.cPointer(), C.int(len(hndls)), (*C.OGRGeometryH)(unsafe.Pointer(&hndls[0])))
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	return &Geometry{
+		isOwned: true,
+		handle:  hndl,
+	}, nil
+}
+
+// Polygonize builds polygons from a collection of (multi)linestrings whose endpoints
+// are fully noded, e.g. the result of a UnaryUnion of lines. The result is a
+// geometry collection of polygons. GDAL must have been built with GEOS support.
+func (g *Geometry) Polygonize(opts ...GeometryPolygonizeOption) (*Geometry, error) {
+	if g.handle == nil {
+		return nil, errors.New("geometry is empty")
+	}
+	po := &geometryPolygonizeOpts{}
+	for _, o := range opts {
+		o.setGeometryPolygonizeOpt(po)
+	}
+	cgc := createCGOContext(nil, po.errorHandler)
+	hndl := C.godal_OGR_G_Polygonize(cgc.cPointer(), g.handle)
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	return &Geometry{
+		isOwned: true,
+		handle:  hndl,
+	}, nil
+}
+
+// ConvexHull computes the convex hull of the geometry. GDAL must have been built
+// with GEOS support.
+func (g *Geometry) ConvexHull(opts ...ConvexHullOption) (*Geometry, error) {
+	if g.handle == nil {
+		return nil, errors.New("geometry is empty")
+	}
+	co := &convexHullOpts{}
+	for _, o := range opts {
+		o.setConvexHullOpt(co)
+	}
+	cgc := createCGOContext(nil, co.errorHandler)
+	hndl := C.godal_OGR_G_ConvexHull(cgc.cPointer(), g.handle)
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	return &Geometry{
+		isOwned: true,
+		handle:  hndl,
+	}, nil
+}
+
+// Boundary computes the boundary of the geometry, e.g. the exterior and interior
+// rings of a polygon as a (multi)linestring. GDAL must have been built with GEOS support.
+func (g *Geometry) Boundary(opts ...BoundaryOption) (*Geometry, error) {
+	if g.handle == nil {
+		return nil, errors.New("geometry is empty")
+	}
+	bo := &boundaryOpts{}
+	for _, o := range opts {
+		o.setBoundaryOpt(bo)
+	}
+	cgc := createCGOContext(nil, bo.errorHandler)
+	hndl := C.godal_OGR_G_Boundary(cgc.cPointer(), g.handle)
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	return &Geometry{
+		isOwned: true,
+		handle:  hndl,
+	}, nil
+}
+
+// PointOnSurface returns a point guaranteed to lie inside the geometry, e.g. to be
+// used as a label point for concave polygons where the centroid may fall outside
+// of the surface. GDAL must have been built with GEOS support.
+func (g *Geometry) PointOnSurface(opts ...PointOnSurfaceOption) (*Geometry, error) {
+	if g.handle == nil {
+		return nil, errors.New("geometry is empty")
+	}
+	po := &pointOnSurfaceOpts{}
+	for _, o := range opts {
+		o.setPointOnSurfaceOpt(po)
+	}
+	cgc := createCGOContext(nil, po.errorHandler)
+	hndl := C.godal_OGR_G_PointOnSurface(cgc.cPointer(), g.handle)
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	return &Geometry{
+		isOwned: true,
+		handle:  hndl,
+	}, nil
+}
+
+// MakeValid returns a valid version of the geometry, e.g. to repair self-intersecting
+// polygons before computing their Union or Intersection. Already valid geometries are
+// returned as a (cloned) copy. GDAL must have been built with GEOS support.
+//
+// The MakeValidMethod and KeepCollapsed options require GDAL >= 3.4.
+func (g *Geometry) MakeValid(opts ...MakeValidOption) (*Geometry, error) {
+	if g.handle == nil {
+		return nil, errors.New("geometry is empty")
+	}
+	mo := &makeValidOpts{}
+	for _, o := range opts {
+		o.setMakeValidOpt(mo)
+	}
+	copts := sliceToCStringArray(mo.options)
+	defer copts.free()
+	cgc := createCGOContext(nil, mo.errorHandler)
+	hndl := C.godal_OGR_G_MakeValid(cgc.cPointer(), g.handle, copts.cPointer())
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	return &Geometry{
+		isOwned: true,
+		handle:  hndl,
+	}, nil
+}
+
+// Segmentize modifies the geometry in place so that no segment is longer than maxLength,
+// by adding intermediate vertices. It is typically used to densify long edges before
+// reprojecting a geometry.
+func (g *Geometry) Segmentize(maxLength float64, opts ...SegmentizeOption) error {
+	if g.handle == nil {
+		return errors.New("geometry is empty")
+	}
+	if maxLength <= 0 {
+		return fmt.Errorf("invalid segment length %g", maxLength)
+	}
+	so := &segmentizeOpts{}
+	for _, o := range opts {
+		o.setSegmentizeOpt(so)
+	}
+	cgc := createCGOContext(nil, so.errorHandler)
+	C.godal_OGR_G_Segmentize(cgc.cPointer(), g.handle, C.double(maxLength))
+	return cgc.close()
+}
+
+// Flags that can be passed to Geometry.SetPrecision
+const (
+	// PrecisionNoTopo makes SetPrecision only snap the coordinates, without preserving
+	// the topology (i.e. the output may be invalid)
+	PrecisionNoTopo = 1
+	// PrecisionKeepCollapsed makes SetPrecision keep the components that collapse into
+	// a lower dimensionality
+	PrecisionKeepCollapsed = 2
+)
+
+// SetPrecision returns a copy of the geometry whose coordinates are snapped to a grid of
+// size gridSize, removing the vertices that become duplicate. By default the output is
+// a valid geometry, which can be changed with the PrecisionNoTopo and
+// PrecisionKeepCollapsed flags (or 0 for the default behavior).
+//
+// Requires GDAL >= 3.9 built with GEOS >= 3.9.
+func (g *Geometry) SetPrecision(gridSize float64, flags int, opts ...SetPrecisionOption) (*Geometry, error) {
+	if g.handle == nil {
+		return nil, errors.New("geometry is empty")
+	}
+	so := &setPrecisionOpts{}
+	for _, o := range opts {
+		o.setSetPrecisionOpt(so)
+	}
+	cgc := createCGOContext(nil, so.errorHandler)
+	hndl := C.godal_OGR_G_SetPrecision(cgc.cPointer(), g.handle, C.double(gridSize), C.int(flags))
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	return &Geometry{
+		isOwned: true,
+		handle:  hndl,
+	}, nil
+}
+
 // Contains tests if this geometry contains the other geometry.
 func (g *Geometry) Contains(other *Geometry) bool {
 	ret := C.OGR_G_Contains(g.handle, other.handle)
@@ -2811,6 +5420,19 @@ func (g *Geometry) Valid() bool {
 	return ret != 0
 }
 
+// IsRing returns true if the geometry is a closed and simple linestring
+func (g *Geometry) IsRing() bool {
+	ret := C.OGR_G_IsRing(g.handle)
+	return ret != 0
+}
+
+// IsSimple returns true if the geometry has no anomalous points such as
+// self-intersections
+func (g *Geometry) IsSimple() bool {
+	ret := C.OGR_G_IsSimple(g.handle)
+	return ret != 0
+}
+
 // Bounds returns the geometry's envelope in the order minx,miny,maxx,maxy
 func (g *Geometry) Bounds(opts ...BoundsOption) ([4]float64, error) {
 	bo := boundsOpts{}
@@ -2830,7 +5452,7 @@ func (g *Geometry) Bounds(opts ...BoundsOption) ([4]float64, error) {
 	}
 	sr := g.SpatialRef()
 	defer sr.Close()
-	ret, err := reprojectBounds(bnds, sr, bo.sr)
+	ret, err := reprojectBounds(bnds, sr, bo.sr, bo.densifyPts)
 	if err != nil {
 		return bnds, err
 	}
@@ -2838,6 +5460,9 @@ func (g *Geometry) Bounds(opts ...BoundsOption) ([4]float64, error) {
 }
 
 // Close may reclaim memory from geometry. Must be called exactly once.
+//
+// Closing a geometry that is not owned by the caller (i.e. returned by SubGeometry or
+// Feature.Geometry) only invalidates the reference and leaves the parent object untouched.
 func (g *Geometry) Close() {
 	if g.handle == nil {
 		return
@@ -2863,6 +5488,62 @@ func (f *Feature) Geometry() *Geometry {
 	}
 }
 
+// GeometryCount returns the number of geometry fields of the feature
+func (f *Feature) GeometryCount() int {
+	return int(C.OGR_F_GetGeomFieldCount(f.handle))
+}
+
+// GeometryByIndex returns a handle to the feature's i'th geometry, for layers with
+// multiple geometry fields (e.g. GeoPackage). GeometryByIndex(0) is equivalent to
+// Geometry(). The returned geometry has a nil handle if i is out of range or if the
+// geometry is not set.
+func (f *Feature) GeometryByIndex(i int) *Geometry {
+	var hndl C.OGRGeometryH
+	if i >= 0 && i < f.GeometryCount() {
+		hndl = C.OGR_F_GetGeomFieldRef(f.handle, C.int(i))
+	}
+	return &Geometry{
+		isOwned: false,
+		handle:  hndl,
+	}
+}
+
+// SetGeometryByIndex overwrites the feature's i'th geometry, for layers with multiple
+// geometry fields. geom is copied into the feature.
+func (f *Feature) SetGeometryByIndex(i int, geom *Geometry, opts ...SetGeometryOption) error {
+	sgo := &setGeometryOpts{}
+	for _, o := range opts {
+		o.setSetGeometryOpt(sgo)
+	}
+	cgc := createCGOContext(nil, sgo.errorHandler)
+	C.godalFeatureSetGeometryByIndex(cgc.cPointer(), f.handle, C.int(i), geom.handle)
+	return cgc.close()
+}
+
+// GeometryWKB returns the feature's geometry in ISO WKB format. It is equivalent to
+// f.Geometry().WKB() without allocating an intermediate Geometry. A nil slice is
+// returned if the feature has no geometry.
+func (f *Feature) GeometryWKB(opts ...GeometryWKBOption) ([]byte, error) {
+	hndl := C.OGR_F_GetGeometryRef(f.handle)
+	if hndl == nil {
+		return nil, nil
+	}
+	wo := &geometryWKBOpts{}
+	for _, o := range opts {
+		o.setGeometryWKBOpt(wo)
+	}
+	var cwkb unsafe.Pointer
+	clen := C.int(0)
+	cgc := createCGOContext(nil, wo.errorHandler)
+	C.godalExportGeometryWKB(cgc.cPointer(), &cwkb, &clen, hndl, C.int(wo.byteOrder), C.int(wo.variant))
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	wkb := C.GoBytes(unsafe.Pointer(cwkb), clen)
+	C.free(unsafe.Pointer(cwkb))
+	return wkb, nil
+}
+
 // SetGeometry overwrites the feature's geometry
 func (f *Feature) SetGeometry(geom *Geometry, opts ...SetGeometryOption) error {
 	sgo := &setGeometryOpts{}
@@ -2874,6 +5555,41 @@ func (f *Feature) SetGeometry(geom *Geometry, opts ...SetGeometryOption) error {
 	return cgc.close()
 }
 
+// CreateField adds a new attribute field to the layer, e.g. on an existing layer
+// opened in update mode. Features already present in the layer will have the new
+// field unset.
+func (layer Layer) CreateField(fd *FieldDefinition, opts ...CreateFieldOption) error {
+	co := &createFieldOpts{}
+	for _, o := range opts {
+		o.setCreateFieldOpt(co)
+	}
+	cfd := fd.createHandle()
+	defer C.OGR_Fld_Destroy(cfd)
+	cgc := createCGOContext(nil, co.errorHandler)
+	C.godalLayerCreateField(cgc.cPointer(), layer.handle(), cfd)
+	return cgc.close()
+}
+
+// DeleteField deletes the index'th attribute field of the layer. Not all drivers
+// support deleting fields.
+func (layer Layer) DeleteField(index int, opts ...DeleteFieldOption) error {
+	do := &deleteFieldOpts{}
+	for _, o := range opts {
+		o.setDeleteFieldOpt(do)
+	}
+	cgc := createCGOContext(nil, do.errorHandler)
+	C.godalLayerDeleteField(cgc.cPointer(), layer.handle(), C.int(index))
+	return cgc.close()
+}
+
+// FieldIndex returns the index of the attribute field with the given name, or -1
+// if the layer has no such field.
+func (layer Layer) FieldIndex(name string) int {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return int(C.OGR_FD_GetFieldIndex(C.OGR_L_GetLayerDefn(layer.handle()), cname))
+}
+
 // SetGeometryColumnName set the name of feature first geometry field.
 // Deprecated when running with GDAL 3.6+, use SetGeometryColumnName on Layer instead.
 // No more supported when running with GDAL 3.9+.
@@ -2994,6 +5710,128 @@ func (f *Feature) SetFieldValue(field Field, value interface{}, opts ...SetField
 	return cgc.close()
 }
 
+func (f *Feature) checkFieldIndex(idx int) error {
+	if idx < 0 || idx >= int(C.OGR_F_GetFieldCount(f.handle)) {
+		return fmt.Errorf("invalid field index %d", idx)
+	}
+	return nil
+}
+
+// SetFieldInteger sets the value of the idx'th field of the feature. The value is
+// converted if the field is not of type FTInt. Values outside of the int32 range are
+// set as 64 bit integers, which returns an error for FTInt fields.
+func (f *Feature) SetFieldInteger(idx int, v int, opts ...SetFieldValueOption) error {
+	if err := f.checkFieldIndex(idx); err != nil {
+		return err
+	}
+	sfvo := &setFieldValueOpts{}
+	for _, o := range opts {
+		o.setSetFieldValueOpt(sfvo)
+	}
+	cgc := createCGOContext(nil, sfvo.errorHandler)
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		C.godalFeatureSetFieldInteger64(cgc.cPointer(), f.handle, C.int(idx), C.longlong(v))
+	} else {
+		C.godalFeatureSetFieldInteger(cgc.cPointer(), f.handle, C.int(idx), C.int(v))
+	}
+	return cgc.close()
+}
+
+// SetFieldDouble sets the value of the idx'th field of the feature. The value is
+// converted if the field is not of type FTReal.
+func (f *Feature) SetFieldDouble(idx int, v float64, opts ...SetFieldValueOption) error {
+	if err := f.checkFieldIndex(idx); err != nil {
+		return err
+	}
+	sfvo := &setFieldValueOpts{}
+	for _, o := range opts {
+		o.setSetFieldValueOpt(sfvo)
+	}
+	cgc := createCGOContext(nil, sfvo.errorHandler)
+	C.godalFeatureSetFieldDouble(cgc.cPointer(), f.handle, C.int(idx), C.double(v))
+	return cgc.close()
+}
+
+// SetFieldString sets the value of the idx'th field of the feature. The value is
+// converted if the field is not of type FTString.
+func (f *Feature) SetFieldString(idx int, v string, opts ...SetFieldValueOption) error {
+	if err := f.checkFieldIndex(idx); err != nil {
+		return err
+	}
+	sfvo := &setFieldValueOpts{}
+	for _, o := range opts {
+		o.setSetFieldValueOpt(sfvo)
+	}
+	cval := C.CString(v)
+	defer C.free(unsafe.Pointer(cval))
+	cgc := createCGOContext(nil, sfvo.errorHandler)
+	C.godalFeatureSetFieldString(cgc.cPointer(), f.handle, C.int(idx), cval)
+	return cgc.close()
+}
+
+// SetFieldNull sets the idx'th field of the feature to null. Contrary to an unset
+// field (see UnsetField), a null field is explicitly written as NULL by drivers
+// supporting it.
+func (f *Feature) SetFieldNull(idx int, opts ...SetFieldValueOption) error {
+	if err := f.checkFieldIndex(idx); err != nil {
+		return err
+	}
+	sfvo := &setFieldValueOpts{}
+	for _, o := range opts {
+		o.setSetFieldValueOpt(sfvo)
+	}
+	cgc := createCGOContext(nil, sfvo.errorHandler)
+	C.godalFeatureSetFieldNull(cgc.cPointer(), f.handle, C.int(idx))
+	return cgc.close()
+}
+
+// UnsetField clears the idx'th field of the feature, which will then be left out
+// when writing the feature (i.e. drivers will use their default value).
+func (f *Feature) UnsetField(idx int, opts ...SetFieldValueOption) error {
+	if err := f.checkFieldIndex(idx); err != nil {
+		return err
+	}
+	sfvo := &setFieldValueOpts{}
+	for _, o := range opts {
+		o.setSetFieldValueOpt(sfvo)
+	}
+	cgc := createCGOContext(nil, sfvo.errorHandler)
+	C.godalFeatureUnsetField(cgc.cPointer(), f.handle, C.int(idx))
+	return cgc.close()
+}
+
+// SetFrom copies the geometry and the attribute fields of src into f, matching fields
+// by name (fields of src missing from f are ignored). If forgiving is false, an error is
+// returned if a field or the geometry could not be copied, and f is left in an undefined
+// state.
+func (f *Feature) SetFrom(src *Feature, forgiving bool, opts ...SetFromOption) error {
+	if src == nil || src.handle == nil {
+		return errors.New("source feature is empty")
+	}
+	so := &setFromOpts{}
+	for _, o := range opts {
+		o.setSetFromOpt(so)
+	}
+	cforgiving := C.int(0)
+	if forgiving {
+		cforgiving = 1
+	}
+	cgc := createCGOContext(nil, so.errorHandler)
+	C.godalFeatureSetFrom(cgc.cPointer(), f.handle, src.handle, cforgiving)
+	return cgc.close()
+}
+
+// IsFieldSet returns whether the idx'th field of the feature has been assigned a
+// value, including null.
+func (f *Feature) IsFieldSet(idx int) bool {
+	return C.OGR_F_IsFieldSet(f.handle, C.int(idx)) != 0
+}
+
+// IsFieldNull returns whether the idx'th field of the feature is null.
+func (f *Feature) IsFieldNull(idx int) bool {
+	return C.OGR_F_IsFieldNull(f.handle, C.int(idx)) != 0
+}
+
 // Field is a Feature attribute
 type Field struct {
 	index int
@@ -3249,6 +6087,36 @@ func (layer Layer) CreateFeature(feat *Feature, opts ...CreateFeatureOption) err
 	return nil
 }
 
+// CreateFeatureBatch writes all the given features to the Layer inside a single
+// transaction, which is committed once all features have been created. If the creation
+// of any feature fails, the transaction is rolled back and none of the features are
+// written.
+//
+// For SQLite based drivers (e.g. GeoPackage) or PostGIS, this is typically orders of
+// magnitude faster than calling CreateFeature for each feature, as the latter commits
+// after each creation. An error is returned if the layer's driver does not support
+// transactions.
+func (layer Layer) CreateFeatureBatch(features []*Feature, opts ...CreateFeatureBatchOption) error {
+	cfo := createFeatureBatchOpts{}
+	for _, opt := range opts {
+		opt.setCreateFeatureBatchOpt(&cfo)
+	}
+	hfeats := make([]C.OGRFeatureH, len(features))
+	for i, f := range features {
+		if f == nil {
+			return fmt.Errorf("feature %d is nil", i)
+		}
+		hfeats[i] = f.handle
+	}
+	var phfeats *C.OGRFeatureH
+	if len(hfeats) > 0 {
+		phfeats = &hfeats[0]
+	}
+	cgc := createCGOContext(nil, cfo.errorHandler)
+	C.godalLayerCreateFeatureBatch(cgc.cPointer(), layer.handle(), phfeats, C.int(len(hfeats)))
+	return cgc.close()
+}
+
 // NewFeature creates a feature on Layer from a geometry
 func (layer Layer) NewFeature(geom *Geometry, opts ...NewFeatureOption) (*Feature, error) {
 	nfo := newFeatureOpts{}
@@ -3289,6 +6157,40 @@ func (layer Layer) DeleteFeature(feat *Feature, opts ...DeleteFeatureOption) err
 	return cgc.close()
 }
 
+// Flags that can be passed to Layer.AlterFieldDefn to select which properties of the
+// field definition must be altered. They can be combined, e.g. AlterFieldName|AlterFieldType
+const (
+	// AlterFieldName alters the field name
+	AlterFieldName = int(C.ALTER_NAME_FLAG)
+	// AlterFieldType alters the field type
+	AlterFieldType = int(C.ALTER_TYPE_FLAG)
+	// AlterFieldWidthPrecision alters the field width and precision
+	AlterFieldWidthPrecision = int(C.ALTER_WIDTH_PRECISION_FLAG)
+	// AlterFieldNullable alters the field nullability
+	AlterFieldNullable = int(C.ALTER_NULLABLE_FLAG)
+	// AlterFieldDefault alters the field default value
+	AlterFieldDefault = int(C.ALTER_DEFAULT_FLAG)
+	// AlterFieldAll alters all the above properties
+	AlterFieldAll = int(C.ALTER_ALL_FLAG)
+)
+
+// AlterFieldDefn alters the definition of the index'th field of the layer (e.g. to rename
+// or retype it), using the properties of newDef selected by flags (see AlterFieldName and
+// related flags).
+//
+// Not all drivers support altering fields, and some only support a subset of the flags.
+func (layer Layer) AlterFieldDefn(index int, newDef *FieldDefinition, flags int, opts ...AlterFieldDefnOption) error {
+	ao := &alterFieldDefnOpts{}
+	for _, o := range opts {
+		o.setAlterFieldDefnOpt(ao)
+	}
+	cfd := newDef.createHandle()
+	defer C.OGR_Fld_Destroy(cfd)
+	cgc := createCGOContext(nil, ao.errorHandler)
+	C.godalLayerAlterFieldDefn(cgc.cPointer(), layer.handle(), C.int(index), cfd, C.int(flags))
+	return cgc.close()
+}
+
 // SetGeometryColumnName set the name of feature first geometry field.
 // Only supported when running with GDAL 3.6+.
 func (layer Layer) SetGeometryColumnName(name string, opts ...SetGeometryColumnNameOption) error {
@@ -3318,8 +6220,10 @@ func (ds *Dataset) CreateLayer(name string, sr *SpatialRef, gtype GeometryType,
 	}
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
+	copts := sliceToCStringArray(co.options)
+	defer copts.free()
 	cgc := createCGOContext(nil, co.errorHandler)
-	hndl := C.godalCreateLayer(cgc.cPointer(), ds.handle(), (*C.char)(unsafe.Pointer(cname)), srHandle, C.OGRwkbGeometryType(gtype))
+	hndl := C.godalCreateLayer(cgc.cPointer(), ds.handle(), (*C.char)(unsafe.Pointer(cname)), srHandle, C.OGRwkbGeometryType(gtype), copts.cPointer())
 	if err := cgc.close(); err != nil {
 		return Layer{}, err
 	}
@@ -3361,6 +6265,26 @@ func (ds *Dataset) LayerByName(name string) *Layer {
 	return &Layer{majorObject{C.GDALMajorObjectH(hndl)}}
 }
 
+// RenameLayer renames the layer oldName of the dataset to newName. An error is returned
+// if the layer does not exist or if the driver does not support renaming layers.
+//
+// Requires GDAL >= 3.5
+func (ds *Dataset) RenameLayer(oldName, newName string, opts ...RenameLayerOption) error {
+	ro := renameLayerOpts{}
+	for _, opt := range opts {
+		opt.setRenameLayerOpt(&ro)
+	}
+	lyr := ds.LayerByName(oldName)
+	if lyr == nil {
+		return fmt.Errorf("layer %s not found", oldName)
+	}
+	cname := C.CString(newName)
+	defer C.free(unsafe.Pointer(cname))
+	cgc := createCGOContext(nil, ro.errorHandler)
+	C.godalLayerRename(cgc.cPointer(), lyr.handle(), cname)
+	return cgc.close()
+}
+
 // ResultSet is a Layer generated by Dataset.ExecuteSQL
 type ResultSet struct {
 	Layer
@@ -3405,6 +6329,35 @@ func (ds *Dataset) ExecuteSQL(sql string, opts ...ExecuteSQLOption) (*ResultSet,
 	return &ResultSet{layer, ds, false}, nil
 }
 
+// Columns returns the definitions of the attribute fields of the ResultSet, in order.
+// It allows to inspect the schema of the result before iterating over its features. A
+// nil slice is returned for statements that do not return rows.
+func (rs *ResultSet) Columns() []FieldDefinition {
+	if rs.handle() == nil {
+		return nil
+	}
+	defn := C.OGR_L_GetLayerDefn(rs.handle())
+	n := int(C.OGR_FD_GetFieldCount(defn))
+	cols := make([]FieldDefinition, n)
+	for i := 0; i < n; i++ {
+		fdefn := C.OGR_FD_GetFieldDefn(defn, C.int(i))
+		col := FieldDefinition{
+			name:  C.GoString(C.OGR_Fld_GetNameRef(fdefn)),
+			ftype: FieldType(C.OGR_Fld_GetType(fdefn)),
+		}
+		col.width = int(C.OGR_Fld_GetWidth(fdefn))
+		col.precision = int(C.OGR_Fld_GetPrecision(fdefn))
+		nullable := C.OGR_Fld_IsNullable(fdefn) != 0
+		col.nullable = &nullable
+		if cdef := C.OGR_Fld_GetDefault(fdefn); cdef != nil {
+			def := C.GoString(cdef)
+			col.defaultValue = &def
+		}
+		cols[i] = col
+	}
+	return cols
+}
+
 // Close releases results of Dataset.ExecuteSQL
 func (rs *ResultSet) Close(opts ...CloseResultSetOption) error {
 	if rs.closed {
@@ -3470,6 +6423,27 @@ func (ds *Dataset) CommitTransaction(opts ...CommitTransactionOption) error {
 	return err
 }
 
+// NewGeometry creates a new empty geometry of type gtype, to be filled with AddPoint,
+// AddPoint2D or AddGeometry. It returns nil if gtype is not a supported type.
+//
+// Example building a polygon:
+//
+//	ring := NewGeometry(GTLinearRing)
+//	ring.AddPoint2D(0, 0)
+//	ring.AddPoint2D(1, 0)
+//	ring.AddPoint2D(1, 1)
+//	poly := NewGeometry(GTPolygon)
+//	_ = poly.AddGeometry(ring)
+//	ring.Close()
+//	poly.CloseRings()
+func NewGeometry(gtype GeometryType) *Geometry {
+	hndl := C.OGR_G_CreateGeometry(C.OGRwkbGeometryType(gtype))
+	if hndl == nil {
+		return nil
+	}
+	return &Geometry{isOwned: true, handle: hndl}
+}
+
 // NewGeometryFromGeoJSON creates a new Geometry from its GeoJSON representation
 func NewGeometryFromGeoJSON(geoJSON string, opts ...NewGeometryOption) (*Geometry, error) {
 	no := &newGeometryOpts{}
@@ -3541,7 +6515,8 @@ func (g *Geometry) WKT(opts ...GeometryWKTOption) (string, error) {
 	return wkt, nil
 }
 
-// WKB returns the Geomtry's WKB representation
+// WKB returns the Geomtry's WKB representation. By default, little-endian ISO WKB is
+// exported; use the ByteOrder and Variant options to change the encoding.
 func (g *Geometry) WKB(opts ...GeometryWKBOption) ([]byte, error) {
 	wo := &geometryWKBOpts{}
 	for _, o := range opts {
@@ -3550,7 +6525,7 @@ func (g *Geometry) WKB(opts ...GeometryWKBOption) ([]byte, error) {
 	var cwkb unsafe.Pointer
 	clen := C.int(0)
 	cgc := createCGOContext(nil, wo.errorHandler)
-	C.godalExportGeometryWKB(cgc.cPointer(), &cwkb, &clen, g.handle)
+	C.godalExportGeometryWKB(cgc.cPointer(), &cwkb, &clen, g.handle, C.int(wo.byteOrder), C.int(wo.variant))
 	if err := cgc.close(); err != nil {
 		return nil, err
 	}
@@ -3597,6 +6572,27 @@ func (g *Geometry) Transform(trn *Transform, opts ...GeometryTransformOption) er
 	return cgc.close()
 }
 
+// TransformPartial transforms the given geometry like Transform, but instead of failing when
+// some of its points cannot be transformed, those points are removed from the geometry. It
+// returns the number of points that failed to transform and were dropped, so the caller can
+// decide to reject partially transformed geometries.
+//
+// Points are only dropped from linestrings and polygon rings: a point geometry that fails to
+// transform still returns an error. g is expected to already be in the supplied Transform
+// source SpatialRef.
+func (g *Geometry) TransformPartial(trn *Transform, opts ...GeometryTransformOption) (int, error) {
+	gt := &geometryTransformOpts{}
+	for _, o := range opts {
+		o.setGeometryTransformOpt(gt)
+	}
+	cgc := createCGOContext([]string{"OGR_ENABLE_PARTIAL_REPROJECTION=YES"}, gt.errorHandler)
+	dropped := C.godalGeometryTransformPartial(cgc.cPointer(), g.handle, trn.handle, trn.dst)
+	if err := cgc.close(); err != nil {
+		return 0, err
+	}
+	return int(dropped), nil
+}
+
 // GeoJSON returns the geometry in geojson format. The geometry is expected to be in epsg:4326
 // projection per RFCxxx
 //
@@ -3648,15 +6644,23 @@ type VSIFile struct {
 }
 
 // VSIOpen opens path. path can be virtual, eg beginning with /vsimem/
+//
+// The file is opened read-only unless another access mode is given with VSIOpenMode.
+// Open failures are reported with the error message set by the underlying
+// filesystem handler.
 func VSIOpen(path string, opts ...VSIOpenOption) (*VSIFile, error) {
-	vo := &vsiOpenOpts{}
+	vo := &vsiOpenOpts{
+		mode: "r",
+	}
 	for _, o := range opts {
 		o.setVSIOpenOpt(vo)
 	}
 	cname := unsafe.Pointer(C.CString(path))
 	defer C.free(cname)
+	cmode := unsafe.Pointer(C.CString(vo.mode))
+	defer C.free(cmode)
 	cgc := createCGOContext(nil, vo.errorHandler)
-	hndl := C.godalVSIOpen(cgc.cPointer(), (*C.char)(cname))
+	hndl := C.godalVSIOpen(cgc.cPointer(), (*C.char)(cname), (*C.char)(cmode))
 	if err := cgc.close(); err != nil {
 		return nil, err
 	}
@@ -3690,6 +6694,197 @@ func VSIUnlink(path string, opts ...VSIUnlinkOption) error {
 	return cgc.close()
 }
 
+// VSIMemCreate creates (or replaces) the /vsimem/ file at path with the given content, e.g.
+// to open an in-memory GeoTIFF with Open. The data is copied once into memory owned by
+// gdal (as Go memory may not be retained by C code), and is released when the file is
+// unlinked.
+func VSIMemCreate(path string, data []byte, opts ...VSIMemOption) error {
+	vo := &vsiMemOpts{}
+	for _, o := range opts {
+		o.setVSIMemOpt(vo)
+	}
+	if !strings.HasPrefix(path, "/vsimem/") {
+		return fmt.Errorf("%s is not a /vsimem/ path", path)
+	}
+	cname := unsafe.Pointer(C.CString(path))
+	defer C.free(cname)
+	var cdata unsafe.Pointer
+	if len(data) > 0 {
+		cdata = unsafe.Pointer(&data[0])
+	}
+	cgc := createCGOContext(nil, vo.errorHandler)
+	C.godalVSIMemCreate(cgc.cPointer(), (*C.char)(cname), cdata, C.size_t(len(data)))
+	return cgc.close()
+}
+
+// VSIMemSteal returns the content of the /vsimem/ file at path and removes it, without
+// going through a VSIOpen/Read loop. It is typically used to retrieve a file written
+// by gdal, e.g. the output of Translate to a /vsimem/ location. The content is copied
+// once into the returned Go slice, and the gdal buffer is released.
+func VSIMemSteal(path string, opts ...VSIMemOption) ([]byte, error) {
+	vo := &vsiMemOpts{}
+	for _, o := range opts {
+		o.setVSIMemOpt(vo)
+	}
+	cname := unsafe.Pointer(C.CString(path))
+	defer C.free(cname)
+	clen := C.size_t(0)
+	cgc := createCGOContext(nil, vo.errorHandler)
+	cdata := C.godalVSIMemSteal(cgc.cPointer(), (*C.char)(cname), &clen)
+	if err := cgc.close(); err != nil {
+		return nil, err
+	}
+	defer C.VSIFree(cdata)
+	// copy by chunks as C.GoBytes is limited to 2GB
+	const chunk = 1 << 30
+	data := make([]byte, int(clen))
+	for off := 0; off < len(data); off += chunk {
+		n := len(data) - off
+		if n > chunk {
+			n = chunk
+		}
+		copy(data[off:off+n], (*[chunk]byte)(unsafe.Pointer(uintptr(cdata) + uintptr(off)))[:n:n])
+	}
+	return data, nil
+}
+
+// SetPathSpecificOption sets a configuration option that only applies to the files whose
+// path starts with pathPrefix, e.g. to use different credentials for different buckets:
+//
+//	godal.SetPathSpecificOption("/vsis3/bucket-a", "AWS_ACCESS_KEY_ID", keyA)
+//	godal.SetPathSpecificOption("/vsis3/bucket-b", "AWS_ACCESS_KEY_ID", keyB)
+//
+// The option is set for the whole process, and takes precedence over a global or thread-local
+// configuration option with the same key.
+//
+// Requires GDAL >= 3.6
+func SetPathSpecificOption(pathPrefix, key, value string, opts ...PathSpecificOption) error {
+	return setPathSpecificOption(pathPrefix, key, value, false, opts)
+}
+
+// SetCredential sets a credential (or more generally a configuration option) for the files
+// whose path starts with pathPrefix. It behaves as SetPathSpecificOption, but is also
+// available with GDAL 3.5 where path specific options were restricted to credentials.
+//
+// Requires GDAL >= 3.5
+func SetCredential(pathPrefix, key, value string, opts ...PathSpecificOption) error {
+	return setPathSpecificOption(pathPrefix, key, value, true, opts)
+}
+
+func setPathSpecificOption(pathPrefix, key, value string, credential bool, opts []PathSpecificOption) error {
+	po := &pathSpecificOpts{}
+	for _, o := range opts {
+		o.setPathSpecificOpt(po)
+	}
+	cprefix := C.CString(pathPrefix)
+	defer C.free(unsafe.Pointer(cprefix))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	cval := C.CString(value)
+	defer C.free(unsafe.Pointer(cval))
+	ccred := C.int(0)
+	if credential {
+		ccred = 1
+	}
+	cgc := createCGOContext(nil, po.errorHandler)
+	C.godalVSISetPathSpecificOption(cgc.cPointer(), cprefix, ckey, cval, ccred)
+	return cgc.close()
+}
+
+// ClearPathSpecificOptions removes the options set with SetPathSpecificOption or
+// SetCredential for pathPrefix, e.g. before setting rotated credentials. All the path
+// specific options are removed if pathPrefix is empty.
+//
+// Requires GDAL >= 3.5
+func ClearPathSpecificOptions(pathPrefix string, opts ...PathSpecificOption) error {
+	po := &pathSpecificOpts{}
+	for _, o := range opts {
+		o.setPathSpecificOpt(po)
+	}
+	cprefix := (*C.char)(nil)
+	if len(pathPrefix) > 0 {
+		cprefix = C.CString(pathPrefix)
+		defer C.free(unsafe.Pointer(cprefix))
+	}
+	cgc := createCGOContext(nil, po.errorHandler)
+	C.godalVSIClearPathSpecificOptions(cgc.cPointer(), cprefix)
+	return cgc.close()
+}
+
+// VSICurlClearCache clears all the cached data (file properties, directory listings and
+// downloaded blocks) of the /vsicurl/ based filesystems, i.e. /vsicurl/, /vsis3/, /vsigs/,
+// /vsiaz/ etc. It should be called when remote objects may have been overwritten, so that
+// stale data is not served.
+//
+// Datasets that are currently open may still hold their own cached blocks.
+func VSICurlClearCache() {
+	C.VSICurlClearCache()
+}
+
+// VSICurlPartialClearCache clears the cached data of the /vsicurl/ based filesystems for
+// the files whose name starts with prefix, e.g. "/vsis3/bucket/key.tif"
+func VSICurlPartialClearCache(prefix string) {
+	cprefix := C.CString(prefix)
+	defer C.free(unsafe.Pointer(cprefix))
+	C.VSICurlPartialClearCache(cprefix)
+}
+
+// VSICacheStats returns the amount of memory, in bytes, currently used by gdal's raster
+// block cache, and the maximum size it is allowed to grow to (as set by the GDAL_CACHEMAX
+// configuration option). The block cache holds the decoded blocks of all open datasets.
+// It does not cover the download cache of the /vsicurl/ based filesystems, which does not
+// expose statistics and is cleared with VSICurlClearCache.
+func VSICacheStats() (used, max int64) {
+	return int64(C.GDALGetCacheUsed64()), int64(C.GDALGetCacheMax64())
+}
+
+// BlockCacheStats is an alias for VSICacheStats, named after the cache it actually reports on.
+func BlockCacheStats() (used, max int64) {
+	return VSICacheStats()
+}
+
+// CreateSOZip creates a seek-optimized zip file (SOZip) at zipPath, containing the given files.
+// files maps the name of each file inside the archive to the path (possibly virtual, e.g.
+// beginning with /vsimem/) of the file to add.
+//
+// Members of the created archive can then be accessed efficiently through the /vsizip/
+// filesystem, e.g. /vsizip/path/to/archive.zip/member.tif
+//
+// Requires GDAL >= 3.7
+func CreateSOZip(zipPath string, files map[string]string, opts ...CreateSOZipOption) error {
+	so := &createSOZipOpts{}
+	for _, o := range opts {
+		o.setCreateSOZipOpt(so)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no files to add to %s", zipPath)
+	}
+	archiveNames := make([]string, 0, len(files))
+	for name := range files {
+		archiveNames = append(archiveNames, name)
+	}
+	sort.Strings(archiveNames)
+	inputNames := make([]string, len(archiveNames))
+	for i, name := range archiveNames {
+		inputNames[i] = files[name]
+	}
+	options := append([]string{"SOZIP_ENABLED=YES"}, so.options...)
+
+	cname := unsafe.Pointer(C.CString(zipPath))
+	defer C.free(cname)
+	carchiveNames := sliceToCStringArray(archiveNames)
+	defer carchiveNames.free()
+	cinputNames := sliceToCStringArray(inputNames)
+	defer cinputNames.free()
+	coptions := sliceToCStringArray(options)
+	defer coptions.free()
+
+	cgc := createCGOContext(nil, so.errorHandler)
+	C.godalCreateSOZip(cgc.cPointer(), (*C.char)(cname), carchiveNames.cPointer(),
+		cinputNames.cPointer(), coptions.cPointer())
+	return cgc.close()
+}
+
 var _ io.ReadCloser = &VSIFile{}
 
 // Read is the standard io.Reader interface
@@ -3709,6 +6904,70 @@ func (vf *VSIFile) Read(buf []byte) (int, error) {
 	return int(n), nil
 }
 
+var _ io.WriterTo = &VSIFile{}
+
+// vsiCopyBufferSize is the size of the buffer used by VSIFile.WriteTo
+const vsiCopyBufferSize = 1024 * 1024
+
+// WriteTo is the standard io.WriterTo interface. It copies the remainder of the file
+// to w using a large intermediate buffer, and is used by io.Copy.
+func (vf *VSIFile) WriteTo(w io.Writer) (int64, error) {
+	buf := make([]byte, vsiCopyBufferSize)
+	total := int64(0)
+	for {
+		n, rerr := vf.Read(buf)
+		if n > 0 {
+			nw, werr := w.Write(buf[:n])
+			total += int64(nw)
+			if werr != nil {
+				return total, werr
+			}
+			if nw != n {
+				return total, io.ErrShortWrite
+			}
+		}
+		if rerr == io.EOF {
+			return total, nil
+		}
+		if rerr != nil {
+			return total, rerr
+		}
+	}
+}
+
+var _ io.Writer = &VSIFile{}
+
+// Write is the standard io.Writer interface. The file must have been opened
+// with a VSIOpenMode allowing writing.
+func (vf *VSIFile) Write(buf []byte) (int, error) {
+	if len(buf) == 0 {
+		return 0, nil
+	}
+	var errmsg *C.char
+	n := C.godalVSIWrite(vf.handle, unsafe.Pointer(&buf[0]), C.int(len(buf)), &errmsg)
+	if errmsg != nil {
+		defer C.free(unsafe.Pointer(errmsg))
+		return int(n), errors.New(C.GoString(errmsg))
+	}
+	return int(n), nil
+}
+
+// EOF returns true if the end of file has been reached, i.e. if a previous read
+// tried to read past the end of the file.
+func (vf *VSIFile) EOF() bool {
+	return C.VSIFEofL(vf.handle) != 0
+}
+
+// Flush flushes pending writes to the underlying storage.
+func (vf *VSIFile) Flush() error {
+	errmsg := C.godalVSIFlush(vf.handle)
+	if errmsg != nil {
+		defer C.free(unsafe.Pointer(errmsg))
+		return errors.New(C.GoString(errmsg))
+	}
+	return nil
+}
+
 // KeySizerReaderAt is the interface expected when calling RegisterVSIHandler
 //
 // ReadAt() is a standard io.ReaderAt that takes a key (i.e. filename) as argument.
@@ -3929,7 +7188,38 @@ func BuildVRT(dstVRTName string, sourceDatasets []string, switches []string, opt
 	if err := cgc.close(); err != nil {
 		return nil, err
 	}
-	return &Dataset{majorObject{C.GDALMajorObjectH(hndl)}}, nil
+	ds := &Dataset{majorObject{C.GDALMajorObjectH(hndl)}}
+	if len(bvo.ovrLevels) > 0 {
+		if err := ds.AddVirtualOverviews(bvo.resampling, bvo.ovrLevels, ErrLogger(bvo.errorHandler)); err != nil {
+			ds.Close()
+			return nil, err
+		}
+	}
+	return ds, nil
+}
+
+// AddVirtualOverviews adds virtual overviews to a VRT dataset, i.e. an <OverviewList>
+// element listing the overview levels that gdal will compute on the fly (with the given
+// resampling) from the full resolution sources. No pixels are written, and the overviews
+// are persisted in the VRT file when the dataset is closed.
+//
+// Requires GDAL >= 3.2
+func (ds *Dataset) AddVirtualOverviews(resampling ResamplingAlg, levels []int, opts ...AddVirtualOverviewsOption) error {
+	ao := addVirtualOverviewsOpts{}
+	for _, opt := range opts {
+		opt.setAddVirtualOverviewsOpt(&ao)
+	}
+	if !CheckMinVersion(3, 2, 0) {
+		return fmt.Errorf("virtual overviews require gdal >= 3.2")
+	}
+	if drv := ds.Driver().ShortName(); drv != "VRT" {
+		return fmt.Errorf("virtual overviews are only supported on VRT datasets, not %s", drv)
+	}
+	if len(levels) == 0 {
+		return fmt.Errorf("no overview levels given")
+	}
+	return ds.BuildOverviews(Levels(levels...), Resampling(resampling),
+		ConfigOption("VRT_VIRTUAL_OVERVIEWS=YES"), ErrLogger(ao.errorHandler))
 }
 
 // GridCreate, creates a grid from scattered data, given provided gridding parameters as a string (pszAlgorithm)
@@ -4037,6 +7327,16 @@ func (ds *Dataset) Dem(destPath, processingMode string, colorFilename string, sw
 	for _, opt := range opts {
 		opt.setDemOpt(&demOpts)
 	}
+	if demOpts.driver != "" {
+		dname := string(demOpts.driver)
+		if dm, ok := driverMappings[demOpts.driver]; ok {
+			dname = dm.rasterName
+		}
+		switches = append(switches[:len(switches):len(switches)], "-of", dname)
+	}
+	for _, copt := range demOpts.creation {
+		switches = append(switches[:len(switches):len(switches)], "-co", copt)
+	}
 
 	cswitches := sliceToCStringArray(switches)
 	defer cswitches.free()
@@ -4186,7 +7486,16 @@ func (ds *Dataset) GCPProjection() string {
 	return C.GoString(C.godalGetGCPProjection(ds.handle()))
 }
 
+// GCPCount returns the number of GCPs of the dataset, without allocating them as GCPs() does
+func (ds *Dataset) GCPCount() int {
+	return int(C.GDALGetGCPCount(ds.handle()))
+}
+
 // SetGCPs runs the GDALSetGCPs function
+//
+// The spatial reference of the GCPs is given with either the GCPSpatialRef or the
+// GCPProjection option. If both are provided, GCPSpatialRef takes precedence. If none
+// is provided, the GCPs are set with an empty spatial reference.
 func (ds *Dataset) SetGCPs(GCPList []GCP, opts ...SetGCPsOption) error {
 	setGCPsOpts := setGCPsOpts{}
 	for _, opt := range opts {
@@ -4241,6 +7550,17 @@ func (ds *Dataset) SetGCPs(GCPList []GCP, opts ...SetGCPsOption) error {
 	return nil
 }
 
+// ClearGCPs removes all the GCPs of the dataset, i.e. it runs GDALSetGCPs with an empty list
+func (ds *Dataset) ClearGCPs(opts ...ClearGCPsOption) error {
+	co := clearGCPsOpts{}
+	for _, opt := range opts {
+		opt.setClearGCPsOpt(&co)
+	}
+	cgc := createCGOContext(nil, co.errorHandler)
+	C.godalClearGCPs(cgc.cPointer(), ds.handle())
+	return cgc.close()
+}
+
 // Convert list of GCPs to a GDAL GeoTransorm array
 func GCPsToGeoTransform(GCPList []GCP, opts ...GCPsToGeoTransformOption) ([6]float64, error) {
 	gco := gcpsToGeoTransformOpts{}
@@ -4298,12 +7618,72 @@ func GCPsToGeoTransform(GCPList []GCP, opts ...GCPsToGeoTransformOption) ([6]flo
 	return ret, nil
 }
 
+// RPCInfo holds the Rational Polynomial Coefficients of a dataset, as parsed from its
+// "RPC" metadata domain. It mirrors the structure of the GDALRPCInfoV2 type.
+type RPCInfo struct {
+	ErrBias, ErrRand                             float64
+	LineOff, SampOff, LatOff, LongOff, HeightOff float64
+	LineScale, SampScale, LatScale, LongScale    float64
+	HeightScale                                  float64
+	LineNumCoeff, LineDenCoeff                   [20]float64
+	SampNumCoeff, SampDenCoeff                   [20]float64
+	MinLong, MinLat, MaxLong, MaxLat             float64
+}
+
+// RPC returns the RPC coefficients of the dataset, and false if the dataset has no
+// (or invalid) RPC metadata. It wraps GDALExtractRPCInfoV2.
+//
+// Use the RPCDEM option of PixelToGeo/GeoToPixel to take the terrain elevation into
+// account when transforming coordinates with these RPCs.
+func (ds *Dataset) RPC() (*RPCInfo, bool) {
+	var crpc C.GDALRPCInfoV2
+	if C.godalGetRPCInfo(ds.handle(), &crpc) == 0 {
+		return nil, false
+	}
+	rpc := &RPCInfo{
+		ErrBias:     float64(crpc.dfERR_BIAS),
+		ErrRand:     float64(crpc.dfERR_RAND),
+		LineOff:     float64(crpc.dfLINE_OFF),
+		SampOff:     float64(crpc.dfSAMP_OFF),
+		LatOff:      float64(crpc.dfLAT_OFF),
+		LongOff:     float64(crpc.dfLONG_OFF),
+		HeightOff:   float64(crpc.dfHEIGHT_OFF),
+		LineScale:   float64(crpc.dfLINE_SCALE),
+		SampScale:   float64(crpc.dfSAMP_SCALE),
+		LatScale:    float64(crpc.dfLAT_SCALE),
+		LongScale:   float64(crpc.dfLONG_SCALE),
+		HeightScale: float64(crpc.dfHEIGHT_SCALE),
+		MinLong:     float64(crpc.dfMIN_LONG),
+		MinLat:      float64(crpc.dfMIN_LAT),
+		MaxLong:     float64(crpc.dfMAX_LONG),
+		MaxLat:      float64(crpc.dfMAX_LAT),
+	}
+	for i := 0; i < 20; i++ {
+		rpc.LineNumCoeff[i] = float64(crpc.adfLINE_NUM_COEFF[i])
+		rpc.LineDenCoeff[i] = float64(crpc.adfLINE_DEN_COEFF[i])
+		rpc.SampNumCoeff[i] = float64(crpc.adfSAMP_NUM_COEFF[i])
+		rpc.SampDenCoeff[i] = float64(crpc.adfSAMP_DEN_COEFF[i])
+	}
+	return rpc, true
+}
+
 type cgoContext struct {
 	cctx *C.cctx
 	opts cStringArray
+	// reused is set for contexts owned by a ReadContext, which must not be freed by close()
+	reused bool
 }
 
+// cgoContextMallocs counts the C allocations made by createCGOContext, i.e. the context
+// itself and its configuration options. It is used to measure the effect of ReadContext.
+var cgoContextMallocs uint64
+
 func createCGOContext(configOptions []string, eh ErrorHandler) cgoContext {
+	mallocs := uint64(1)
+	if len(configOptions) > 0 {
+		mallocs += uint64(len(configOptions) + 1)
+	}
+	atomic.AddUint64(&cgoContextMallocs, mallocs)
 	cgc := cgoContext{
 		opts: sliceToCStringArray(configOptions),
 		cctx: (*C.cctx)(C.malloc(C.size_t(unsafe.Sizeof(C.cctx{})))),
@@ -4311,6 +7691,8 @@ func createCGOContext(configOptions []string, eh ErrorHandler) cgoContext {
 	cgc.cctx.configOptions = cgc.opts.cPointer()
 	cgc.cctx.failed = 0
 	cgc.cctx.errMessage = nil
+	cgc.cctx.errCategory = 0
+	cgc.cctx.errCode = 0
 	if eh != nil {
 		cgc.cctx.handlerIdx = C.int(registerErrorHandler(eh))
 	} else {
@@ -4325,8 +7707,10 @@ func (cgc cgoContext) cPointer() *C.cctx {
 
 // frees the context and returns any error it may contain
 func (cgc cgoContext) close() error {
-	cgc.opts.free()
-	defer C.free(unsafe.Pointer(cgc.cctx))
+	if !cgc.reused {
+		cgc.opts.free()
+		defer C.free(unsafe.Pointer(cgc.cctx))
+	}
 	if cgc.cctx.errMessage != nil {
 		/* debug code
 		if cgc.cctx.handlerIdx != 0 {
@@ -4334,7 +7718,11 @@ func (cgc cgoContext) close() error {
 		}
 		*/
 		defer C.free(unsafe.Pointer(cgc.cctx.errMessage))
-		return errors.New(C.GoString(cgc.cctx.errMessage))
+		return GDALError{
+			Category: ErrorCategory(cgc.cctx.errCategory),
+			Code:     int(cgc.cctx.errCode),
+			Message:  C.GoString(cgc.cctx.errMessage),
+		}
 	}
 
 	if cgc.cctx.handlerIdx != 0 {
@@ -4343,3 +7731,80 @@ func (cgc cgoContext) close() error {
 	}
 	return nil
 }
+
+// ReadContext holds the C context used to report errors and pass configuration options
+// to gdal, so that it can be reused across Band.IO and Dataset.IO calls instead of being
+// allocated and freed for each of them, e.g. in a tight tile serving loop:
+//
+//	rc := godal.NewReadContext(godal.ConfigOption("GDAL_DISABLE_READDIR_ON_OPEN=EMPTY_DIR"))
+//	defer rc.Close()
+//	for _, tile := range tiles {
+//		err := ds.Read(tile.X, tile.Y, buf, 256, 256, rc)
+//	}
+//
+// A ReadContext is passed directly as a BandIOOption or DatasetIOOption. The configuration
+// options it was created with replace the ones given to the individual calls: calls that
+// specify their own ConfigOption do not use the ReadContext.
+//
+// A ReadContext must not be used concurrently: use one per goroutine.
+type ReadContext struct {
+	cgc cgoContext
+}
+
+// NewReadContext creates a ReadContext, which must be released with Close once done.
+//
+// Available options are:
+//   - ConfigOption
+func NewReadContext(opts ...ReadContextOption) *ReadContext {
+	ro := readContextOpts{}
+	for _, o := range opts {
+		o.setReadContextOpt(&ro)
+	}
+	rc := &ReadContext{cgc: createCGOContext(ro.config, nil)}
+	rc.cgc.reused = true
+	return rc
+}
+
+// Close releases the resources held by the ReadContext. It must not be used afterwards.
+func (rc *ReadContext) Close() {
+	if rc.cgc.cctx == nil {
+		return
+	}
+	rc.cgc.opts.free()
+	C.free(unsafe.Pointer(rc.cgc.cctx))
+	rc.cgc.cctx = nil
+}
+
+// context resets the reused C context, so that it can be used for a new gdal call
+func (rc *ReadContext) context(eh ErrorHandler) (cgoContext, error) {
+	if rc.cgc.cctx == nil {
+		return cgoContext{}, errors.New("ReadContext used after Close")
+	}
+	rc.cgc.cctx.failed = 0
+	rc.cgc.cctx.errMessage = nil
+	rc.cgc.cctx.errCategory = 0
+	rc.cgc.cctx.errCode = 0
+	if eh != nil {
+		rc.cgc.cctx.handlerIdx = C.int(registerErrorHandler(eh))
+	} else {
+		rc.cgc.cctx.handlerIdx = 0
+	}
+	return rc.cgc, nil
+}
+
+func (rc *ReadContext) setBandIOOpt(ro *bandIOOpts) {
+	ro.readContext = rc
+}
+
+func (rc *ReadContext) setDatasetIOOpt(ro *datasetIOOpts) {
+	ro.readContext = rc
+}
+
+// createIOContext returns the context to use for an IO call, i.e. the one from rc if
+// it is set and no specific configuration options were requested
+func createIOContext(rc *ReadContext, configOptions []string, eh ErrorHandler) (cgoContext, error) {
+	if rc != nil && len(configOptions) == 0 {
+		return rc.context(eh)
+	}
+	return createCGOContext(configOptions, eh), nil
+}
@@ -33,6 +33,17 @@ typedef enum {
 } FutureGDALDataType;
 #endif
 
+#ifndef GDAL_OF_MULTIDIM_RASTER
+/* GDAL >= 3.1 */
+#define GDAL_OF_MULTIDIM_RASTER 0x10
+#endif
+
+#if GDAL_VERSION_NUM < GDAL_COMPUTE_VERSION(3, 3, 0)
+typedef enum {
+    /*! RMS: Root Mean Square / Quadratic Mean (GDAL >= 3.3) */ GRIORA_RMS = 14
+} FutureGDALRIOResampleAlg;
+#endif
+
 #ifdef __cplusplus
 extern "C" {
 #endif
@@ -41,10 +52,13 @@ extern "C" {
 		int handlerIdx;
 		int failed;
 		char **configOptions;
+		int errCategory;
+		int errCode;
 	} cctx;
 	void godalSetMetadataItem(cctx *ctx, GDALMajorObjectH mo, char *ckey, char *cval, char *cdom);
 	void godalSetDescription(cctx *ctx, GDALMajorObjectH mo, char *desc);
 	void godalClearMetadata(cctx *ctx, GDALMajorObjectH mo, char *cdom);
+	GDALDriverH godalIdentifyDriver(cctx *ctx, const char *name, unsigned int nIdentifyFlags, const char *const *papszAllowedDrivers, const char *const *papszSiblingFiles);
 	GDALDatasetH godalOpen(cctx *ctx, const char *name, unsigned int nOpenFlags, const char *const *papszAllowedDrivers,
 						   const char *const *papszOpenOptions, const char *const *papszSiblingFiles);
 
@@ -67,52 +81,76 @@ extern "C" {
 	void godalSetDatasetNoDataValue(cctx *ctx, GDALDatasetH bnd, double nd);
 	void godalDeleteRasterNoDataValue(cctx *ctx, GDALRasterBandH bnd);
 	void godalSetRasterScaleOffset(cctx *ctx, GDALRasterBandH bnd, double scale, double offset);
+	void godalSetRasterCategoryNames(cctx *ctx, GDALRasterBandH bnd, char **names);
+	void godalSetRasterUnitType(cctx *ctx, GDALRasterBandH bnd, char *unit);
 	void godalSetDatasetScaleOffset(cctx *ctx, GDALDatasetH bnd, double scale, double offset);
 	void godalSetRasterColorInterpretation(cctx *ctx, GDALRasterBandH bnd, GDALColorInterp ci);
 	GDALRasterBandH godalCreateMaskBand(cctx *ctx, GDALRasterBandH bnd, int flags);
 	GDALRasterBandH godalCreateDatasetMaskBand(cctx *ctx, GDALDatasetH ds, int flags);
+	GDALRasterBandH godalAddBand(cctx *ctx, GDALDatasetH ds, GDALDataType dtype, char **opts);
 	OGRSpatialReferenceH godalCreateUserSpatialRef(cctx *ctx, char *userInput);
 	OGRSpatialReferenceH godalCreateWKTSpatialRef(cctx *ctx, char *wkt);
 	OGRSpatialReferenceH godalCreateProj4SpatialRef(cctx *ctx, char *proj);
+	OGRSpatialReferenceH godalCreateESRISpatialRef(cctx *ctx, char *esriWKT);
 	OGRSpatialReferenceH godalCreateEPSGSpatialRef(cctx *ctx, int epsgCode);
 	void godalValidateSpatialRef(cctx *ctx, OGRSpatialReferenceH sr);
+	OGRSpatialReferenceH *godalFindMatches(cctx *ctx, OGRSpatialReferenceH sr, int *nMatches, int **confidences);
 	char* godalExportToWKT(cctx *ctx, OGRSpatialReferenceH sr);
-	OGRCoordinateTransformationH godalNewCoordinateTransformation(cctx *ctx,  OGRSpatialReferenceH src, OGRSpatialReferenceH dst);
+	char* godalExportToProj4(cctx *ctx, OGRSpatialReferenceH sr);
+	char* godalExportToESRIWKT(cctx *ctx, OGRSpatialReferenceH sr);
+	char* godalExportToPROJJSON(cctx *ctx, OGRSpatialReferenceH sr, char **options);
+	OGRCoordinateTransformationH godalNewCoordinateTransformation(cctx *ctx,  OGRSpatialReferenceH src, OGRSpatialReferenceH dst,
+																  double *areaOfInterest, int ballparkAllowed, int traditionalGISOrder);
+	void *godalCreateGenImgProjTransformer(cctx *ctx, GDALDatasetH src, GDALDatasetH dst, char **options);
+	void godalGenImgProjTransform(cctx *ctx, void *trn, int inverse, int n, double *x, double *y);
+	void godalDatasetPixelGeoTransform(cctx *ctx, GDALDatasetH ds, char **options, int inverse, int n, double *x, double *y);
+	void godalTransformBounds(cctx *ctx, OGRCoordinateTransformationH trn, double *bounds, int densifyPts);
 	void godalDatasetSetSpatialRef(cctx *ctx, GDALDatasetH ds, OGRSpatialReferenceH sr);
 	void godalSetGeoTransform(cctx *ctx, GDALDatasetH ds, double *gt);
 	void godalGetGeoTransform(cctx *ctx, GDALDatasetH ds, double *gt);
 	void godalSetProjection(cctx *ctx, GDALDatasetH ds, char *wkt);
 
 	GDALDatasetH godalTranslate(cctx *ctx, char *dstName, GDALDatasetH ds, char **switches);
+	char *godalMultiDimInfo(cctx *ctx, GDALDatasetH ds, char **switches);
 	GDALDatasetH godalDatasetWarp(cctx *ctx, char *dstName, int nSrcCount, GDALDatasetH *srcDS, char **switches);
 	void godalDatasetWarpInto(cctx *ctx, GDALDatasetH dstDs,  int nSrcCount, GDALDatasetH *srcDS, char **switches);
 	GDALDatasetH godalDatasetVectorTranslate(cctx *ctx, char *dstName, GDALDatasetH ds, char **switches);
 	GDALDatasetH godalRasterize(cctx *ctx, char *dstName, GDALDatasetH dstDS, GDALDatasetH ds, char **switches);
 	void godalRasterizeGeometry(cctx *ctx, GDALDatasetH ds, OGRGeometryH geom, int *bands, int nBands, double *vals, int allTouched);
-	void godalBuildOverviews(cctx *ctx, GDALDatasetH ds, const char *resampling, int nLevels, int *levels, int nBands, int *bands);
+	void godalBuildOverviews(cctx *ctx, GDALDatasetH ds, const char *resampling, const char *maskResampling, int nLevels, int *levels, int nBands, int *bands);
 	void godalClearOverviews(cctx *ctx, GDALDatasetH ds);
 
 	void godalDatasetStructure(GDALDatasetH ds, int *sx, int *sy, int *bsx, int *bsy, double *scale, double *offset, int *bandCount, int *dtype);
 	void godalBandStructure(GDALRasterBandH bnd, int *sx, int *sy, int *bsx, int *bsy, double *scale, double *offset, int *dtype);
 	void godalDatasetRasterIO(cctx *ctx, GDALDatasetH ds, GDALRWFlag rw, int nDSXOff, int nDSYOff, int nDSXSize, int nDSYSize, void *pBuffer,
 		int nBXSize, int nBYSize, GDALDataType eBDataType, int nBandCount, int *panBandCount,
-		int nPixelSpace, int nLineSpace, int nBandSpace, GDALRIOResampleAlg alg);
+		int nPixelSpace, int nLineSpace, int nBandSpace, GDALRIOResampleAlg alg, double *srcWinF);
 	void godalBandRasterIO(cctx *ctx, GDALRasterBandH bnd, GDALRWFlag rw, int nDSXOff, int nDSYOff, int nDSXSize, int nDSYSize, void *pBuffer,
-		int nBXSize, int nBYSize, GDALDataType eBDataType, int nPixelSpace, int nLineSpace, GDALRIOResampleAlg alg);
+		int nBXSize, int nBYSize, GDALDataType eBDataType, int nPixelSpace, int nLineSpace, GDALRIOResampleAlg alg, double *srcWinF);
 	void godalFillRaster(cctx *ctx, GDALRasterBandH bnd, double real, double imag);
 	void godalPolygonize(cctx *ctx, GDALRasterBandH in, GDALRasterBandH mask, OGRLayerH layer, int fieldIndex, char **opts);
 	void godalFillNoData(cctx *ctx, GDALRasterBandH in, GDALRasterBandH mask, int maxDistance, int iterations, char **opts);
+	int godalChecksumImage(cctx *ctx, GDALRasterBandH bnd, int xOff, int yOff, int xSize, int ySize);
+	void godalComputeProximity(cctx *ctx, GDALRasterBandH in, GDALRasterBandH dst, char **opts);
+	void godalContourGenerate(cctx *ctx, GDALRasterBandH in, OGRLayerH layer, char **opts);
 	void godalSieveFilter(cctx *ctx, GDALRasterBandH bnd, GDALRasterBandH mask, GDALRasterBandH dst, int sizeThreshold, int connectedNess);
 
-	void godalLayerGetExtent(cctx *ctx, OGRLayerH layer, OGREnvelope *envelope);
+	void godalLayerGetExtent(cctx *ctx, OGRLayerH layer, OGREnvelope *envelope, int force);
+	void godalLayerGetExtent3D(cctx *ctx, OGRLayerH layer, double *extent, int force);
 	void godalLayerFeatureCount(cctx *ctx, OGRLayerH layer, int *count);
 	void godalLayerSetFeature(cctx *ctx, OGRLayerH layer, OGRFeatureH feat);
 	void godalLayerCreateFeature(cctx *ctx, OGRLayerH layer, OGRFeatureH feat);
+	void godalLayerRename(cctx *ctx, OGRLayerH layer, char *name);
+	void godalLayerCreateFeatureBatch(cctx *ctx, OGRLayerH layer, OGRFeatureH *feats, int count);
 	OGRFeatureH godalLayerNewFeature(cctx *ctx, OGRLayerH layer, OGRGeometryH geom);
 	void godalLayerDeleteFeature(cctx *ctx, OGRLayerH layer, OGRFeatureH feat);
+	void godalLayerAlterFieldDefn(cctx *ctx, OGRLayerH layer, int fieldIndex, OGRFieldDefnH fdefn, int flags);
+	void godalLayerCreateField(cctx *ctx, OGRLayerH layer, OGRFieldDefnH fdefn);
+	void godalLayerDeleteField(cctx *ctx, OGRLayerH layer, int fieldIndex);
 	void godalLayerSetGeometryColumnName(cctx *ctx, OGRLayerH layer, char *name);
 	void godalFeatureSetGeometryColumnName(cctx *ctx, OGRFeatureH feat, char *name);
 	void godalFeatureSetGeometry(cctx *ctx, OGRFeatureH feat, OGRGeometryH geom);
+	void godalFeatureSetGeometryByIndex(cctx *ctx, OGRFeatureH feat, int index, OGRGeometryH geom);
 	void godalFeatureSetFieldInteger(cctx *ctx, OGRFeatureH feat, int fieldIndex, int value);
 	void godalFeatureSetFieldInteger64(cctx *ctx, OGRFeatureH feat, int fieldIndex, long long value);
 	void godalFeatureSetFieldDouble(cctx *ctx, OGRFeatureH feat, int fieldIndex, double value);
@@ -123,7 +161,10 @@ extern "C" {
 	void godalFeatureSetFieldDoubleList(cctx *ctx, OGRFeatureH feat, int fieldIndex, int nbValues, double *values);
 	void godalFeatureSetFieldStringList(cctx *ctx, OGRFeatureH feat, int fieldIndex, char **values);
 	void godalFeatureSetFieldBinary(cctx *ctx, OGRFeatureH feat, int fieldIndex, int nbBytes, void *value);
-	OGRLayerH godalCreateLayer(cctx *ctx, GDALDatasetH ds, char *name, OGRSpatialReferenceH sr, OGRwkbGeometryType gtype);
+	void godalFeatureSetFieldNull(cctx *ctx, OGRFeatureH feat, int fieldIndex);
+	void godalFeatureUnsetField(cctx *ctx, OGRFeatureH feat, int fieldIndex);
+	void godalFeatureSetFrom(cctx *ctx, OGRFeatureH feat, OGRFeatureH other, int forgiving);
+	OGRLayerH godalCreateLayer(cctx *ctx, GDALDatasetH ds, char *name, OGRSpatialReferenceH sr, OGRwkbGeometryType gtype, char **options);
 	OGRLayerH godalCopyLayer(cctx *ctx, GDALDatasetH ds, OGRLayerH layer, char *name);
 	OGRLayerH godalDatasetExecuteSQL(cctx *ctx, GDALDatasetH ds, char *sql, OGRGeometryH filter, char *dialect);
 	void godalReleaseResultSet(cctx *ctx, GDALDatasetH ds, OGRLayerH rs);
@@ -137,32 +178,63 @@ extern "C" {
 	void godalSetColorTable(cctx *ctx, GDALRasterBandH bnd, GDALPaletteInterp interp, int nEntries, short *entries);
 	void godalRasterHistogram(cctx *ctx, GDALRasterBandH bnd, double *min, double *max, int *buckets,
 						   unsigned long long **values, int bIncludeOutOfRange, int bApproxOK);
+	void godalComputeRasterMinMax(cctx *ctx, GDALRasterBandH bnd, int bApproxOK, double *minmax);
+	int godalGetDefaultHistogram(cctx *ctx, GDALRasterBandH bnd, double *min, double *max, int *buckets,
+								 unsigned long long **values);
+	void godalSetDefaultHistogram(cctx *ctx, GDALRasterBandH bnd, double min, double max, int buckets,
+								  unsigned long long *values);
 
-	VSILFILE *godalVSIOpen(cctx *ctx, const char *name);
+	VSILFILE *godalVSIOpen(cctx *ctx, const char *name, const char *mode);
 	void godalVSIUnlink(cctx *ctx, const char *name);
+	void godalVSIMemCreate(cctx *ctx, const char *name, const void *data, size_t len);
+	void *godalVSIMemSteal(cctx *ctx, const char *name, size_t *len);
+	void godalVSISetPathSpecificOption(cctx *ctx, const char *prefix, const char *key, const char *value, int credential);
+	void godalVSIClearPathSpecificOptions(cctx *ctx, const char *prefix);
+	void godalCreateSOZip(cctx *ctx, const char *zipName, char **archiveNames, char **inputNames, char **options);
 	char* godalVSIClose(VSILFILE *f);
+	char* godalVSIFlush(VSILFILE *f);
 	size_t godalVSIRead(VSILFILE *f, void *buf, int len, char **errmsg);
+	size_t godalVSIWrite(VSILFILE *f, const void *buf, int len, char **errmsg);
 	void godal_OGR_G_AddGeometry(cctx *ctx, OGRGeometryH geom, OGRGeometryH subGeom);
 	OGRGeometryH godal_OGR_G_Simplify(cctx *ctx, OGRGeometryH in, double tolerance);
 	OGRGeometryH godal_OGR_G_Buffer(cctx *ctx, OGRGeometryH in, double tolerance, int segments);
 	OGRGeometryH godal_OGR_G_Difference(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2);
 	OGRGeometryH godal_OGR_G_GetGeometryRef(cctx *ctx, OGRGeometryH in, int subGeomIndex);
 	int godal_OGR_G_Intersects(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2);
+	int godal_OGR_G_Touches(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2);
+	int godal_OGR_G_Crosses(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2);
+	int godal_OGR_G_Within(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2);
+	int godal_OGR_G_Overlaps(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2);
+	int godal_OGR_G_Disjoint(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2);
+	int godal_OGR_G_Equals(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2);
 	OGRGeometryH godal_OGR_G_Intersection(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2);
 	OGRGeometryH godal_OGR_G_Union(cctx *ctx, OGRGeometryH geom1, OGRGeometryH geom2);
+	OGRGeometryH godal_OGR_G_UnaryUnion(cctx *ctx, int nGeoms, OGRGeometryH *geoms);
+	OGRGeometryH godal_OGR_G_Polygonize(cctx *ctx, OGRGeometryH in);
+	OGRGeometryH godal_OGR_G_ConvexHull(cctx *ctx, OGRGeometryH in);
+	OGRGeometryH godal_OGR_G_Boundary(cctx *ctx, OGRGeometryH in);
+	OGRGeometryH godal_OGR_G_PointOnSurface(cctx *ctx, OGRGeometryH in);
+	OGRGeometryH godal_OGR_G_MakeValid(cctx *ctx, OGRGeometryH in, char **options);
+	void godal_OGR_G_Segmentize(cctx *ctx, OGRGeometryH in, double maxLength);
+	OGRGeometryH godal_OGR_G_SetPrecision(cctx *ctx, OGRGeometryH in, double gridSize, int flags);
 	OGRGeometryH godalNewGeometryFromGeoJSON(cctx *ctx, char *geoJSON);
 	OGRGeometryH godalNewGeometryFromWKT(cctx *ctx, char *wkt, OGRSpatialReferenceH sr);
 	OGRGeometryH godalNewGeometryFromWKB(cctx *ctx, void *wkb, int wkbLen,OGRSpatialReferenceH sr);
 	char* godalExportGeometryWKT(cctx *ctx, OGRGeometryH in);
 	char* godalExportGeometryGeoJSON(cctx *ctx, OGRGeometryH in, int precision);
 	char* godalExportGeometryGML(cctx *ctx, OGRGeometryH in, char **switches);
-	void godalExportGeometryWKB(cctx *ctx, void **wkb, int *wkbLen, OGRGeometryH in);
+	void godalExportGeometryWKB(cctx *ctx, void **wkb, int *wkbLen, OGRGeometryH in, int xdr, int extended);
 	void godalGeometryTransformTo(cctx *ctx, OGRGeometryH geom, OGRSpatialReferenceH sr);
 	void godalGeometryTransform(cctx *ctx, OGRGeometryH geom, OGRCoordinateTransformationH trn, OGRSpatialReferenceH dst);
+	int godalGeometryTransformPartial(cctx *ctx, OGRGeometryH geom, OGRCoordinateTransformationH trn, OGRSpatialReferenceH dst);
 
 	GDALDatasetH godalBuildVRT(cctx *ctx, char *dstname, char **sources, char **switches);
 
 	void test_godal_error_handling(cctx *ctx);
+	void test_godal_global_error_handling();
+	void godalSetGlobalErrorHandler(int enable);
+	int godalPushCaptureHandler(int handlerIdx);
+	void godalPopCaptureHandler(int previousIdx);
 	void godalClearRasterStatistics(cctx *ctx, GDALDatasetH ds);
 	void godalComputeRasterStatistics(cctx *ctx, GDALRasterBandH bnd, int bApproxOK, double *pdfMin, double *pdfMax, double *pdfMean, double *pdfStdDev);
 	int godalGetRasterStatistics(cctx *ctx, GDALRasterBandH bnd, int bApproxOK, double *pdfMin, double *pdfMax, double *pdfMean, double *pdfStdDev);
@@ -190,8 +262,12 @@ extern "C" {
 	const char *godalGetGCPProjection(GDALDatasetH hSrcDS);
 	void godalSetGCPs(cctx *ctx, GDALDatasetH hSrcDS, int numGCPs, goGCPList GCPList, const char *pszGCPProjection);
 	void godalSetGCPs2(cctx *ctx, GDALDatasetH hSrcDS, int numGCPs, goGCPList GCPList, OGRSpatialReferenceH hSRS);
+	void godalDeleteDataset(cctx *ctx, GDALDriverH drv, const char *name);
+	void godalRenameDataset(cctx *ctx, GDALDriverH drv, const char *newName, const char *oldName);
+	void godalClearGCPs(cctx *ctx, GDALDatasetH hSrcDS);
 	GDAL_GCP *goGCPListToGDALGCP(goGCPList GCPList, int numGCPs);
 	void godalGCPListToGeoTransform(cctx *ctx, goGCPList GCPList, int numGCPs, double *gt);
+	int godalGetRPCInfo(GDALDatasetH hDS, GDALRPCInfoV2 *rpc);
 #ifdef __cplusplus
 }
 #endif
@@ -17,15 +17,21 @@ package godal
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"image"
+	"image/color"
 	"io"
 	"io/ioutil"
 	"math"
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -167,6 +173,28 @@ func TestColorTable(t *testing.T) {
 	assert.Len(t, ct3.Entries, 0)
 }
 
+func TestAddBand(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer ds.Close()
+	ehc := eh()
+	bnd, err := ds.AddBand(Float32, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	assert.Len(t, ds.Bands(), 2)
+	assert.Equal(t, Float32, bnd.Structure().DataType)
+	_ = bnd.Fill(3.5, 0)
+	buf := make([]float32, 1)
+	_ = ds.Bands()[1].Read(0, 0, buf, 1, 1)
+	assert.Equal(t, float32(3.5), buf[0])
+
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
+	tds, _ := Create(GTiff, tmpname, 1, Byte, 10, 10)
+	defer tds.Close()
+	_, err = tds.AddBand(Byte)
+	assert.Error(t, err)
+	assert.Len(t, tds.Bands(), 1)
+}
+
 func TestCreate(t *testing.T) {
 	tmpname := tempfile()
 	defer os.Remove(tmpname)
@@ -244,6 +272,128 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestDriverDeleteRename(t *testing.T) {
+	tmpname := tempfile() + ".tif"
+	ds, err := Create(GTiff, tmpname, 1, Byte, 64, 64)
+	require.NoError(t, err)
+	ds.Close()
+	//building overviews on a read-only dataset creates a sidecar .ovr file
+	ds, _ = Open(tmpname)
+	require.NoError(t, ds.BuildOverviews(Levels(2)))
+	ds.Close()
+	_, err = os.Stat(tmpname + ".ovr")
+	require.NoError(t, err)
+
+	drv, _ := RasterDriver(GTiff)
+	newname := tempfile() + ".tif"
+	ehc := eh()
+	err = drv.Rename(newname, tmpname, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	_, err = os.Stat(tmpname)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(tmpname + ".ovr")
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(newname + ".ovr")
+	assert.NoError(t, err)
+
+	err = drv.Delete(newname, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	_, err = os.Stat(newname)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(newname + ".ovr")
+	assert.True(t, os.IsNotExist(err))
+
+	assert.Error(t, drv.Delete(newname))
+	assert.Error(t, drv.Rename(tmpname, newname))
+}
+
+func TestDriverHasCapability(t *testing.T) {
+	gtiff, _ := RasterDriver(GTiff)
+	assert.True(t, gtiff.HasCapability(DCapRaster))
+	assert.True(t, gtiff.HasCapability(DCapCreate))
+	assert.True(t, gtiff.HasCapability(DCapVirtualIO))
+	assert.False(t, gtiff.HasCapability(DCapVector))
+
+	geojson, _ := VectorDriver(GeoJSON)
+	assert.True(t, geojson.HasCapability(DCapVector))
+	assert.False(t, geojson.HasCapability(DCapRaster))
+	assert.False(t, geojson.HasCapability("DCAP_FOOBAR"))
+}
+
+func TestRegisteredDrivers(t *testing.T) {
+	drivers := RegisteredDrivers()
+	names := map[string]Driver{}
+	for _, drv := range drivers {
+		names[drv.ShortName()] = drv
+	}
+	require.Contains(t, names, "GTiff")
+	require.Contains(t, names, "GeoJSON")
+	assert.Contains(t, names, "MEM")
+
+	assert.Contains(t, names["GTiff"].Extensions(), "tif")
+	assert.Contains(t, names["GTiff"].Extensions(), "tiff")
+	assert.Equal(t, "image/tiff", names["GTiff"].MimeType())
+	assert.Equal(t, []string{"json", "geojson"}, names["GeoJSON"].Extensions())
+	assert.Empty(t, names["MEM"].Extensions())
+}
+
+func TestIdentifyDriver(t *testing.T) {
+	drv, ok := IdentifyDriver("testdata/test.tif")
+	require.True(t, ok)
+	assert.Equal(t, "GTiff", drv.ShortName())
+	ehc := eh()
+	_, ok = IdentifyDriver("testdata/test.tif", VectorOnly(), ErrLogger(ehc.ErrorHandler))
+	assert.False(t, ok)
+	_, ok = IdentifyDriver("testdata/test.tif", Drivers("GeoJSON"))
+	assert.False(t, ok)
+
+	drv, ok = IdentifyDriver("testdata/test.geojson", VectorOnly(), SiblingFiles())
+	require.True(t, ok)
+	assert.Equal(t, "GeoJSON", drv.ShortName())
+
+	_, ok = IdentifyDriver("testdata/not_existing.tif")
+	assert.False(t, ok)
+}
+
+func TestOpenedBy(t *testing.T) {
+	ds, err := Open("testdata/test.tif", OpenedBy(GeoJSON, GTiff))
+	require.NoError(t, err)
+	ds.Close()
+
+	_, err = Open("testdata/test.tif", OpenedBy(GeoJSON, "PNG"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GTiff")
+
+	ds, err = Open("testdata/test.geojson", VectorOnly(), OpenedBy(GeoJSON))
+	require.NoError(t, err)
+	ds.Close()
+
+	mem, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer mem.Close()
+	assert.True(t, Memory.matches(mem.Driver().ShortName()))
+}
+
+func TestSubdatasets(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer ds.Close()
+	assert.Nil(t, ds.Subdatasets())
+
+	_ = ds.SetMetadata("SUBDATASET_1_NAME", "testdata/test.tif", Domain("SUBDATASETS"))
+	_ = ds.SetMetadata("SUBDATASET_1_DESC", "[10x10] test (8-bit unsigned integer)", Domain("SUBDATASETS"))
+	_ = ds.SetMetadata("SUBDATASET_2_NAME", "testdata/not_existing.tif", Domain("SUBDATASETS"))
+	sds := ds.Subdatasets()
+	require.Len(t, sds, 2)
+	assert.Equal(t, Subdataset{Name: "testdata/test.tif", Desc: "[10x10] test (8-bit unsigned integer)"}, sds[0])
+	assert.Equal(t, "", sds[1].Desc)
+
+	sub, err := sds[0].Open(OpenedBy(GTiff))
+	require.NoError(t, err)
+	sub.Close()
+	ehc := eh()
+	_, err = sds[1].Open(ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+}
+
 func TestRegisterDrivers(t *testing.T) {
 	_, ok := RasterDriver(HFA)
 	assert.False(t, ok)
@@ -417,9 +567,9 @@ func TestConfigOptions(t *testing.T) {
 	_, err = ds.GeoTransform()
 	assert.Error(t, err)
 	if CheckMinVersion(3, 9, 0) {
-		assert.Equal(t, 0x02, ds.Bands()[0].MaskFlags()) //gdal 3.9+ will not create a separate mask file by default
+		assert.Equal(t, GMF_PER_DATASET, ds.Bands()[0].MaskFlags()) //gdal 3.9+ will not create a separate mask file by default
 	} else {
-		assert.NotEqual(t, 0x02, ds.Bands()[0].MaskFlags())
+		assert.NotEqual(t, GMF_PER_DATASET, ds.Bands()[0].MaskFlags())
 	}
 }
 
@@ -476,6 +626,191 @@ func TestHistogram(t *testing.T) {
 
 }
 
+func TestDefaultHistogram(t *testing.T) {
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
+	defer os.Remove(tmpname + ".aux.xml")
+	ds, err := Create(GTiff, tmpname, 1, Byte, 16, 16)
+	require.NoError(t, err)
+	bnd := ds.Bands()[0]
+
+	_, ok, err := bnd.GetDefaultHistogram()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	err = bnd.SetDefaultHistogram(-0.5, 3.5, []uint64{1, 2, 3, 4})
+	assert.NoError(t, err)
+	err = bnd.SetDefaultHistogram(0, 1, nil)
+	assert.Error(t, err)
+	_ = ds.Close()
+
+	ds, _ = Open(tmpname)
+	defer ds.Close()
+	ehc := eh()
+	hist, ok, err := ds.Bands()[0].GetDefaultHistogram(ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 4, hist.Len())
+	for i := 0; i < hist.Len(); i++ {
+		b := hist.Bucket(i)
+		assert.Equal(t, float64(i)-0.5, b.Min)
+		assert.Equal(t, uint64(i+1), b.Count)
+	}
+
+	ebnd := Band{}
+	_, _, err = ebnd.GetDefaultHistogram()
+	assert.Error(t, err)
+}
+
+func TestAutoStretch(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, UInt16, 100, 100)
+	defer ds.Close()
+	buf := make([]uint16, 100*100)
+	for i := range buf {
+		buf[i] = uint16(i)
+	}
+	band := ds.Bands()[0]
+	require.NoError(t, band.Write(0, 0, buf, 100, 100))
+
+	min, max, err := band.AutoStretch(2, 98)
+	assert.NoError(t, err)
+	assert.Equal(t, 200.0, min)
+	assert.Equal(t, 9799.0, max)
+	ehc := eh()
+	_, _, err = band.AutoStretch(2, 98, Approximate(), ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	_, _, err = band.AutoStretch(98, 2)
+	assert.Error(t, err)
+
+	bds, err := ds.StretchToByte(2, 98)
+	require.NoError(t, err)
+	defer bds.Close()
+	st := bds.Structure()
+	assert.Equal(t, Byte, st.DataType)
+	assert.Equal(t, 100, st.SizeX)
+	bbuf := make([]byte, 100*100)
+	require.NoError(t, bds.Read(0, 0, bbuf, 100, 100))
+	assert.Equal(t, byte(0), bbuf[0])
+	assert.Equal(t, byte(0), bbuf[200])
+	assert.Equal(t, byte(255), bbuf[9799])
+	assert.Equal(t, byte(255), bbuf[9999])
+	assert.InDelta(t, 127, int(bbuf[5000]), 1)
+
+	ads, err := ds.AutoStretch()
+	require.NoError(t, err)
+	abuf := make([]byte, 100*100)
+	require.NoError(t, ads.Read(0, 0, abuf, 100, 100))
+	assert.Equal(t, bbuf, abuf)
+	ads.Close()
+	ads, err = ds.AutoStretch(Percentiles(10, 90), ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	require.NoError(t, ads.Read(0, 0, abuf, 100, 100))
+	assert.Equal(t, byte(0), abuf[999])
+	assert.Equal(t, byte(255), abuf[8999])
+	ads.Close()
+	_, err = ds.AutoStretch(Percentiles(90, 10))
+	assert.Error(t, err)
+
+	empty, _ := Create(Memory, "", 1, Float32, 10, 10)
+	defer empty.Close()
+	_ = empty.Bands()[0].SetNoData(0)
+	ehc = eh()
+	_, err = empty.StretchToByte(2, 98, ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+}
+
+func TestReadAsImage(t *testing.T) {
+	gray, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer gray.Close()
+	_ = gray.Bands()[0].Fill(100, 0)
+	img, err := gray.ReadAsImage(0, 0, 10, 10)
+	require.NoError(t, err)
+	require.IsType(t, &image.Gray{}, img)
+	assert.Equal(t, color.Gray{Y: 100}, img.At(3, 3))
+	_, err = gray.ReadAsImage(0, 0, 0, 10)
+	assert.Error(t, err)
+
+	// thumbnail of a band with nodata
+	_ = gray.Bands()[0].SetNoData(0)
+	_ = gray.Bands()[0].Write(0, 0, make([]byte, 20), 10, 2)
+	ehc := eh()
+	img, err = gray.ReadAsImage(0, 0, 5, 5, Window(10, 10), ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	require.IsType(t, &image.NRGBA{}, img)
+	assert.Equal(t, image.Rect(0, 0, 5, 5), img.Bounds())
+	assert.Equal(t, color.NRGBA{0, 0, 0, 0}, img.At(0, 0))
+	assert.Equal(t, color.NRGBA{100, 100, 100, 255}, img.At(2, 2))
+
+	// bands are stored as BGR, relying on color interpretation
+	rgb, _ := Create(Memory, "", 3, Byte, 4, 4)
+	defer rgb.Close()
+	for i, ci := range []ColorInterp{CIBlue, CIGreen, CIRed} {
+		_ = rgb.Bands()[i].SetColorInterp(ci)
+		_ = rgb.Bands()[i].Fill(float64(10*(i+1)), 0)
+	}
+	img, err = rgb.ReadAsImage(0, 0, 4, 4)
+	require.NoError(t, err)
+	require.IsType(t, &image.RGBA{}, img)
+	assert.Equal(t, color.RGBA{30, 20, 10, 255}, img.At(1, 1))
+
+	rgba, _ := Create(Memory, "", 4, Byte, 4, 4)
+	defer rgba.Close()
+	for i := 0; i < 4; i++ {
+		_ = rgba.Bands()[i].Fill(float64(50*(i+1)), 0)
+	}
+	img, err = rgba.ReadAsImage(0, 0, 4, 4)
+	require.NoError(t, err)
+	require.IsType(t, &image.NRGBA{}, img)
+	assert.Equal(t, color.NRGBA{50, 100, 150, 200}, img.At(3, 3))
+}
+
+func TestCreateFromImage(t *testing.T) {
+	nrgba := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			nrgba.SetNRGBA(x, y, color.NRGBA{uint8(x), uint8(y), 100, 200})
+		}
+	}
+	// sub images must be handled
+	sub := nrgba.SubImage(image.Rect(2, 2, 6, 6))
+	ehc := eh()
+	ds, err := CreateFromImage(Memory, "", sub, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	defer ds.Close()
+	st := ds.Structure()
+	assert.Equal(t, 4, st.NBands)
+	assert.Equal(t, 4, st.SizeX)
+	assert.Equal(t, CIAlpha, ds.Bands()[3].ColorInterp())
+	img, err := ds.ReadAsImage(0, 0, 4, 4)
+	require.NoError(t, err)
+	assert.Equal(t, color.NRGBA{3, 4, 100, 200}, img.At(1, 2))
+
+	g16 := image.NewGray16(image.Rect(0, 0, 2, 2))
+	g16.SetGray16(1, 0, color.Gray16{Y: 1000})
+	ds16, err := CreateFromImage(Memory, "", g16)
+	require.NoError(t, err)
+	defer ds16.Close()
+	assert.Equal(t, UInt16, ds16.Structure().DataType)
+	buf := make([]uint16, 4)
+	_ = ds16.Read(0, 0, buf, 2, 2)
+	assert.Equal(t, []uint16{0, 1000, 0, 0}, buf)
+
+	pal := image.NewPaletted(image.Rect(0, 0, 2, 2), color.Palette{color.Black, color.NRGBA{255, 0, 0, 255}})
+	pal.SetColorIndex(0, 1, 1)
+	pds, err := CreateFromImage(Memory, "", pal)
+	require.NoError(t, err)
+	defer pds.Close()
+	assert.Equal(t, CIPalette, pds.Bands()[0].ColorInterp())
+	ct := pds.Bands()[0].ColorTable()
+	assert.Equal(t, [][4]int16{{0, 0, 0, 255}, {255, 0, 0, 255}}, ct.Entries)
+	bbuf := make([]byte, 4)
+	_ = pds.Read(0, 0, bbuf, 2, 2)
+	assert.Equal(t, []byte{0, 0, 1, 0}, bbuf)
+
+	_, err = CreateFromImage(Memory, "", image.NewGray(image.Rect(0, 0, 0, 0)))
+	assert.Error(t, err)
+}
+
 func TestSize(t *testing.T) {
 	ds, _ := Open("testdata/test.tif")
 	srm, err := NewSpatialRefFromEPSG(3857)
@@ -537,6 +872,42 @@ func TestBands(t *testing.T) {
 	assert.Equal(t, 99.0, nd)
 }
 
+func TestSetColorInterps(t *testing.T) {
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
+	ds, err := Create(GTiff, tmpname, 3, Byte, 10, 10)
+	require.NoError(t, err)
+	defer ds.Close()
+
+	err = ds.SetColorInterps([]ColorInterp{CIRed, CIGreen, CIBlue})
+	assert.NoError(t, err)
+	bnds := ds.Bands()
+	assert.Equal(t, CIRed, bnds[0].ColorInterp())
+	assert.Equal(t, CIGreen, bnds[1].ColorInterp())
+	assert.Equal(t, CIBlue, bnds[2].ColorInterp())
+
+	err = ds.SetColorInterps([]ColorInterp{CIRed, CIGreen})
+	assert.Error(t, err)
+
+	//Setting a second alpha band on a gtiff raises a warning
+	ehc := eh()
+	err = ds.SetColorInterps([]ColorInterp{CIAlpha, CIAlpha, CIBlue}, ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+	assert.Equal(t, 1, ehc.errs)
+
+	//which can be ignored with an ErrLogger
+	var warnings []string
+	err = ds.SetColorInterps([]ColorInterp{CIAlpha, CIAlpha, CIBlue}, ErrLogger(func(ec ErrorCategory, code int, msg string) error {
+		if ec > CE_Warning {
+			return errors.New(msg)
+		}
+		warnings = append(warnings, msg)
+		return nil
+	}))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, warnings)
+}
+
 func TestNoData(t *testing.T) {
 	ds, err := Create(Memory, "ffff", 2, Byte, 20, 20)
 	require.NoError(t, err)
@@ -592,111 +963,484 @@ func TestSetScale(t *testing.T) {
 	assert.Equal(t, 101.0, st.Offset)
 }
 
-func TestStructure(t *testing.T) {
+func TestScaleOffsetSet(t *testing.T) {
 	tmpname := tempfile()
 	defer os.Remove(tmpname)
-	ds, err := Create(GTiff, tmpname, 3, Byte, 64, 64, CreationOption("TILED=YES", "BLOCKXSIZE=32", "BLOCKYSIZE=32"))
+	ds, err := Create(GTiff, tmpname, 2, Byte, 4, 4)
 	require.NoError(t, err)
-	st := ds.Structure()
-	assert.Equal(t, 64, st.SizeX)
-	assert.Equal(t, 64, st.SizeY)
-	assert.Equal(t, 32, st.BlockSizeX)
-	assert.Equal(t, 32, st.BlockSizeY)
-	if x, y := st.BlockCount(); x != 2 || y != 2 {
-		t.Errorf("cx,cy: %d,%d", x, y)
-	}
-	if x, y := st.ActualBlockSize(0, 0); x != 32 || y != 32 {
-		t.Errorf("abx,abyy: %d,%d", x, y)
-	}
-	if x, y := st.ActualBlockSize(1, 1); x != 32 || y != 32 {
-		t.Errorf("abx,abyy: %d,%d", x, y)
-	}
-	if x, y := st.ActualBlockSize(2, 2); x != 0 || y != 0 {
-		t.Errorf("abx,abyy: %d,%d", x, y)
-	}
-	ds.Close()
+	defer ds.Close()
+	bands := ds.Bands()
 
-	ds, err = Create(GTiff, tmpname, 3, Byte, 65, 65, CreationOption("TILED=YES", "BLOCKXSIZE=32", "BLOCKYSIZE=32"))
-	require.NoError(t, err)
-	st = ds.Structure()
-	if x, y := st.BlockCount(); x != 3 || y != 3 {
-		t.Errorf("cx,cy: %d,%d", x, y)
-	}
-	if x, y := st.ActualBlockSize(2, 2); x != 1 || y != 1 {
-		t.Errorf("abx,abyy: %d,%d", x, y)
-	}
-	if x, y := st.ActualBlockSize(1, 1); x != 32 || y != 32 {
-		t.Errorf("abx,abyy: %d,%d", x, y)
-	}
-	if x, y := st.ActualBlockSize(3, 3); x != 0 || y != 0 {
-		t.Errorf("abx,abyy: %d,%d", x, y)
-	}
-	ds.Close()
+	scale, offset, scaleSet, offsetSet := bands[0].ScaleOffset()
+	assert.Equal(t, 1.0, scale)
+	assert.Equal(t, 0.0, offset)
+	assert.False(t, scaleSet)
+	assert.False(t, offsetSet)
 
-	ds, err = Create(GTiff, tmpname, 3, Byte, 63, 63, CreationOption("TILED=YES", "BLOCKXSIZE=32", "BLOCKYSIZE=32"))
-	require.NoError(t, err)
-	st = ds.Structure()
-	if x, y := st.BlockCount(); x != 2 || y != 2 {
-		t.Errorf("cx,cy: %d,%d", x, y)
-	}
-	if x, y := st.ActualBlockSize(2, 2); x != 0 || y != 0 {
-		t.Errorf("abx,abyy: %d,%d", x, y)
-	}
-	if x, y := st.ActualBlockSize(1, 1); x != 31 || y != 31 {
-		t.Errorf("abx,abyy: %d,%d", x, y)
-	}
-	if x, y := st.ActualBlockSize(0, 0); x != 32 || y != 32 {
-		t.Errorf("abx,abyy: %d,%d", x, y)
-	}
-	ds.Close()
+	_ = bands[1].SetScaleOffset(1, 0)
+	scale, offset, scaleSet, offsetSet = bands[1].ScaleOffset()
+	assert.Equal(t, 1.0, scale)
+	assert.Equal(t, 0.0, offset)
+	assert.True(t, scaleSet)
+	assert.True(t, offsetSet)
 }
 
-func TestVersion(t *testing.T) {
-	AssertMinVersion(3, 0, 0)
-	assert.False(t, CheckMinVersion(99, 99, 99))
-	assert.True(t, CheckMinVersion(3, 0, 0))
-	assert.Panics(t, func() { AssertMinVersion(99, 99, 99) })
+func TestUnitTypeScaledRead(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, UInt16, 4, 1)
+	defer ds.Close()
+	bnd := ds.Bands()[0]
+	assert.Equal(t, "", bnd.UnitType())
+	ehc := eh()
+	assert.NoError(t, bnd.SetUnitType("K", ErrLogger(ehc.ErrorHandler)))
+	assert.Equal(t, "K", bnd.UnitType())
+
+	_ = bnd.Write(0, 0, []uint16{0, 100, 200, 300}, 4, 1)
+	_ = bnd.SetNoData(0)
+	_ = bnd.SetScaleOffset(0.5, 200)
+
+	f64 := make([]float64, 4)
+	assert.NoError(t, bnd.Read(0, 0, f64, 4, 1, Scaled()))
+	assert.Equal(t, []float64{0, 250, 300, 350}, f64)
+
+	f32 := make([]float32, 4)
+	assert.NoError(t, bnd.Read(0, 0, f32, 2, 1, Window(2, 1), PixelStride(2), Scaled()))
+	assert.Equal(t, []float32{0, 0, 250, 0}, f32)
+
+	raw := make([]uint16, 4)
+	assert.Error(t, bnd.Read(0, 0, raw, 4, 1, Scaled()))
+	assert.Error(t, bnd.Write(0, 0, f64, 4, 1, Scaled()))
 }
 
-func TestReadOnlyDataset(t *testing.T) {
-	//These tests are essentially here to cover error cases
-	tmpdir, _ := ioutil.TempDir("", "")
-	fname := filepath.Join(tmpdir, "ff.tif")
-	defer func() {
-		_ = os.Chmod(tmpdir, 0777)
-		_ = os.Chmod(fname, 0777)
-		_ = os.RemoveAll(tmpdir)
-	}()
-	ds, _ := Open("testdata/test.tif")
-	rds, _ := ds.Translate(fname, []string{"-b", "1", "-b", "1", "-of", "GTiff"})
-	err := rds.Bands()[0].SetColorInterp(CIAlpha)
+func TestCategoryNames(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Byte, 4, 4)
+	defer ds.Close()
+	bnd := ds.Bands()[0]
+	assert.Len(t, bnd.CategoryNames(), 0)
+	ehc := eh()
+	err := bnd.SetCategoryNames([]string{"nodata", "water", "forest"}, ErrLogger(ehc.ErrorHandler))
 	assert.NoError(t, err)
-	//Setting a second alpha band on a gtiff raises a warning
-	err = rds.Bands()[1].SetColorInterp(CIAlpha)
-	assert.Error(t, err)
-	_ = rds.Close()
-	_ = ds.Close()
-	_ = os.Chmod(fname, 0400)
-	ds, err = Open(fname)
-	require.NoError(t, err)
-	_ = os.Chmod(tmpdir, 0400)
-	_, err = ds.CreateMaskBand(0x02, ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"))
-	assert.Error(t, err)
+	assert.Equal(t, []string{"nodata", "water", "forest"}, bnd.CategoryNames())
+	assert.NoError(t, bnd.SetCategoryNames(nil))
+	assert.Len(t, bnd.CategoryNames(), 0)
+}
+
+func TestBandReadWithMask(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Byte, 4, 2)
+	defer ds.Close()
+	bnd := ds.Bands()[0]
+	_ = bnd.Write(0, 0, []byte{0, 1, 2, 0, 3, 0, 4, 5}, 4, 2)
+	_ = bnd.SetNoData(0)
+
+	data := make([]byte, 8)
+	mask := make([]byte, 8)
 	ehc := eh()
-	_, err = ds.CreateMaskBand(0x02, ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"), ErrLogger(ehc.ErrorHandler))
-	assert.Error(t, err)
+	err := bnd.Read(0, 0, data, 4, 2, WithMask(mask), ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 1, 2, 0, 3, 0, 4, 5}, data)
+	assert.Equal(t, []byte{0, 255, 255, 0, 255, 0, 255, 255}, mask)
 
-	_, err = ds.Bands()[0].CreateMask(0x02, ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"))
-	assert.Error(t, err)
-	ehc = eh()
-	_, err = ds.Bands()[0].CreateMask(0x02, ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"), ErrLogger(ehc.ErrorHandler))
-	assert.Error(t, err)
+	// window and stride only apply to the data buffer
+	data = make([]byte, 4)
+	mask = make([]byte, 2)
+	err = bnd.Read(1, 1, data, 2, 1, Window(2, 1), PixelStride(2), WithMask(mask))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 4, 0}, data)
+	assert.Equal(t, []byte{0, 255}, mask)
 
-	err = ds.Bands()[0].Fill(5, 5)
-	assert.Error(t, err)
-	ehc = eh()
-	err = ds.Bands()[0].Fill(5, 5, ErrLogger(ehc.ErrorHandler))
-	assert.Error(t, err)
+	// fractional windows apply to both the data and the mask
+	data = make([]byte, 6)
+	mask = make([]byte, 6)
+	err = bnd.Read(0, 0, data, 3, 2, SourceWindowF(0.5, 0.25, 3, 1.5), WithMask(mask))
+	assert.NoError(t, err)
+	for i := range data {
+		assert.Equal(t, data[i] != 0, mask[i] == 255, "pixel %d: data=%d mask=%d", i, data[i], mask[i])
+	}
+
+	data = make([]byte, 4)
+	mask = make([]byte, 2)
+	assert.Error(t, bnd.Read(0, 0, data, 2, 2, WithMask(mask)))
+	assert.Error(t, bnd.Write(0, 0, data, 2, 1, WithMask(mask)))
+}
+
+func TestBandReadNative(t *testing.T) {
+	ds, _ := Create(Memory, "", 2, Float64, 4, 4)
+	defer ds.Close()
+	_ = ds.Bands()[0].Fill(1.5, 0)
+
+	ehc := eh()
+	buf, dtype, err := ds.Bands()[0].ReadNative(1, 1, 2, 2, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	assert.Equal(t, Float64, dtype)
+	assert.Equal(t, []float64{1.5, 1.5, 1.5, 1.5}, buf)
+
+	buf, _, err = ds.Bands()[0].ReadNative(0, 0, 1, 1, Window(4, 4), Resampling(Average))
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1.5}, buf)
+
+	_, _, err = ds.Bands()[0].ReadNative(0, 0, 2, 2, PixelStride(2))
+	assert.Error(t, err)
+	_, _, err = ds.Bands()[0].ReadNative(3, 3, 2, 2)
+	assert.Error(t, err)
+
+	bds, _ := Create(Memory, "", 1, UInt16, 4, 4)
+	defer bds.Close()
+	buf, dtype, _ = bds.Bands()[0].ReadNative(0, 0, 4, 4)
+	assert.Equal(t, UInt16, dtype)
+	assert.IsType(t, []uint16{}, buf)
+	assert.Len(t, buf, 16)
+}
+
+func TestEachScanline(t *testing.T) {
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
+	ds, err := Create(GTiff, tmpname, 1, UInt16, 10, 7, CreationOption("BLOCKYSIZE=3"))
+	require.NoError(t, err)
+	defer ds.Close()
+	bnd := ds.Bands()[0]
+	data := make([]uint16, 70)
+	for i := range data {
+		data[i] = uint16(i)
+	}
+	_ = bnd.Write(0, 0, data, 10, 7)
+
+	full := make([]uint16, 70)
+	_ = bnd.Read(0, 0, full, 10, 7)
+	expected := 0
+	for _, v := range full {
+		expected += int(v)
+	}
+
+	sum := 0
+	rows := []int{}
+	ehc := eh()
+	err = bnd.EachScanline(func(y int, row interface{}) error {
+		rows = append(rows, y)
+		r := row.([]uint16)
+		assert.Len(t, r, 10)
+		assert.Equal(t, uint16(y*10), r[0])
+		for _, v := range r {
+			sum += int(v)
+		}
+		return nil
+	}, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sum)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, rows)
+
+	stop := fmt.Errorf("stop")
+	err = bnd.EachScanline(func(y int, row interface{}) error {
+		if y == 4 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+}
+
+func TestProcessBlocks(t *testing.T) {
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
+	ds, err := Create(GTiff, tmpname, 2, Byte, 20, 15, CreationOption("TILED=YES", "BLOCKXSIZE=16", "BLOCKYSIZE=16"))
+	require.NoError(t, err)
+	defer ds.Close()
+	data := make([]byte, 2*20*15)
+	for i := range data {
+		data[i] = byte(i % 2)
+	}
+	_ = ds.Write(0, 0, data, 20, 15)
+
+	blocks := []Block{}
+	ehc := eh()
+	err = ds.ProcessBlocks(func(blk Block, data interface{}) error {
+		blocks = append(blocks, blk)
+		d := data.([]byte)
+		assert.Len(t, d, 2*blk.W*blk.H)
+		for i := range d {
+			d[i] += 10
+		}
+		return nil
+	}, WriteBack(), ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, 16, blocks[0].W)
+	assert.Equal(t, 4, blocks[1].W)
+	assert.Equal(t, 15, blocks[1].H)
+
+	_ = ds.Read(0, 0, data, 20, 15)
+	for i := range data {
+		assert.Equal(t, byte(10+i%2), data[i])
+	}
+
+	// band interleaved, subset of bands, without writing back
+	err = ds.ProcessBlocks(func(blk Block, data interface{}) error {
+		d := data.([]byte)
+		assert.Len(t, d, blk.W*blk.H)
+		for i := range d {
+			assert.Equal(t, byte(11), d[i])
+			d[i] = 0
+		}
+		return nil
+	}, Bands(1), BandInterleaved())
+	assert.NoError(t, err)
+	_ = ds.Read(0, 0, data, 20, 15)
+	assert.Equal(t, byte(11), data[1])
+
+	stop := fmt.Errorf("stop")
+	err = ds.ProcessBlocks(func(blk Block, data interface{}) error {
+		return stop
+	})
+	assert.Equal(t, stop, err)
+}
+
+func TestProcessBlocksParallel(t *testing.T) {
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
+	ds, err := Create(GTiff, tmpname, 1, UInt16, 100, 100, CreationOption("TILED=YES", "BLOCKXSIZE=16", "BLOCKYSIZE=16"))
+	require.NoError(t, err)
+	data := make([]uint16, 100*100)
+	expected := 0
+	for i := range data {
+		data[i] = uint16(i)
+		expected += i
+	}
+	_ = ds.Write(0, 0, data, 100, 100)
+	ds.Close()
+	ds, _ = Open(tmpname)
+	defer ds.Close()
+
+	var (
+		mu     sync.Mutex
+		sum    int
+		blocks int
+	)
+	err = ds.ProcessBlocks(func(blk Block, data interface{}) error {
+		s := 0
+		for _, v := range data.([]uint16) {
+			s += int(v)
+		}
+		mu.Lock()
+		sum += s
+		blocks++
+		mu.Unlock()
+		return nil
+	}, Parallel(4))
+	assert.NoError(t, err)
+	assert.Equal(t, 49, blocks)
+	assert.Equal(t, expected, sum)
+
+	stop := fmt.Errorf("stop")
+	err = ds.ProcessBlocks(func(blk Block, data interface{}) error {
+		return stop
+	}, Parallel(4))
+	assert.Equal(t, stop, err)
+
+	err = ds.ProcessBlocks(func(blk Block, data interface{}) error { return nil }, Parallel(2), WriteBack())
+	assert.Error(t, err)
+
+	mds, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer mds.Close()
+	err = mds.ProcessBlocks(func(blk Block, data interface{}) error { return nil }, Parallel(2))
+	assert.Error(t, err)
+}
+
+func TestNewReadView(t *testing.T) {
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
+	ds, err := Create(GTiff, tmpname, 1, Byte, 64, 64)
+	require.NoError(t, err)
+	data := make([]byte, 64*64)
+	for i := range data {
+		data[i] = byte(i / 64)
+	}
+	_ = ds.Write(0, 0, data, 64, 64)
+	ds.Close()
+	ds, _ = Open(tmpname)
+	defer ds.Close()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		view, err := ds.NewReadView(ErrLogger(eh().ErrorHandler))
+		require.NoError(t, err)
+		assert.Equal(t, ds.Description(), view.Description())
+		wg.Add(1)
+		go func(i int, view *Dataset) {
+			defer wg.Done()
+			defer view.Close()
+			buf := make([]byte, 64)
+			for r := 0; r < 16; r++ {
+				y := i*16 + r
+				assert.NoError(t, view.Read(0, y, buf, 64, 1))
+				assert.Equal(t, byte(y), buf[63])
+			}
+		}(i, view)
+	}
+	wg.Wait()
+
+	mds, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer mds.Close()
+	_, err = mds.NewReadView()
+	assert.Error(t, err)
+}
+
+func TestSourceWindowF(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Float32, 5, 1)
+	defer ds.Close()
+	bnd := ds.Bands()[0]
+	_ = bnd.Write(0, 0, []float32{0, 10, 20, 30, 40}, 5, 1)
+
+	buf := make([]float32, 2)
+	_ = bnd.Read(1, 0, buf, 2, 1, Window(3, 1))
+	assert.Equal(t, []float32{10, 30}, buf)
+
+	ehc := eh()
+	err := bnd.Read(0, 0, buf, 2, 1, SourceWindowF(1.3, 0, 2, 1), ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{10, 20}, buf)
+
+	buf = []float32{0, 0}
+	err = ds.Read(0, 0, buf, 2, 1, SourceWindowF(1.3, 0, 2, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{10, 20}, buf)
+
+	err = bnd.Read(0, 0, buf, 2, 1, SourceWindowF(4.5, 0, 2, 1))
+	assert.Error(t, err)
+}
+
+func TestSetNoDataFromMask(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Byte, 8, 8)
+	defer ds.Close()
+	bnd := ds.Bands()[0]
+	_ = bnd.Fill(50, 0)
+	mds, _ := Create(Memory, "", 1, Byte, 8, 8)
+	defer mds.Close()
+	mask := mds.Bands()[0]
+	_ = mask.Fill(255, 0)
+	// mask out the top left 3x3 corner
+	_ = mask.Write(0, 0, make([]byte, 9), 3, 3)
+
+	ehc := eh()
+	err := bnd.SetNoDataFromMask(mask, 7, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	nd, ok := bnd.NoData()
+	assert.True(t, ok)
+	assert.Equal(t, 7.0, nd)
+	buf := make([]byte, 64)
+	_ = bnd.Read(0, 0, buf, 8, 8)
+	assert.Equal(t, byte(7), buf[0])
+	assert.Equal(t, byte(7), buf[2*8+2])
+	assert.Equal(t, byte(50), buf[3])
+	assert.Equal(t, byte(50), buf[3*8])
+
+	small, _ := Create(Memory, "", 1, Byte, 4, 4)
+	defer small.Close()
+	assert.Error(t, bnd.SetNoDataFromMask(small.Bands()[0], 0))
+}
+
+func TestStructure(t *testing.T) {
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
+	ds, err := Create(GTiff, tmpname, 3, Byte, 64, 64, CreationOption("TILED=YES", "BLOCKXSIZE=32", "BLOCKYSIZE=32"))
+	require.NoError(t, err)
+	st := ds.Structure()
+	assert.Equal(t, 64, st.SizeX)
+	assert.Equal(t, 64, st.SizeY)
+	assert.Equal(t, 32, st.BlockSizeX)
+	assert.Equal(t, 32, st.BlockSizeY)
+	if x, y := st.BlockCount(); x != 2 || y != 2 {
+		t.Errorf("cx,cy: %d,%d", x, y)
+	}
+	if x, y := st.ActualBlockSize(0, 0); x != 32 || y != 32 {
+		t.Errorf("abx,abyy: %d,%d", x, y)
+	}
+	if x, y := st.ActualBlockSize(1, 1); x != 32 || y != 32 {
+		t.Errorf("abx,abyy: %d,%d", x, y)
+	}
+	if x, y := st.ActualBlockSize(2, 2); x != 0 || y != 0 {
+		t.Errorf("abx,abyy: %d,%d", x, y)
+	}
+	ds.Close()
+
+	ds, err = Create(GTiff, tmpname, 3, Byte, 65, 65, CreationOption("TILED=YES", "BLOCKXSIZE=32", "BLOCKYSIZE=32"))
+	require.NoError(t, err)
+	st = ds.Structure()
+	if x, y := st.BlockCount(); x != 3 || y != 3 {
+		t.Errorf("cx,cy: %d,%d", x, y)
+	}
+	if x, y := st.ActualBlockSize(2, 2); x != 1 || y != 1 {
+		t.Errorf("abx,abyy: %d,%d", x, y)
+	}
+	if x, y := st.ActualBlockSize(1, 1); x != 32 || y != 32 {
+		t.Errorf("abx,abyy: %d,%d", x, y)
+	}
+	if x, y := st.ActualBlockSize(3, 3); x != 0 || y != 0 {
+		t.Errorf("abx,abyy: %d,%d", x, y)
+	}
+	ds.Close()
+
+	ds, err = Create(GTiff, tmpname, 3, Byte, 63, 63, CreationOption("TILED=YES", "BLOCKXSIZE=32", "BLOCKYSIZE=32"))
+	require.NoError(t, err)
+	st = ds.Structure()
+	if x, y := st.BlockCount(); x != 2 || y != 2 {
+		t.Errorf("cx,cy: %d,%d", x, y)
+	}
+	if x, y := st.ActualBlockSize(2, 2); x != 0 || y != 0 {
+		t.Errorf("abx,abyy: %d,%d", x, y)
+	}
+	if x, y := st.ActualBlockSize(1, 1); x != 31 || y != 31 {
+		t.Errorf("abx,abyy: %d,%d", x, y)
+	}
+	if x, y := st.ActualBlockSize(0, 0); x != 32 || y != 32 {
+		t.Errorf("abx,abyy: %d,%d", x, y)
+	}
+	ds.Close()
+}
+
+func TestVersion(t *testing.T) {
+	AssertMinVersion(3, 0, 0)
+	assert.False(t, CheckMinVersion(99, 99, 99))
+	assert.True(t, CheckMinVersion(3, 0, 0))
+	assert.Panics(t, func() { AssertMinVersion(99, 99, 99) })
+}
+
+func TestReadOnlyDataset(t *testing.T) {
+	//These tests are essentially here to cover error cases
+	tmpdir, _ := ioutil.TempDir("", "")
+	fname := filepath.Join(tmpdir, "ff.tif")
+	defer func() {
+		_ = os.Chmod(tmpdir, 0777)
+		_ = os.Chmod(fname, 0777)
+		_ = os.RemoveAll(tmpdir)
+	}()
+	ds, _ := Open("testdata/test.tif")
+	rds, _ := ds.Translate(fname, []string{"-b", "1", "-b", "1", "-of", "GTiff"})
+	err := rds.Bands()[0].SetColorInterp(CIAlpha)
+	assert.NoError(t, err)
+	//Setting a second alpha band on a gtiff raises a warning
+	err = rds.Bands()[1].SetColorInterp(CIAlpha)
+	assert.Error(t, err)
+	_ = rds.Close()
+	_ = ds.Close()
+	_ = os.Chmod(fname, 0400)
+	ds, err = Open(fname)
+	require.NoError(t, err)
+	_ = os.Chmod(tmpdir, 0400)
+	_, err = ds.CreateMaskBand(GMF_PER_DATASET, ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"))
+	assert.Error(t, err)
+	ehc := eh()
+	_, err = ds.CreateMaskBand(0x02, ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"), ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+
+	_, err = ds.Bands()[0].CreateMask(0x02, ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"))
+	assert.Error(t, err)
+	ehc = eh()
+	_, err = ds.Bands()[0].CreateMask(0x02, ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"), ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+
+	err = ds.Bands()[0].Fill(5, 5)
+	assert.Error(t, err)
+	ehc = eh()
+	err = ds.Bands()[0].Fill(5, 5, ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
 
 }
 func TestDatasetRead(t *testing.T) {
@@ -827,6 +1571,41 @@ func TestBandRead(t *testing.T) {
 	}
 }
 
+func TestWriteBands(t *testing.T) {
+	ds, _ := Create(Memory, "", 3, Float32, 4, 3)
+	defer ds.Close()
+	r := make([]float32, 12)
+	g := make([]float32, 12)
+	b := make([]float32, 12)
+	for i := range r {
+		r[i], g[i], b[i] = float32(i), float32(100+i), float32(200+i)
+	}
+	ehc := eh()
+	err := ds.WriteBands(0, 0, []interface{}{r, g, b}, 4, 3, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	buf := make([]float32, 12)
+	for i, exp := range [][]float32{r, g, b} {
+		_ = ds.Bands()[i].Read(0, 0, buf, 4, 3)
+		assert.Equal(t, exp, buf)
+	}
+
+	err = ds.WriteBands(1, 1, []interface{}{[]float32{-1, -2}, []float32{-3, -4}}, 2, 1, Bands(2, 0))
+	require.NoError(t, err)
+	_ = ds.Bands()[2].Read(1, 1, buf, 2, 1)
+	assert.Equal(t, []float32{-1, -2}, buf[:2])
+	_ = ds.Bands()[0].Read(1, 1, buf, 2, 1)
+	assert.Equal(t, []float32{-3, -4}, buf[:2])
+
+	assert.Error(t, ds.WriteBands(0, 0, []interface{}{r, g}, 4, 3))
+	assert.Error(t, ds.WriteBands(0, 0, []interface{}{r, g, make([]float64, 12)}, 4, 3))
+	assert.Error(t, ds.WriteBands(0, 0, []interface{}{r, g, b[:6]}, 4, 3))
+	assert.Error(t, ds.WriteBands(0, 0, []interface{}{r, g, b}, 4, 3, BandInterleaved()))
+
+	nobands, _ := Create(Memory, "", 0, Byte, 4, 3)
+	defer nobands.Close()
+	assert.Error(t, nobands.WriteBands(0, 0, nil, 4, 3))
+}
+
 func TestStridedIO(t *testing.T) {
 	ds, _ := Create(Memory, "", 3, Byte, 2, 2)
 	defer func() {
@@ -1191,7 +1970,7 @@ func TestDatasetMask(t *testing.T) {
 	}
 	bnd := ds.Bands()[0]
 	mflag := bnd.MaskFlags()
-	if mflag != 0x01 {
+	if mflag != GMF_ALL_VALID {
 		t.Errorf("mflag: %d", mflag)
 	}
 	_, err = ds.CreateMaskBand(0x02, ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"))
@@ -1199,7 +1978,7 @@ func TestDatasetMask(t *testing.T) {
 		t.Fatal(err)
 	}
 	mflag = bnd.MaskFlags()
-	if mflag != 0x02 {
+	if mflag != GMF_PER_DATASET {
 		t.Errorf("flag: %d", mflag)
 	}
 	ds.Close()
@@ -1208,6 +1987,26 @@ func TestDatasetMask(t *testing.T) {
 		t.Error(".msk was created")
 	}
 }
+
+func TestMaskFlags(t *testing.T) {
+	ds, _ := Create(Memory, "", 4, Byte, 10, 10)
+	defer ds.Close()
+	mf := ds.Bands()[0].MaskFlags()
+	assert.True(t, mf.IsAllValid())
+	assert.False(t, mf.IsNodata())
+
+	_ = ds.Bands()[0].SetNoData(0)
+	mf = ds.Bands()[0].MaskFlags()
+	assert.Equal(t, GMF_NODATA, mf)
+	assert.True(t, mf.IsNodata())
+	assert.False(t, mf.IsAllValid())
+
+	_ = ds.Bands()[3].SetColorInterp(CIAlpha)
+	mf = ds.Bands()[1].MaskFlags()
+	assert.True(t, mf.IsAlpha())
+	assert.True(t, mf.IsPerDataset())
+}
+
 func TestBandMask(t *testing.T) {
 	tmpname := tempfile()
 	defer os.Remove(tmpname)
@@ -1218,7 +2017,7 @@ func TestBandMask(t *testing.T) {
 	}
 	bnd := ds.Bands()[0]
 	mflag := bnd.MaskFlags()
-	if mflag != 0x01 {
+	if mflag != GMF_ALL_VALID {
 		t.Errorf("mflag: %d", mflag)
 	}
 	_, err = bnd.CreateMask(0x00, ConfigOption("GDAL_TIFF_INTERNAL_MASK=NO"))
@@ -1252,6 +2051,29 @@ func TestSetNoData(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSetBandsNoData(t *testing.T) {
+	ds, _ := Create(Memory, "", 3, Byte, 10, 10)
+	defer ds.Close()
+	assert.NoError(t, ds.SetBandsNoData(5))
+	for _, bnd := range ds.Bands() {
+		nd, ok := bnd.NoData()
+		assert.True(t, ok)
+		assert.Equal(t, 5.0, nd)
+	}
+	assert.NoError(t, ds.ClearBandsNoData())
+	for _, bnd := range ds.Bands() {
+		_, ok := bnd.NoData()
+		assert.False(t, ok)
+	}
+
+	rds, _ := Open("testdata/test.tif")
+	defer rds.Close()
+	err := rds.SetBandsNoData(0.5)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "band 1:")
+	assert.Contains(t, err.Error(), "band 3:")
+}
+
 func TestOpen(t *testing.T) {
 	_, err := Open("testdata/test.tif", Drivers("MEM"))
 	if err == nil {
@@ -1330,15 +2152,15 @@ func TestOpenUpdate(t *testing.T) {
 	uds, _ = Open(tt)
 	flags := uds.Bands()[0].MaskFlags()
 	if CheckMinVersion(3, 9, 0) {
-		assert.Equal(t, 0x2, flags, "mask not set")
+		assert.Equal(t, GMF_PER_DATASET, flags, "mask not set")
 	} else {
-		assert.Equal(t, 0x8, flags, "mask not being set from nodata values")
+		assert.Equal(t, GMF_NODATA, flags, "mask not being set from nodata values")
 	}
 
 	_ = uds.Close()
 	uds, _ = Open(tt, SiblingFiles(filepath.Base(tt+".msk")))
 	flags = uds.Bands()[0].MaskFlags()
-	if flags != 0x2 {
+	if flags != GMF_PER_DATASET {
 		t.Errorf("mask was not used: %d", flags)
 	}
 	nd, _ := uds.Bands()[0].NoData()
@@ -1471,14 +2293,186 @@ func TestTransform(t *testing.T) {
 	ct.Close()
 	assert.NotPanics(t, ct.Close, "2nd close must not panic")
 
+	ct, err = NewTransform(sr1, sr2, WithAreaOfInterest(-10, 40, 10, 50))
+	assert.NoError(t, err)
+	x = []float64{1}
+	y = []float64{45}
+	assert.NoError(t, ct.TransformEx(x, y, nil, nil))
+	ct.Close()
+	_, err = NewTransform(sr1, sr2, WithAreaOfInterest(-10, 400, 10, 50))
+	assert.Error(t, err)
+	ct, err = NewTransform(sr1, sr2, DisallowBallpark())
+	if CheckMinVersion(3, 3, 0) {
+		assert.NoError(t, err)
+		ct.Close()
+	} else {
+		assert.Error(t, err)
+	}
+
+	//lat/long ordered 4326
+	authsr := sr1.Clone()
+	defer authsr.Close()
+	authsr.SetAxisMappingStrategy(AuthorityCompliant)
+	fwd, err := NewTransform(authsr, sr2)
+	require.NoError(t, err)
+	x = []float64{2.35}
+	y = []float64{48.85}
+	assert.NoError(t, fwd.TransformEx(x, y, nil, nil))
+	assert.InDelta(t, 5438000, x[0], 1000) //2.35 was taken as the latitude
+	assert.InDelta(t, 261700, y[0], 1000)
+	fwd.Close()
+
+	fwd, err = NewTransform(authsr, sr2, TransformTraditionalGISOrder())
+	require.NoError(t, err)
+	inv, err := NewTransform(sr2, authsr, TransformTraditionalGISOrder())
+	require.NoError(t, err)
+	x = []float64{2.35}
+	y = []float64{48.85}
+	assert.NoError(t, fwd.TransformEx(x, y, nil, nil))
+	assert.InDelta(t, 261600, x[0], 100) //easting first
+	assert.InDelta(t, 6250000, y[0], 1000)
+	assert.NoError(t, inv.TransformEx(x, y, nil, nil))
+	assert.InDelta(t, 2.35, x[0], 1e-8) //longitude first
+	assert.InDelta(t, 48.85, y[0], 1e-8)
+	fwd.Close()
+	inv.Close()
+
 	_, err = NewTransform(sr1, &SpatialRef{handle: nil})
 	if err == nil {
 		t.Error("err not raised")
 	}
 }
-func TestProjection(t *testing.T) {
-	tmpname := tempfile()
-	defer os.Remove(tmpname)
+func TestDatasetTransform(t *testing.T) {
+	src, _ := Open("testdata/test.tif")
+	defer src.Close()
+	dstname := "/vsimem/dataset_transform_3857.tif"
+	dst, err := src.Warp(dstname, []string{"-t_srs", "epsg:3857", "-of", "GTiff"})
+	require.NoError(t, err)
+	defer func() { _ = VSIUnlink(dstname) }()
+	defer dst.Close()
+
+	ehc := eh()
+	dt, err := NewDatasetTransform(src, dst, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	defer dt.Close()
+
+	//expected pixel coords of the src top-left corner, computed from the georeferencing
+	sgt, _ := src.GeoTransform()
+	dgt, _ := dst.GeoTransform()
+	sr4326, _ := NewSpatialRefFromEPSG(4326)
+	sr3857, _ := NewSpatialRefFromEPSG(3857)
+	trn, _ := NewTransform(sr4326, sr3857)
+	ex, ey := []float64{sgt[0]}, []float64{sgt[3]}
+	_ = trn.TransformEx(ex, ey, nil, nil)
+	trn.Close()
+	epx, epy := (ex[0]-dgt[0])/dgt[1], (ey[0]-dgt[3])/dgt[5]
+
+	x, y := []float64{0}, []float64{0}
+	err = dt.Transform(x, y, false)
+	assert.NoError(t, err)
+	assert.InDelta(t, epx, x[0], 1e-3)
+	assert.InDelta(t, epy, y[0], 1e-3)
+
+	err = dt.Transform(x, y, true, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, x[0], 1e-3)
+	assert.InDelta(t, 0, y[0], 1e-3)
+
+	assert.Error(t, dt.Transform([]float64{0}, nil, false))
+	dt.Close()
+	assert.NotPanics(t, dt.Close)
+
+	nogeo, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer nogeo.Close()
+	_, err = NewDatasetTransform(src, nogeo)
+	assert.Error(t, err)
+}
+
+func TestPixelToGeo(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Byte, 100, 100)
+	defer ds.Close()
+	sr, _ := NewSpatialRefFromEPSG(4326)
+	defer sr.Close()
+
+	_, _, err := ds.PixelToGeo([]float64{0}, []float64{0})
+	assert.Error(t, err)
+
+	gcps := []GCP{
+		{DfGCPPixel: 0, DfGCPLine: 0, DfGCPX: 10, DfGCPY: 20},
+		{DfGCPPixel: 100, DfGCPLine: 0, DfGCPX: 110, DfGCPY: 20},
+		{DfGCPPixel: 0, DfGCPLine: 100, DfGCPX: 10, DfGCPY: -80},
+	}
+	err = ds.SetGCPs(gcps, GCPSpatialRef(sr))
+	require.NoError(t, err)
+
+	ehc := eh()
+	xs, ys, err := ds.PixelToGeo([]float64{50, 100}, []float64{50, 0}, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{60, 110}, xs, 1e-6)
+	assert.InDeltaSlice(t, []float64{-30, 20}, ys, 1e-6)
+
+	px, ln, err := ds.GeoToPixel(xs, ys, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{50, 100}, px, 1e-6)
+	assert.InDeltaSlice(t, []float64{50, 0}, ln, 1e-6)
+
+	_, _, err = ds.GeoToPixel([]float64{0}, nil)
+	assert.Error(t, err)
+	xs, _, err = ds.PixelToGeo(nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, xs)
+}
+
+func TestRPC(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Byte, 100, 100)
+	defer ds.Close()
+	_, ok := ds.RPC()
+	assert.False(t, ok)
+
+	coeffs := func(idx int, v float64) string {
+		c := make([]string, 20)
+		for i := range c {
+			c[i] = "0"
+		}
+		c[idx] = fmt.Sprintf("%g", v)
+		return strings.Join(c, " ")
+	}
+	// a linear model mapping (lon=5,lat=45) to the center of the image, with one
+	// degree per 50 pixels
+	md := map[string]string{
+		"LINE_OFF": "50", "SAMP_OFF": "50", "LAT_OFF": "45", "LONG_OFF": "5", "HEIGHT_OFF": "0",
+		"LINE_SCALE": "50", "SAMP_SCALE": "50", "LAT_SCALE": "1", "LONG_SCALE": "1", "HEIGHT_SCALE": "1",
+		"LINE_NUM_COEFF": coeffs(2, -1), "LINE_DEN_COEFF": coeffs(0, 1),
+		"SAMP_NUM_COEFF": coeffs(1, 1), "SAMP_DEN_COEFF": coeffs(0, 1),
+	}
+	for k, v := range md {
+		_ = ds.SetMetadata(k, v, Domain("RPC"))
+	}
+	rpc, ok := ds.RPC()
+	require.True(t, ok)
+	assert.Equal(t, 50.0, rpc.LineOff)
+	assert.Equal(t, 45.0, rpc.LatOff)
+	assert.Equal(t, -1.0, rpc.LineNumCoeff[2])
+	assert.Equal(t, 1.0, rpc.SampNumCoeff[1])
+	assert.Equal(t, 1.0, rpc.SampDenCoeff[0])
+
+	ehc := eh()
+	xs, ys, err := ds.PixelToGeo([]float64{50, 100}, []float64{50, 0}, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{5, 6}, xs, 1e-4)
+	assert.InDeltaSlice(t, []float64{45, 46}, ys, 1e-4)
+	px, ln, err := ds.GeoToPixel([]float64{5.5}, []float64{44.5})
+	require.NoError(t, err)
+	assert.InDelta(t, 75, px[0], 1e-4)
+	assert.InDelta(t, 75, ln[0], 1e-4)
+
+	_, _, err = ds.GeoToPixel([]float64{5.5}, []float64{44.5}, RPCDEM("testdata/not_existing.tif"))
+	assert.Error(t, err)
+}
+
+func TestProjection(t *testing.T) {
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
 	ds, err := Create(GTiff, tmpname, 1, Byte, 20, 20)
 	if err != nil {
 		t.Fatal(err)
@@ -1699,6 +2693,150 @@ func TestProjMisc(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSpatialRefAreaOfUseAxis(t *testing.T) {
+	sr, _ := NewSpatialRefFromEPSG(32631)
+	defer sr.Close()
+	w, s, e, n, name, ok := sr.AreaOfUse()
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, w)
+	assert.Equal(t, 6.0, e)
+	assert.Equal(t, 0.0, s)
+	assert.Equal(t, 84.0, n)
+	assert.NotEmpty(t, name)
+
+	name, orient := sr.Axis("PROJCS", 0)
+	assert.Equal(t, "Easting", name)
+	assert.Equal(t, "East", orient)
+	name, orient = sr.Axis("PROJCS", 1)
+	assert.Equal(t, "Northing", name)
+	assert.Equal(t, "North", orient)
+	name, orient = sr.Axis("PROJCS", 5)
+	assert.Empty(t, name)
+	assert.Empty(t, orient)
+
+	geo, _ := NewSpatialRefFromEPSG(4326)
+	defer geo.Close()
+	name, orient = geo.Axis("GEOGCS", 0)
+	assert.Equal(t, "Latitude", name)
+	assert.Equal(t, "North", orient)
+
+	custom, _ := NewSpatialRefFromProj4("+proj=utm +zone=31 +datum=WGS84 +units=m +no_defs")
+	defer custom.Close()
+	_, _, _, _, _, ok = custom.AreaOfUse()
+	assert.False(t, ok)
+}
+
+func TestSpatialRefFindMatches(t *testing.T) {
+	sr, _ := NewSpatialRefFromProj4("+proj=utm +zone=31 +datum=WGS84 +units=m +no_defs")
+	defer sr.Close()
+
+	ehc := eh()
+	matches, err := sr.FindMatches(ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, matches) {
+		assert.Equal(t, "EPSG", matches[0].SpatialRef.AuthorityName(""))
+		assert.Equal(t, "32631", matches[0].SpatialRef.AuthorityCode(""))
+		assert.Greater(t, matches[0].Confidence, 0)
+		assert.LessOrEqual(t, matches[0].Confidence, 100)
+	}
+	for _, m := range matches {
+		m.SpatialRef.Close()
+	}
+
+	_, err = (&SpatialRef{}).FindMatches()
+	assert.Error(t, err)
+}
+
+func TestSpatialRefESRI(t *testing.T) {
+	prj := `PROJCS["WGS_1984_UTM_Zone_31N",GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],` +
+		`PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],PROJECTION["Transverse_Mercator"],PARAMETER["False_Easting",500000.0],` +
+		`PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",3.0],PARAMETER["Scale_Factor",0.9996],` +
+		`PARAMETER["Latitude_Of_Origin",0.0],UNIT["Meter",1.0]]`
+	ehc := eh()
+	sr, err := NewSpatialRefFromESRI(prj, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	defer sr.Close()
+	assert.True(t, sr.Projected())
+	datum, _ := sr.AttrValue("DATUM", 0)
+	assert.Equal(t, "WGS_1984", datum)
+
+	epsg, _ := NewSpatialRefFromEPSG(32631)
+	defer epsg.Close()
+	esri, err := epsg.ESRIWKT()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(esri, `PROJCS["WGS_1984_UTM_Zone_31N",GEOGCS["GCS_WGS_1984"`), esri)
+	wkt, _ := epsg.WKT()
+	assert.Contains(t, wkt, `AUTHORITY["EPSG","32631"]`, "ESRIWKT must not morph the SpatialRef")
+
+	_, err = NewSpatialRefFromESRI("invalid")
+	assert.Error(t, err)
+	_, err = (&SpatialRef{}).ESRIWKT(ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+}
+
+func TestProjExport(t *testing.T) {
+	ds, _ := Open("testdata/test.tif")
+	sr := ds.SpatialRef()
+	clone := sr.Clone()
+	_ = ds.Close()
+	defer clone.Close()
+
+	epsg4326, _ := NewSpatialRefFromEPSG(4326)
+	defer epsg4326.Close()
+	assert.True(t, epsg4326.IsSame(clone))
+
+	p4, err := clone.Proj4()
+	assert.NoError(t, err)
+	assert.Contains(t, p4, "+proj=longlat")
+
+	if CheckMinVersion(3, 1, 0) {
+		pj, err := clone.PROJJSON()
+		assert.NoError(t, err)
+		assert.Contains(t, pj, "\n")
+		assert.Contains(t, pj, `"name": "WGS 84"`)
+		ehc := eh()
+		pj, err = clone.PROJJSON(SingleLine(), ErrLogger(ehc.ErrorHandler))
+		assert.NoError(t, err)
+		assert.NotContains(t, pj, "\n")
+	}
+
+	var nilsr *SpatialRef
+	assert.Nil(t, nilsr.Clone())
+	_, err = (&SpatialRef{}).Proj4()
+	assert.Error(t, err)
+	ehc := eh()
+	_, err = (&SpatialRef{}).Proj4(ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+	p4, err = clone.Proj4(ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.Contains(t, p4, "+proj=longlat")
+}
+
+func TestNewSpatialRefFromCRSURL(t *testing.T) {
+	epsg4326, _ := NewSpatialRefFromEPSG(4326)
+	defer epsg4326.Close()
+	for _, uri := range []string{
+		"http://www.opengis.net/def/crs/EPSG/0/4326",
+		"https://www.opengis.net/def/crs/EPSG/0/4326",
+		"urn:ogc:def:crs:EPSG::4326",
+	} {
+		sr, err := NewSpatialRefFromCRSURL(uri)
+		require.NoError(t, err, uri)
+		assert.True(t, sr.IsSame(epsg4326), uri)
+		code := sr.AuthorityCode("")
+		assert.Equal(t, "4326", code, uri)
+		sr.Close()
+	}
+
+	_, err := NewSpatialRefFromCRSURL("epsg:4326")
+	assert.Error(t, err)
+	_, err = NewSpatialRefFromCRSURL("https://example.com/crs/4326")
+	assert.Error(t, err)
+	ehc := eh()
+	_, err = NewSpatialRefFromCRSURL("http://www.opengis.net/def/crs/EPSG/0/99999999", ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+}
+
 func TestGeoTransform(t *testing.T) {
 	tmpname := tempfile()
 	defer os.Remove(tmpname)
@@ -1788,6 +2926,27 @@ func TestGeometryTransform(t *testing.T) {
 	err = gp.Transform(trn, ErrLogger(ehc.ErrorHandler))
 	assert.Error(t, err)
 	gp.Close()
+
+	gp, _ = NewGeometryFromWKT("LINESTRING (10 10,10 91,11 11)", sr)
+	err = gp.Transform(trn)
+	assert.Error(t, err)
+	dropped, err := gp.TransformPartial(trn)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+	assert.True(t, gp.SpatialRef().IsSame(srm))
+	gp.Close()
+
+	ehc = eh()
+	gp, _ = NewGeometryFromWKT("LINESTRING (10 10,11 11)", sr)
+	dropped, err = gp.TransformPartial(trn, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	gp.Close()
+
+	gp, _ = NewGeometryFromWKT("POINT (10 91)", sr)
+	_, err = gp.TransformPartial(trn)
+	assert.Error(t, err)
+	gp.Close()
 }
 
 func TestProjBounds(t *testing.T) {
@@ -1802,6 +2961,45 @@ func TestProjBounds(t *testing.T) {
 
 }
 
+func TestTransformBounds(t *testing.T) {
+	sr4326, _ := NewSpatialRefFromEPSG(4326)
+	defer sr4326.Close()
+	srPolar, _ := NewSpatialRefFromEPSG(3413)
+	defer srPolar.Close()
+	box, _ := NewGeometryFromWKT("POLYGON((-105 50,-105 80,15 80,15 50,-105 50))", sr4326)
+	defer box.Close()
+
+	corners, err := box.Bounds(srPolar)
+	require.NoError(t, err)
+	dense, err := box.Bounds(srPolar, DensifyBounds(21))
+	require.NoError(t, err)
+	if !CheckMinVersion(3, 4, 0) {
+		assert.Equal(t, corners, dense)
+		return
+	}
+	//the lat=50 edge bulges away from the pole, so is not contained in the corner-only bbox
+	assert.LessOrEqual(t, dense[0], corners[0])
+	assert.LessOrEqual(t, dense[1], corners[1])
+	assert.GreaterOrEqual(t, dense[2], corners[2])
+	assert.GreaterOrEqual(t, dense[3], corners[3])
+	assert.Greater(t, (dense[2]-dense[0])*(dense[3]-dense[1]), (corners[2]-corners[0])*(corners[3]-corners[1]))
+
+	trn, err := NewTransform(sr4326, srPolar)
+	require.NoError(t, err)
+	defer trn.Close()
+	tb, err := trn.TransformBounds([4]float64{-105, 50, 15, 80}, 21)
+	assert.NoError(t, err)
+	assert.Equal(t, dense, tb)
+
+	ehc := eh()
+	tb, err = trn.TransformBounds([4]float64{-105, 50, 15, 80}, 21, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.Equal(t, dense, tb)
+	_, err = trn.TransformBounds([4]float64{-105, 50, 15, 80}, -1, ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+	assert.NotZero(t, ehc.errs)
+}
+
 func TestTranslate(t *testing.T) {
 	tmpname := tempfile()
 	tmpname2 := tempfile()
@@ -1836,110 +3034,636 @@ func TestTranslate(t *testing.T) {
 		t.Errorf("wrong block size %d,%d", st.BlockSizeX, st.BlockSizeY)
 	}
 }
-func TestDatasetWarp(t *testing.T) {
-	tmpname := tempfile()
-	tmpname2 := tempfile()
-	defer os.Remove(tmpname)
-	defer os.Remove(tmpname2)
 
-	ds, err := Create(GTiff, tmpname, 1, Byte, 20, 20)
-	if err != nil {
-		t.Fatal(err)
-	}
+func TestTranslateToBytes(t *testing.T) {
+	ds, _ := Open("testdata/test.tif")
 	defer ds.Close()
-	sr, _ := NewSpatialRefFromEPSG(3857)
-	err = ds.SetSpatialRef(sr)
-	if err != nil {
-		t.Error(err)
-	}
-	err = ds.SetGeoTransform([6]float64{0, 2, 0, 0, 0, -2})
-	if err != nil {
-		t.Error(err)
-	}
-	_, err = ds.Warp(tmpname2, []string{"-bogus"})
-	if err == nil {
-		t.Error("invalid switch not detected")
-	}
-	/* TODO
-	ds2, err = ds.Warp(tmpname2, nil, CreationOption("BAR=BAZ"))
-	if err == nil {
-		t.Error("invalid creation option not detected")
-	}
-	*/
-	ds2, err := ds.Warp(tmpname2, []string{"-ts", "40", "40"}, CreationOption("TILED=YES", "BLOCKXSIZE=32", "BLOCKYSIZE=16"), GTiff)
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer ds2.Close()
-	st := ds2.Structure()
-	if st.SizeX != 40 || st.SizeY != 40 {
-		t.Errorf("wrong size %d,%d", st.SizeX, st.SizeY)
-	}
-	if st.BlockSizeX != 32 || st.BlockSizeY != 16 {
-		t.Errorf("wrong block size %d,%d", st.BlockSizeX, st.BlockSizeY)
-	}
-}
-func TestDatasetWarpMulti(t *testing.T) {
-	ds1, _ := Create(Memory, "", 1, Byte, 5, 5)
-	ds2, _ := Create(Memory, "", 1, Byte, 5, 5)
 
-	sr, _ := NewSpatialRefFromEPSG(4326)
-	_ = ds1.SetSpatialRef(sr)
-	_ = ds2.SetSpatialRef(sr)
+	require.NoError(t, RegisterRaster("PNG"))
+	ehc := eh()
+	png, err := ds.TranslateToBytes(nil, DriverName("PNG"), ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(png, []byte("\x89PNG\r\n\x1a\n")))
 
-	_ = ds1.SetGeoTransform([6]float64{45, 1, 0, 35, 0, -1})
-	_ = ds2.SetGeoTransform([6]float64{50, 1, 0, 35, 0, -1})
+	tif, err := ds.TranslateToBytes([]string{"-of", "GTiff", "-outsize", "5", "5"})
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(tif, []byte("II*\x00")))
 
-	for _, b := range ds1.Bands() {
-		_ = b.Fill(200, 0)
-	}
+	_, err = ds.TranslateToBytes([]string{"-bogus"})
+	assert.Error(t, err)
+}
 
-	for _, b := range ds2.Bands() {
-		_ = b.Fill(100, 0)
+func TestVSIMem(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/test.tif")
+	assert.NoError(t, err)
+	ehc := eh()
+	err = VSIMemCreate("/vsimem/memcreate.tif", data, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	data[0] = 0 // the file must not reference the go slice
+	ds, err := Open("/vsimem/memcreate.tif")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 10, ds.Structure().SizeX)
+		_ = ds.Close()
 	}
 
-	defer ds1.Close()
-	defer ds2.Close()
+	stolen, err := VSIMemSteal("/vsimem/memcreate.tif", ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), len(stolen))
+	assert.Equal(t, data[1:], stolen[1:])
+	assert.NotEqual(t, data[0], stolen[0])
+	_, err = VSIOpen("/vsimem/memcreate.tif")
+	assert.Error(t, err, "stolen file must be removed")
 
-	// Warp NewDataset with multiple input dataset
-	filePath := path.Join(os.TempDir(), "warp.tif")
-	outputDataset, err := Warp(filePath, []*Dataset{ds1, ds2}, []string{}, CreationOption("TILED=YES"), GTiff)
-	if err != nil {
-		t.Fatal(err)
-	}
+	_, err = VSIMemSteal("/vsimem/memcreate.tif")
+	assert.Error(t, err)
+	err = VSIMemCreate("/tmp/memcreate.tif", data)
+	assert.Error(t, err)
 
-	defer os.Remove(filePath)
-	defer outputDataset.Close()
+	err = VSIMemCreate("/vsimem/empty.bin", nil)
+	assert.NoError(t, err)
+	stolen, err = VSIMemSteal("/vsimem/empty.bin")
+	assert.NoError(t, err, "empty file must be found")
+	assert.Len(t, stolen, 0)
+	_, err = VSIMemSteal("/vsimem/empty.bin")
+	assert.Error(t, err)
+}
 
-	if outputDataset.Structure().SizeX != 10 || outputDataset.Structure().SizeY != 5 {
-		t.Errorf("wrong size %d,%d", outputDataset.Structure().SizeX, outputDataset.Structure().SizeY)
+func TestSetPathSpecificOption(t *testing.T) {
+	ehc := eh()
+	err := SetPathSpecificOption("/vsis3/godal-test-bucket", "AWS_REGION", "eu-west-3", ErrLogger(ehc.ErrorHandler))
+	if CheckMinVersion(3, 6, 0) {
+		assert.NoError(t, err)
+	} else {
+		assert.Error(t, err)
 	}
+	err = SetCredential("/vsis3/godal-test-bucket", "AWS_NO_SIGN_REQUEST", "YES")
+	if CheckMinVersion(3, 5, 0) {
+		assert.NoError(t, err)
+	} else {
+		assert.Error(t, err)
+	}
+	// do not leak process-wide options to other tests
+	err = ClearPathSpecificOptions("/vsis3/godal-test-bucket", ErrLogger(ehc.ErrorHandler))
+	if CheckMinVersion(3, 5, 0) {
+		assert.NoError(t, err)
+	} else {
+		assert.Error(t, err)
+	}
+}
 
-	// read total warp result
-	data := make([]uint8, 50)
-	err = outputDataset.Read(0, 0, data, outputDataset.Structure().SizeX, outputDataset.Structure().SizeY) //Bands(0, 1, 2),
-	//Window(outputDataset.Structure().SizeX, outputDataset.Structure().SizeY),
-
-	assert.NoError(t, err)
+func TestVSICurlCache(t *testing.T) {
+	ds, _ := Open("testdata/test.tif")
+	defer ds.Close()
+	buf := make([]uint8, 300)
+	_ = ds.Read(0, 0, buf, 10, 10)
+	used, limit := VSICacheStats()
+	assert.Greater(t, used, int64(0))
+	assert.GreaterOrEqual(t, limit, used)
+	bused, blimit := BlockCacheStats()
+	assert.Equal(t, used, bused)
+	assert.Equal(t, limit, blimit)
 
-	assert.Equal(t, []uint8{
-		200, 200, 200, 200, 200, 100, 100, 100, 100, 100,
-		200, 200, 200, 200, 200, 100, 100, 100, 100, 100,
-		200, 200, 200, 200, 200, 100, 100, 100, 100, 100,
-		200, 200, 200, 200, 200, 100, 100, 100, 100, 100,
-		200, 200, 200, 200, 200, 100, 100, 100, 100, 100}, data)
+	VSICurlPartialClearCache("/vsicurl/http://example.com/")
+	VSICurlClearCache()
 }
-func TestDatasetWarpInto(t *testing.T) {
-	outputDataset, _ := Create(Memory, "", 1, Byte, 5, 5)
-	inputDataset, _ := Create(Memory, "", 1, Byte, 5, 5)
 
-	for _, b := range outputDataset.Bands() {
-		_ = b.Fill(200, 0)
+func TestCompression(t *testing.T) {
+	ds, _ := Open("testdata/test.tif")
+	defer ds.Close()
+	drv, _ := getDriver("GTiff")
+	if !strings.Contains(drv.Metadata("DMD_CREATIONOPTIONLIST"), "<Value>ZSTD</Value>") {
+		_, err := ds.Translate("", nil, GTiff, Compression(CompressZSTD))
+		assert.Error(t, err)
+		t.Skip("gdal built without zstd support")
 	}
 
-	for _, b := range inputDataset.Bands() {
-		_ = b.Fill(155, 0)
-	}
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
+	zds, err := ds.Translate(tmpname, nil, GTiff,
+		Compression(CompressZSTD, CompressionLevel(9), Predictor(2), CompressionThreads(0)))
+	require.NoError(t, err)
+	assert.Equal(t, "ZSTD", zds.Metadata("COMPRESSION", Domain("IMAGE_STRUCTURE")))
+	assert.Equal(t, "2", zds.Metadata("PREDICTOR", Domain("IMAGE_STRUCTURE")))
+	_ = zds.Close()
+
+	cds, err := Create(GTiff, tmpname, 1, Byte, 16, 16, Compression(CompressDeflate))
+	require.NoError(t, err)
+	_ = cds.Close()
+	cds, _ = Open(tmpname)
+	assert.Equal(t, "DEFLATE", cds.Metadata("COMPRESSION", Domain("IMAGE_STRUCTURE")))
+	_ = cds.Close()
+
+	_, err = ds.Translate(tmpname, nil, GTiff, Compression(CompressLZW, CompressionLevel(3)))
+	assert.Error(t, err)
+	_, err = ds.Translate(tmpname, nil, GTiff, Compression(CompressZSTD, CompressionLevel(30)))
+	assert.Error(t, err)
+	_, err = Warp(tmpname, []*Dataset{ds}, nil, GTiff, Compression(CompressJPEG, Predictor(2)))
+	assert.Error(t, err)
+	_, err = Create(GTiff, tmpname, 1, Byte, 16, 16, Compression("BOGUS"))
+	assert.Error(t, err)
+	_, err = ds.Translate(tmpname, nil, DriverName("PNG"), Compression(CompressDeflate))
+	assert.Error(t, err)
+
+	copts, err := compressionOpt{alg: CompressZSTD, level: 9, predictor: 2}.creationOptions("GTiff")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"COMPRESS=ZSTD", "PREDICTOR=2", "ZSTD_LEVEL=9"}, copts)
+	if _, ok := getDriver("COG"); !ok {
+		return
+	}
+	copts, err = compressionOpt{alg: CompressZSTD, level: 9, predictor: 2}.creationOptions("COG")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"COMPRESS=ZSTD", "PREDICTOR=STANDARD", "LEVEL=9"}, copts)
+	copts, err = compressionOpt{alg: CompressJPEG, level: 80}.creationOptions("COG")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"COMPRESS=JPEG", "QUALITY=80"}, copts)
+
+	cogds, err := ds.Translate(tmpname, []string{"-of", "COG"},
+		Compression(CompressDeflate, CompressionLevel(6), Predictor(2)))
+	require.NoError(t, err)
+	assert.Equal(t, "DEFLATE", cogds.Metadata("COMPRESSION", Domain("IMAGE_STRUCTURE")))
+	assert.Equal(t, "2", cogds.Metadata("PREDICTOR", Domain("IMAGE_STRUCTURE")))
+	_ = cogds.Close()
+}
+
+func TestRetile(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("", "")
+	defer os.RemoveAll(tmpdir)
+
+	ds, _ := Create(Memory, "", 1, Byte, 64, 64)
+	defer ds.Close()
+	_ = ds.SetGeoTransform([6]float64{0, 1, 0, 64, 0, -1})
+
+	files, err := ds.Retile(tmpdir, 32, 32)
+	assert.NoError(t, err)
+	require.Len(t, files, 4)
+	assert.Equal(t, filepath.Join(tmpdir, "tile_0_1.tif"), files[1])
+	for _, f := range files {
+		tile, err := Open(f)
+		require.NoError(t, err)
+		st := tile.Structure()
+		assert.Equal(t, 32, st.SizeX)
+		assert.Equal(t, 32, st.SizeY)
+		_ = tile.Close()
+	}
+	tile, _ := Open(files[3])
+	gt, _ := tile.GeoTransform()
+	assert.Equal(t, [6]float64{32, 1, 0, 32, 0, -1}, gt)
+	_ = tile.Close()
+
+	files, err = ds.Retile(tmpdir, 32, 32, Overlap(8), TileNamePattern("ovl_%02d_%02d.tif"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 9)
+	assert.Equal(t, filepath.Join(tmpdir, "ovl_02_02.tif"), files[8])
+
+	// the second column/row of tiles reaches the edge, no tile must be created after them
+	ds56, _ := Create(Memory, "", 1, Byte, 56, 56)
+	defer ds56.Close()
+	files, err = ds56.Retile(tmpdir, 32, 32, Overlap(8), TileNamePattern("edge_%02d_%02d.tif"))
+	assert.NoError(t, err)
+	require.Len(t, files, 4)
+	for _, f := range files {
+		tile, _ := Open(f)
+		assert.Equal(t, 32, tile.Structure().SizeX)
+		assert.Equal(t, 32, tile.Structure().SizeY)
+		_ = tile.Close()
+	}
+
+	_, err = ds.Retile(tmpdir, 0, 32)
+	assert.Error(t, err)
+	_, err = ds.Retile(tmpdir, 32, 32, Overlap(32))
+	assert.Error(t, err)
+	ehc := eh()
+	_, err = ds.Retile("/nonexistent/dir", 32, 32, ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+}
+
+func TestSplitBands(t *testing.T) {
+	ds, _ := Create(Memory, "", 3, Byte, 16, 16)
+	defer ds.Close()
+	gt := [6]float64{10, 1, 0, 20, 0, -1}
+	_ = ds.SetGeoTransform(gt)
+	for i, b := range ds.Bands() {
+		_ = b.Fill(float64(i+1), 0)
+	}
+
+	for _, drv := range []DriverName{VRT, Memory} {
+		bands, err := ds.SplitBands(drv)
+		require.NoError(t, err)
+		require.Len(t, bands, 3)
+		for i, bds := range bands {
+			assert.Len(t, bds.Bands(), 1)
+			bgt, err := bds.GeoTransform()
+			assert.NoError(t, err)
+			assert.Equal(t, gt, bgt)
+			buf := make([]byte, 1)
+			_ = bds.Read(0, 0, buf, 1, 1)
+			assert.Equal(t, byte(i+1), buf[0])
+			_ = bds.Close()
+		}
+	}
+	ehc := eh()
+	_, err := ds.SplitBands(GTiff, ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+}
+
+func TestMultiDimInfo(t *testing.T) {
+	if !CheckMinVersion(3, 1, 0) {
+		t.Skip("multidimensional api requires gdal >= 3.1")
+	}
+	vrt := `<VRTDataset>
+  <Group name="/">
+    <Dimension name="X" size="3"/>
+    <Dimension name="Y" size="2"/>
+    <Array name="temperature">
+      <DataType>Float32</DataType>
+      <DimensionRef ref="Y"/>
+      <DimensionRef ref="X"/>
+      <ConstantValue>280.5</ConstantValue>
+    </Array>
+  </Group>
+</VRTDataset>`
+	tmpname := tempfile() + ".vrt"
+	defer os.Remove(tmpname)
+	require.NoError(t, ioutil.WriteFile(tmpname, []byte(vrt), 0644))
+
+	ds, err := Open(tmpname, Multidimensional())
+	require.NoError(t, err)
+	defer ds.Close()
+	ehc := eh()
+	info, err := ds.MultiDimInfo([]string{"-detailed"}, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	assert.Contains(t, info, `"temperature"`)
+	assert.Contains(t, info, `"Float32"`)
+	assert.Contains(t, info, `"X"`)
+
+	_, err = ds.MultiDimInfo([]string{"-invalidswitch"})
+	assert.Error(t, err)
+
+	cds, _ := Create(Memory, "", 1, Byte, 2, 2)
+	defer cds.Close()
+	_, err = cds.MultiDimInfo(nil)
+	assert.Error(t, err)
+}
+
+func TestMultiDimInfoNetCDF(t *testing.T) {
+	if !CheckMinVersion(3, 1, 0) {
+		t.Skip("multidimensional api requires gdal >= 3.1")
+	}
+	if _, ok := RasterDriver("netCDF"); !ok {
+		t.Skip("gdal built without netCDF support")
+	}
+	ds, err := Open("testdata/test.nc", Multidimensional())
+	require.NoError(t, err)
+	defer ds.Close()
+	info, err := ds.MultiDimInfo(nil)
+	require.NoError(t, err)
+	assert.Contains(t, info, `"temperature"`)
+	assert.Contains(t, info, `"Float32"`)
+	assert.Contains(t, info, `"x"`)
+	assert.Contains(t, info, `"y"`)
+	assert.Contains(t, info, `"K"`)
+}
+
+func TestExpandPaletteToRGBA(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Byte, 8, 8)
+	defer ds.Close()
+	bnd := ds.Bands()[0]
+
+	_, err := ds.ExpandPaletteToRGBA()
+	assert.Error(t, err)
+
+	_ = bnd.SetColorTable(ColorTable{
+		PaletteInterp: RGBPalette,
+		Entries: [][4]int16{
+			{0, 0, 0, 0},
+			{10, 20, 30, 255},
+			{200, 100, 50, 128},
+		},
+	})
+	_ = bnd.Fill(1, 0)
+	_ = bnd.Write(0, 0, []byte{2}, 1, 1)
+
+	for _, drv := range []DriverName{VRT, Memory} {
+		ehc := eh()
+		rgba, err := ds.ExpandPaletteToRGBA(drv, ErrLogger(ehc.ErrorHandler))
+		require.NoError(t, err)
+		require.Len(t, rgba.Bands(), 4)
+		assert.Equal(t, CIRed, rgba.Bands()[0].ColorInterp())
+		assert.Equal(t, CIAlpha, rgba.Bands()[3].ColorInterp())
+		buf := make([]byte, 8)
+		_ = rgba.Read(0, 0, buf, 2, 1)
+		assert.Equal(t, []byte{200, 100, 50, 128, 10, 20, 30, 255}, buf)
+		_ = rgba.Close()
+	}
+	_, err = ds.ExpandPaletteToRGBA(GTiff)
+	assert.Error(t, err)
+}
+
+func TestDatasetWarp(t *testing.T) {
+	tmpname := tempfile()
+	tmpname2 := tempfile()
+	defer os.Remove(tmpname)
+	defer os.Remove(tmpname2)
+
+	ds, err := Create(GTiff, tmpname, 1, Byte, 20, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ds.Close()
+	sr, _ := NewSpatialRefFromEPSG(3857)
+	err = ds.SetSpatialRef(sr)
+	if err != nil {
+		t.Error(err)
+	}
+	err = ds.SetGeoTransform([6]float64{0, 2, 0, 0, 0, -2})
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = ds.Warp(tmpname2, []string{"-bogus"})
+	if err == nil {
+		t.Error("invalid switch not detected")
+	}
+	/* TODO
+	ds2, err = ds.Warp(tmpname2, nil, CreationOption("BAR=BAZ"))
+	if err == nil {
+		t.Error("invalid creation option not detected")
+	}
+	*/
+	ds2, err := ds.Warp(tmpname2, []string{"-ts", "40", "40"}, CreationOption("TILED=YES", "BLOCKXSIZE=32", "BLOCKYSIZE=16"), GTiff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ds2.Close()
+	st := ds2.Structure()
+	if st.SizeX != 40 || st.SizeY != 40 {
+		t.Errorf("wrong size %d,%d", st.SizeX, st.SizeY)
+	}
+	if st.BlockSizeX != 32 || st.BlockSizeY != 16 {
+		t.Errorf("wrong block size %d,%d", st.BlockSizeX, st.BlockSizeY)
+	}
+}
+func TestDatasetWarpMulti(t *testing.T) {
+	ds1, _ := Create(Memory, "", 1, Byte, 5, 5)
+	ds2, _ := Create(Memory, "", 1, Byte, 5, 5)
+
+	sr, _ := NewSpatialRefFromEPSG(4326)
+	_ = ds1.SetSpatialRef(sr)
+	_ = ds2.SetSpatialRef(sr)
+
+	_ = ds1.SetGeoTransform([6]float64{45, 1, 0, 35, 0, -1})
+	_ = ds2.SetGeoTransform([6]float64{50, 1, 0, 35, 0, -1})
+
+	for _, b := range ds1.Bands() {
+		_ = b.Fill(200, 0)
+	}
+
+	for _, b := range ds2.Bands() {
+		_ = b.Fill(100, 0)
+	}
+
+	defer ds1.Close()
+	defer ds2.Close()
+
+	// Warp NewDataset with multiple input dataset
+	filePath := path.Join(os.TempDir(), "warp.tif")
+	outputDataset, err := Warp(filePath, []*Dataset{ds1, ds2}, []string{}, CreationOption("TILED=YES"), GTiff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(filePath)
+	defer outputDataset.Close()
+
+	if outputDataset.Structure().SizeX != 10 || outputDataset.Structure().SizeY != 5 {
+		t.Errorf("wrong size %d,%d", outputDataset.Structure().SizeX, outputDataset.Structure().SizeY)
+	}
+
+	// read total warp result
+	data := make([]uint8, 50)
+	err = outputDataset.Read(0, 0, data, outputDataset.Structure().SizeX, outputDataset.Structure().SizeY) //Bands(0, 1, 2),
+	//Window(outputDataset.Structure().SizeX, outputDataset.Structure().SizeY),
+
+	assert.NoError(t, err)
+
+	assert.Equal(t, []uint8{
+		200, 200, 200, 200, 200, 100, 100, 100, 100, 100,
+		200, 200, 200, 200, 200, 100, 100, 100, 100, 100,
+		200, 200, 200, 200, 200, 100, 100, 100, 100, 100,
+		200, 200, 200, 200, 200, 100, 100, 100, 100, 100,
+		200, 200, 200, 200, 200, 100, 100, 100, 100, 100}, data)
+}
+func TestDatasetWarpUnifyNoData(t *testing.T) {
+	sr, _ := NewSpatialRefFromEPSG(4326)
+	defer sr.Close()
+
+	// ds1 marks its empty last column with 0, but advertises 255 as nodata
+	ds1, _ := Create(Memory, "", 1, Byte, 5, 5)
+	defer ds1.Close()
+	_ = ds1.SetSpatialRef(sr)
+	_ = ds1.SetGeoTransform([6]float64{45, 1, 0, 35, 0, -1})
+	_ = ds1.Bands()[0].SetNoData(255)
+	buf := make([]uint8, 25)
+	for i := range buf {
+		if i%5 != 4 {
+			buf[i] = 200
+		}
+	}
+	_ = ds1.Write(0, 0, buf, 5, 5)
+
+	// ds2 overlaps the empty column of ds1 and has no nodata
+	ds2, _ := Create(Memory, "", 1, Byte, 5, 5)
+	defer ds2.Close()
+	_ = ds2.SetSpatialRef(sr)
+	_ = ds2.SetGeoTransform([6]float64{49, 1, 0, 35, 0, -1})
+	_ = ds2.Bands()[0].Fill(100, 0)
+
+	// ds1 is warped last: without unified nodata its empty column overwrites ds2
+	switches := []string{"-te", "45", "30", "55", "35"}
+	out, err := Warp("", []*Dataset{ds2, ds1}, switches, Memory)
+	require.NoError(t, err)
+	data := make([]uint8, 10)
+	_ = out.Read(0, 0, data, 10, 1)
+	assert.Equal(t, []uint8{200, 200, 200, 200, 0, 100, 100, 100, 100, 0}, data)
+	out.Close()
+
+	ehc := eh()
+	out, err = Warp("", []*Dataset{ds2, ds1}, switches, Memory, UnifyNoData(0), ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	defer out.Close()
+	_ = out.Read(0, 0, data, 10, 1)
+	assert.Equal(t, []uint8{200, 200, 200, 200, 100, 100, 100, 100, 100, 0}, data)
+	nd, ok := out.Bands()[0].NoData()
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, nd)
+
+	into, _ := Create(Memory, "", 1, Byte, 10, 5)
+	defer into.Close()
+	_ = into.SetSpatialRef(sr)
+	_ = into.SetGeoTransform([6]float64{45, 1, 0, 35, 0, -1})
+	_ = into.Bands()[0].Fill(50, 0)
+	err = into.WarpInto([]*Dataset{ds2, ds1}, []string{}, UnifyNoData(0))
+	require.NoError(t, err)
+	_ = into.Read(0, 0, data, 10, 1)
+	assert.Equal(t, []uint8{200, 200, 200, 200, 100, 100, 100, 100, 100, 50}, data)
+}
+
+func TestDatasetWarpNoDataResampling(t *testing.T) {
+	sr, _ := NewSpatialRefFromEPSG(4326)
+	defer sr.Close()
+	src, _ := Create(Memory, "", 2, Byte, 4, 4)
+	defer src.Close()
+	_ = src.SetSpatialRef(sr)
+	_ = src.SetGeoTransform([6]float64{0, 1, 0, 4, 0, -1})
+	// band 1 uses 10 and band 2 uses 20 to mark the empty first pixel
+	b1 := []uint8{10, 30, 0, 0, 60, 90, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	b2 := []uint8{20, 40, 0, 0, 100, 130, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	_ = src.Bands()[0].Write(0, 0, b1, 4, 4)
+	_ = src.Bands()[1].Write(0, 0, b2, 4, 4)
+
+	ehc := eh()
+	out, err := Warp("", []*Dataset{src}, []string{"-ts", "2", "2"}, Memory,
+		SrcNoData(10, 20), DstNoData(255), Resampling(Average), ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	defer out.Close()
+	data := make([]uint8, 1)
+	_ = out.Bands()[0].Read(0, 0, data, 1, 1)
+	assert.Equal(t, uint8(60), data[0]) // (30+60+90)/3
+	_ = out.Bands()[1].Read(0, 0, data, 1, 1)
+	assert.Equal(t, uint8(90), data[0]) // (40+100+130)/3
+	nd, ok := out.Bands()[1].NoData()
+	assert.True(t, ok)
+	assert.Equal(t, 255.0, nd)
+
+	_, err = Warp("", []*Dataset{src}, nil, Memory, UnifyNoData(0), SrcNoData(1))
+	assert.Error(t, err)
+	_, err = Warp("", []*Dataset{src}, nil, Memory, SrcNoData())
+	assert.Error(t, err)
+	_, err = Warp("", []*Dataset{src}, nil, Memory, DstNoData())
+	assert.Error(t, err)
+	_, err = Warp("", []*Dataset{src}, nil, Memory, Resampling(Gauss))
+	assert.Error(t, err)
+
+	into, _ := Create(Memory, "", 2, Byte, 2, 2)
+	defer into.Close()
+	_ = into.SetSpatialRef(sr)
+	_ = into.SetGeoTransform([6]float64{0, 2, 0, 4, 0, -2})
+	err = into.WarpInto([]*Dataset{src}, nil, SrcNoData(10, 20), Resampling(Average))
+	require.NoError(t, err)
+	_ = into.Bands()[0].Read(0, 0, data, 1, 1)
+	assert.Equal(t, uint8(60), data[0])
+}
+
+func TestDatasetWarpPerformanceOptions(t *testing.T) {
+	sr, _ := NewSpatialRefFromEPSG(4326)
+	defer sr.Close()
+	src, _ := Create(Memory, "", 1, Byte, 64, 64)
+	defer src.Close()
+	_ = src.SetSpatialRef(sr)
+	_ = src.SetGeoTransform([6]float64{0, 1, 0, 64, 0, -1})
+	_ = src.Bands()[0].Fill(42, 0)
+
+	ehc := eh()
+	out, err := Warp("", []*Dataset{src}, []string{"-t_srs", "epsg:3857"}, Memory,
+		WarpMemoryLimit(64), MultiThreaded(2), ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	defer out.Close()
+	data := make([]uint8, 1)
+	st := out.Structure()
+	_ = out.Read(st.SizeX/2, st.SizeY/2, data, 1, 1)
+	assert.Equal(t, uint8(42), data[0])
+
+	out2, err := Warp("", []*Dataset{src}, nil, Memory, WarpMemoryLimit(20000), MultiThreaded(0))
+	require.NoError(t, err)
+	out2.Close()
+
+	_, err = Warp("", []*Dataset{src}, nil, Memory, WarpMemoryLimit(-1))
+	assert.Error(t, err)
+
+	into, _ := Create(Memory, "", 1, Byte, 32, 32)
+	defer into.Close()
+	_ = into.SetSpatialRef(sr)
+	_ = into.SetGeoTransform([6]float64{0, 2, 0, 64, 0, -2})
+	err = into.WarpInto([]*Dataset{src}, nil, WarpMemoryLimit(16), MultiThreaded(-1))
+	require.NoError(t, err)
+	_ = into.Read(0, 0, data, 1, 1)
+	assert.Equal(t, uint8(42), data[0])
+	assert.Error(t, into.WarpInto([]*Dataset{src}, nil, WarpMemoryLimit(-5)))
+}
+
+func TestDatasetWarpCutline(t *testing.T) {
+	sr, _ := NewSpatialRefFromEPSG(4326)
+	defer sr.Close()
+	src, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer src.Close()
+	_ = src.SetSpatialRef(sr)
+	_ = src.SetGeoTransform([6]float64{0, 1, 0, 10, 0, -1})
+	_ = src.Bands()[0].Fill(100, 0)
+
+	clip, _ := NewGeometryFromWKT("POLYGON((2 2,6 2,6 6,2 6,2 2))", sr)
+	defer clip.Close()
+
+	ehc := eh()
+	out, err := Warp("", []*Dataset{src}, []string{"-dstnodata", "0"}, Memory, Cutline(clip), ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	assert.Equal(t, 10, out.Structure().SizeX)
+	buf := make([]byte, 100)
+	_ = out.Read(0, 0, buf, 10, 10)
+	assert.Equal(t, byte(0), buf[0])
+	assert.Equal(t, byte(100), buf[5*10+3])
+	out.Close()
+
+	out, err = Warp("", []*Dataset{src}, []string{"-dstnodata", "0"}, Memory, Cutline(clip), CropToCutline())
+	require.NoError(t, err)
+	assert.Equal(t, 4, out.Structure().SizeX)
+	assert.Equal(t, 4, out.Structure().SizeY)
+	buf = make([]byte, 16)
+	_ = out.Read(0, 0, buf, 4, 4)
+	assert.Equal(t, bytes.Repeat([]byte{100}, 16), buf)
+	out.Close()
+
+	_, err = Warp("", []*Dataset{src}, nil, Memory, CropToCutline())
+	assert.Error(t, err)
+
+	vds, _ := CreateVector(Memory, "")
+	defer vds.Close()
+	lyr, _ := vds.CreateLayer("clip", sr, GTPolygon)
+	f, _ := lyr.NewFeature(clip)
+	f.Close()
+
+	dst, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer dst.Close()
+	_ = dst.SetSpatialRef(sr)
+	_ = dst.SetGeoTransform([6]float64{0, 1, 0, 10, 0, -1})
+	err = dst.WarpInto([]*Dataset{src}, nil, CutlineLayer(vds, "clip"))
+	require.NoError(t, err)
+	buf = make([]byte, 100)
+	_ = dst.Read(0, 0, buf, 10, 10)
+	assert.Equal(t, byte(0), buf[0])
+	assert.Equal(t, byte(100), buf[5*10+3])
+
+	err = dst.WarpInto([]*Dataset{src}, nil, CutlineLayer(vds, "nonexistent"))
+	assert.Error(t, err)
+	assert.NotPanics(t, func() {
+		err = dst.WarpInto([]*Dataset{src}, nil, Cutline(nil))
+	})
+	assert.Error(t, err)
+	assert.NotPanics(t, func() {
+		err = dst.WarpInto([]*Dataset{src}, nil, CutlineLayer(nil, "clip"))
+	})
+	assert.Error(t, err)
+}
+
+func TestDatasetWarpInto(t *testing.T) {
+	outputDataset, _ := Create(Memory, "", 1, Byte, 5, 5)
+	inputDataset, _ := Create(Memory, "", 1, Byte, 5, 5)
+
+	for _, b := range outputDataset.Bands() {
+		_ = b.Fill(200, 0)
+	}
+
+	for _, b := range inputDataset.Bands() {
+		_ = b.Fill(155, 0)
+	}
 
 	sr, _ := NewSpatialRefFromEPSG(4326)
 	_ = outputDataset.SetSpatialRef(sr)
@@ -2062,12 +3786,58 @@ func TestBuildOverviews(t *testing.T) {
 		t.Errorf("band 0 expected 0 overviews")
 	}
 
+	_ = ds.ClearOverviews()
+	err = ds.BuildOverviews(Levels(4, 8))
+	assert.NoError(t, err)
+	ehc = eh()
+	err = ds.BuildOverviews(Levels(2, 4, 8), KeepExisting(), ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.Len(t, ds.Bands()[0].Overviews(), 3)
+	err = ds.BuildOverviews(Levels(2, 4, 8), KeepExisting())
+	assert.NoError(t, err)
+	assert.Len(t, ds.Bands()[0].Overviews(), 3)
+
 	_ = ds.ClearOverviews()
 	err = ds.BuildOverviews(ConfigOption("GDAL_TIFF_OVR_BLOCKSIZE=64"))
 	assert.NoError(t, err)
 	ovrst := ds.Bands()[0].Overviews()[0].Structure()
 	assert.Equal(t, 64, ovrst.BlockSizeX)
 
+	tmpname = tempfile()
+	defer os.Remove(tmpname)
+	defer os.Remove(tmpname + ".ovr")
+	ds, err = Create(GTiff, tmpname, 1, Byte, 1000, 1000, CreationOption("TILED=YES", "BLOCKXSIZE=256", "BLOCKYSIZE=256"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ds.BuildOverviews(External(), Levels(2, 4), ConfigOption("GDAL_TIFF_OVR_BLOCKSIZE=64"))
+	assert.NoError(t, err)
+	assert.Len(t, ds.Bands()[0].Overviews(), 0)
+	_ = ds.Close()
+	_, err = os.Stat(tmpname + ".ovr")
+	assert.NoError(t, err)
+	ds, _ = Open(tmpname, SiblingFiles())
+	ovrs = ds.Bands()[0].Overviews()
+	assert.Len(t, ovrs, 2)
+	assert.Equal(t, 64, ovrs[0].Structure().BlockSizeX)
+	_ = ds.Close()
+
+	ds, _ = Create(Memory, "", 1, Byte, 1000, 1000)
+	err = ds.BuildOverviews(External())
+	assert.Error(t, err)
+	_ = ds.Close()
+
+	ds, _ = Open(tmpname, SiblingFiles(), Update())
+	ehc = eh()
+	err = ds.ClearOverviews(KeepExternal(), ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	_ = ds.Close()
+	_, err = os.Stat(tmpname + ".ovr")
+	assert.NoError(t, err, ".ovr file must not be deleted")
+	ds, _ = Open(tmpname, SiblingFiles())
+	assert.Len(t, ds.Bands()[0].Overviews(), 2)
+	_ = ds.Close()
+
 	/* TODO find a driver that supports building overviews for a single band. disabled for now
 	ds, _ = Create(Memory,"", 2, Byte, 2000, 2000)
 	defer ds.Close()
@@ -2084,6 +3854,149 @@ func TestBuildOverviews(t *testing.T) {
 	*/
 }
 
+func TestBuildOverviewsHeterogeneousBands(t *testing.T) {
+	base, _ := Create(GTiff, "/vsimem/ovrbase.tif", 2, Byte, 64, 64)
+	defer func() { _ = VSIUnlink("/vsimem/ovrbase.tif") }()
+	_ = base.Close()
+	ovr, _ := Create(GTiff, "/vsimem/ovrlevel2.tif", 1, Byte, 32, 32)
+	defer func() { _ = VSIUnlink("/vsimem/ovrlevel2.tif") }()
+	_ = ovr.Close()
+
+	vrt := `<VRTDataset rasterXSize="64" rasterYSize="64">
+  <VRTRasterBand dataType="Byte" band="1">
+    <SimpleSource>
+      <SourceFilename relativeToVRT="0">/vsimem/ovrbase.tif</SourceFilename>
+      <SourceBand>1</SourceBand>
+    </SimpleSource>
+    <Overview>
+      <SourceFilename relativeToVRT="0">/vsimem/ovrlevel2.tif</SourceFilename>
+      <SourceBand>1</SourceBand>
+    </Overview>
+  </VRTRasterBand>
+  <VRTRasterBand dataType="Byte" band="2">
+    <SimpleSource>
+      <SourceFilename relativeToVRT="0">/vsimem/ovrbase.tif</SourceFilename>
+      <SourceBand>2</SourceBand>
+    </SimpleSource>
+  </VRTRasterBand>
+</VRTDataset>`
+	ds, err := Open(vrt)
+	require.NoError(t, err)
+	defer ds.Close()
+	require.Len(t, ds.Bands()[0].Overviews(), 1)
+	require.Len(t, ds.Bands()[1].Overviews(), 0)
+
+	err = ds.BuildOverviews(Levels(2, 4), KeepExisting())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "level 2")
+
+	err = ds.BuildOverviews(Bands(5), Levels(2))
+	assert.Error(t, err)
+}
+
+func TestBuildOverviewsHeterogeneousBlockSizes(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("", "")
+	defer os.RemoveAll(tmpdir)
+	base, err := Create(GTiff, filepath.Join(tmpdir, "base.tif"), 2, Byte, 256, 256)
+	require.NoError(t, err)
+	_ = base.Close()
+
+	vrt := `<VRTDataset rasterXSize="256" rasterYSize="256">
+  <VRTRasterBand dataType="Byte" band="1" blockXSize="32" blockYSize="32">
+    <SimpleSource>
+      <SourceFilename relativeToVRT="1">base.tif</SourceFilename>
+      <SourceBand>1</SourceBand>
+    </SimpleSource>
+  </VRTRasterBand>
+  <VRTRasterBand dataType="Byte" band="2" blockXSize="128" blockYSize="64">
+    <SimpleSource>
+      <SourceFilename relativeToVRT="1">base.tif</SourceFilename>
+      <SourceBand>2</SourceBand>
+    </SimpleSource>
+  </VRTRasterBand>
+</VRTDataset>`
+	vrtname := filepath.Join(tmpdir, "hetero.vrt")
+	require.NoError(t, ioutil.WriteFile(vrtname, []byte(vrt), 0644))
+	ds, err := Open(vrtname)
+	require.NoError(t, err)
+	defer ds.Close()
+	if st := ds.Bands()[1].Structure(); st.BlockSizeX != 128 || st.BlockSizeY != 64 {
+		t.Skip("per band vrt block sizes not supported by this gdal version")
+	}
+	require.Equal(t, 32, ds.Bands()[0].Structure().BlockSizeX)
+
+	//the largest block size (128) must be used: a single overview of 128x128. Using
+	//the first band's block size would have created 2, 4 and 8 levels.
+	require.NoError(t, ds.BuildOverviews())
+	for _, bnd := range ds.Bands() {
+		ovrs := bnd.Overviews()
+		require.Len(t, ovrs, 1)
+		assert.Equal(t, 128, ovrs[0].Structure().SizeX)
+		assert.Equal(t, 128, ovrs[0].Structure().SizeY)
+	}
+}
+
+func TestBuildOverviewsMaskResampling(t *testing.T) {
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
+	ds, err := Create(GTiff, tmpname, 1, Byte, 64, 64)
+	require.NoError(t, err)
+	defer ds.Close()
+	mask, err := ds.CreateMaskBand(0x02, ConfigOption("GDAL_TIFF_INTERNAL_MASK=YES"))
+	require.NoError(t, err)
+	//alternating valid and masked columns, that average to partial transparency
+	buf := make([]byte, 64*64)
+	for i := range buf {
+		if i%2 == 0 {
+			buf[i] = 255
+		}
+	}
+	require.NoError(t, mask.Write(0, 0, buf, 64, 64))
+
+	err = ds.BuildOverviews(Levels(2, 4), Resampling(Average), MaskResampling(Nearest))
+	require.NoError(t, err)
+	movrs := ds.Bands()[0].MaskBand().Overviews()
+	require.Len(t, movrs, 2)
+	for _, movr := range movrs {
+		st := movr.Structure()
+		obuf := make([]byte, st.SizeX*st.SizeY)
+		require.NoError(t, movr.Read(0, 0, obuf, st.SizeX, st.SizeY))
+		for _, v := range obuf {
+			if v != 0 && v != 255 {
+				t.Fatalf("partially transparent mask overview value %d", v)
+			}
+		}
+	}
+}
+
+func TestReportOverviewUsage(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Byte, 1000, 1000)
+	defer ds.Close()
+	bnd := ds.Bands()[0]
+	buf := make([]byte, 100*100)
+
+	used := true
+	err := bnd.Read(0, 0, buf, 100, 100, Window(1000, 1000), ReportOverviewUsage(&used))
+	assert.NoError(t, err)
+	assert.False(t, used, "band has no overviews")
+
+	err = ds.BuildOverviews(Levels(2, 4, 8))
+	require.NoError(t, err)
+
+	err = bnd.Read(0, 0, buf, 100, 100, Window(1000, 1000), ReportOverviewUsage(&used))
+	assert.NoError(t, err)
+	assert.True(t, used, "downsampled read should use overviews")
+
+	err = bnd.Read(0, 0, buf, 100, 100, ReportOverviewUsage(&used))
+	assert.NoError(t, err)
+	assert.False(t, used, "full resolution read should not use overviews")
+
+	used = true
+	err = bnd.Write(0, 0, buf, 100, 100, Window(1000, 1000), ReportOverviewUsage(&used))
+	assert.NoError(t, err)
+	assert.False(t, used)
+}
+
 func TestResampling(t *testing.T) {
 	ds, _ := Create(Memory, "", 1, Byte, 10, 10)
 	data := make([]uint8, 100)
@@ -2157,6 +4070,136 @@ func TestResampling(t *testing.T) {
 			assert.Error(t, err, "%s overview resampling error not raised", a.String())
 		}
 	}
+
+	_ = ds.ClearOverviews()
+	assert.Equal(t, "rms", RMS.String())
+	if CheckMinVersion(3, 3, 0) {
+		//sqrt((0²+1²+2²+10²+11²+12²+20²+21²+22²)/9) = 13.72
+		err := ds.Read(0, 0, data, 1, 1, Window(3, 3), Resampling(RMS))
+		assert.NoError(t, err)
+		assert.InDelta(t, 13.72, float64(data[0]), 1)
+		//sqrt((0²+1²+10²+11²)/4) = 7.45
+		err = ds.BuildOverviews(Resampling(RMS), Levels(2))
+		assert.NoError(t, err)
+		err = ds.Bands()[0].Overviews()[0].Read(0, 0, data, 1, 1)
+		assert.NoError(t, err)
+		assert.InDelta(t, 7.45, float64(data[0]), 1)
+	} else {
+		err := ds.Read(0, 0, data, 1, 1, Window(3, 3), Resampling(RMS))
+		assert.Error(t, err)
+	}
+}
+
+func TestContour(t *testing.T) {
+	rds, _ := Create(Memory, "", 1, Float64, 10, 10)
+	defer rds.Close()
+	data := make([]float64, 100)
+	for i := range data {
+		data[i] = float64(i % 10)
+	}
+	bnd := rds.Bands()[0]
+	_ = bnd.Write(0, 0, data, 10, 10)
+
+	vds, _ := CreateVector(Memory, "")
+	defer vds.Close()
+	lyr, _ := vds.CreateLayer("contours", nil, GTLineString,
+		NewFieldDefinition("id", FTInt), NewFieldDefinition("elev", FTReal))
+
+	assert.Error(t, bnd.Contour(lyr))
+	assert.Error(t, bnd.Contour(lyr, ContourInterval(-1)))
+
+	ehc := eh()
+	err := bnd.Contour(lyr, ContourInterval(2), IDField(0), ElevationField(1), ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	cnt, _ := lyr.FeatureCount()
+	assert.Equal(t, 4, cnt)
+	elevs := []float64{}
+	for f := lyr.NextFeature(); f != nil; f = lyr.NextFeature() {
+		elevs = append(elevs, f.Fields()["elev"].Float())
+		f.Close()
+	}
+	assert.ElementsMatch(t, []float64{2, 4, 6, 8}, elevs)
+
+	fixed, _ := vds.CreateLayer("fixed", nil, GTLineString, NewFieldDefinition("elev", FTReal))
+	err = bnd.Contour(fixed, FixedLevels(3.5, 7.5), ElevationField(0), ContourNoData(9))
+	require.NoError(t, err)
+	cnt, _ = fixed.FeatureCount()
+	assert.Equal(t, 2, cnt)
+}
+
+func TestDatasetPolygonize(t *testing.T) {
+	rds, _ := Create(Memory, "", 1, Byte, 8, 8)
+	defer rds.Close()
+	vds, _ := CreateVector(Memory, "")
+	defer vds.Close()
+	data := make([]byte, 64)
+	for r := 0; r < 8; r++ {
+		for c := 0; c < 8; c++ {
+			if r == c {
+				data[r*8+c] = 128
+			} else {
+				data[r*8+c] = 64
+			}
+		}
+	}
+	_ = rds.Write(0, 0, data, 8, 8)
+
+	lyr, _ := vds.CreateLayer("classes", nil, GTPolygon, NewFieldDefinition("unused", FTString), NewFieldDefinition("class", FTInt))
+	ehc := eh()
+	err := rds.Polygonize(0, lyr, PixelValueFieldName("class"), Connectedness(8), ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	cnt, _ := lyr.FeatureCount()
+	assert.Equal(t, 2, cnt)
+	classes := map[int64]bool{}
+	for f := lyr.NextFeature(); f != nil; f = lyr.NextFeature() {
+		classes[f.Fields()["class"].Int()] = true
+		f.Close()
+	}
+	assert.Equal(t, map[int64]bool{64: true, 128: true}, classes)
+
+	l4, _ := vds.CreateLayer("c4", nil, GTPolygon)
+	require.NoError(t, rds.Polygonize(0, l4, Connectedness(4)))
+	cnt, _ = l4.FeatureCount()
+	assert.Equal(t, 10, cnt)
+
+	assert.Error(t, rds.Polygonize(0, l4, PixelValueFieldName("missing")))
+	assert.Error(t, rds.Polygonize(0, l4, Connectedness(6)))
+	assert.Error(t, rds.Polygonize(1, l4))
+	assert.Error(t, rds.Bands()[0].SieveFilter(2, Connectedness(6)))
+}
+
+func TestFeatureMultiGeometry(t *testing.T) {
+	fname := "/vsimem/multigeom.csv"
+	vf, err := VSIOpen(fname, VSIOpenMode("w"))
+	require.NoError(t, err)
+	_, _ = vf.Write([]byte("id,_WKTgeom1,_WKTgeom2\n1,\"POINT (1 2)\",\"LINESTRING (0 0,1 1)\"\n"))
+	_ = vf.Close()
+	defer func() { _ = VSIUnlink(fname) }()
+
+	ds, err := Open(fname, VectorOnly())
+	require.NoError(t, err)
+	defer ds.Close()
+	feat := ds.Layers()[0].NextFeature()
+	require.NotNil(t, feat)
+	defer feat.Close()
+
+	require.Equal(t, 2, feat.GeometryCount())
+	wkt, _ := feat.GeometryByIndex(0).WKT()
+	assert.Equal(t, "POINT (1 2)", wkt)
+	wkt, _ = feat.GeometryByIndex(1).WKT()
+	assert.Equal(t, "LINESTRING (0 0,1 1)", wkt)
+	assert.Nil(t, feat.GeometryByIndex(2).handle)
+
+	pt, _ := NewGeometryFromWKT("POINT (5 6)", nil)
+	defer pt.Close()
+	ehc := eh()
+	require.NoError(t, feat.SetGeometryByIndex(1, pt, ErrLogger(ehc.ErrorHandler)))
+	wkt, _ = feat.GeometryByIndex(1).WKT()
+	assert.Equal(t, "POINT (5 6)", wkt)
+	wkt, _ = feat.Geometry().WKT()
+	assert.Equal(t, "POINT (1 2)", wkt)
+
+	assert.Error(t, feat.SetGeometryByIndex(2, pt))
 }
 
 func TestPolygonize(t *testing.T) {
@@ -2269,6 +4312,98 @@ func TestPolygonize(t *testing.T) {
 	}
 }
 
+func TestChecksum(t *testing.T) {
+	ds, _ := Open("testdata/test.tif")
+	defer ds.Close()
+	bnd := ds.Bands()[0]
+	full, err := bnd.Checksum(0, 0, 0, 0)
+	assert.NoError(t, err)
+	st := bnd.Structure()
+	ehc := eh()
+	full2, err := bnd.Checksum(0, 0, st.SizeX, st.SizeY, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.Equal(t, full, full2)
+	assert.True(t, full >= 0 && full < 65536)
+
+	mds, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer mds.Close()
+	empty, _ := mds.Bands()[0].Checksum(0, 0, 0, 0)
+	_ = mds.Bands()[0].Fill(1, 0)
+	filled, _ := mds.Bands()[0].Checksum(0, 0, 0, 0)
+	assert.NotEqual(t, empty, filled)
+	sub1, _ := mds.Bands()[0].Checksum(5, 5, 0, 0)
+	sub2, _ := mds.Bands()[0].Checksum(5, 5, 5, 5)
+	assert.Equal(t, sub1, sub2)
+
+	_, err = bnd.Checksum(0, 0, st.SizeX+1, st.SizeY)
+	assert.Error(t, err)
+}
+
+func TestProximityRaster(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Byte, 5, 5)
+	defer ds.Close()
+	_ = ds.SetGeoTransform([6]float64{0, 10, 0, 50, 0, -10})
+	data := make([]byte, 25)
+	data[2*5+2] = 1
+	_ = ds.Write(0, 0, data, 5, 5)
+	bnd := ds.Bands()[0]
+
+	pds, err := bnd.ProximityRaster([]float64{1})
+	require.NoError(t, err)
+	st := pds.Structure()
+	assert.Equal(t, Float32, st.DataType)
+	assert.Equal(t, 5, st.SizeX)
+	gt, _ := pds.GeoTransform()
+	assert.Equal(t, [6]float64{0, 10, 0, 50, 0, -10}, gt)
+	dist := make([]float32, 25)
+	_ = pds.Read(0, 0, dist, 5, 5)
+	assert.Equal(t, float32(0), dist[2*5+2])
+	assert.Equal(t, float32(1), dist[2*5+3])
+	assert.Equal(t, float32(2), dist[0*5+2])
+	assert.InDelta(t, math.Sqrt2, dist[3*5+3], 1e-5)
+	assert.InDelta(t, 2*math.Sqrt2, dist[0], 1e-5)
+	_ = pds.Close()
+
+	ehc := eh()
+	pds, err = bnd.ProximityRaster([]float64{1}, DistanceUnits(GeoDistance), MaxDistance(15), ProximityNoData(255),
+		ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	_ = pds.Read(0, 0, dist, 5, 5)
+	assert.Equal(t, float32(10), dist[2*5+3])
+	assert.InDelta(t, 10*math.Sqrt2, dist[3*5+3], 1e-4)
+	assert.Equal(t, float32(255), dist[0])
+	_ = pds.Close()
+}
+
+func TestComputeProximity(t *testing.T) {
+	ds, _ := Create(Memory, "", 2, Byte, 5, 5)
+	defer ds.Close()
+	data := make([]byte, 25)
+	data[2*5+2] = 7
+	data[0] = 3
+	bnd := ds.Bands()[0]
+	_ = bnd.Write(0, 0, data, 5, 5)
+	dst := ds.Bands()[1]
+
+	ehc := eh()
+	err := bnd.ComputeProximity(dst, TargetValues(7), MaxDistance(1), FixedBufferValue(100), ProximityNoData(0),
+		ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	_ = dst.Read(0, 0, data, 5, 5)
+	assert.Equal(t, byte(100), data[2*5+2])
+	assert.Equal(t, byte(100), data[2*5+3])
+	assert.Equal(t, byte(0), data[2*5+4])
+	assert.Equal(t, byte(0), data[0])
+
+	// all non-zero pixels are targets by default
+	err = bnd.ComputeProximity(dst)
+	require.NoError(t, err)
+	_ = dst.Read(0, 0, data, 5, 5)
+	assert.Equal(t, byte(0), data[0])
+	assert.Equal(t, byte(1), data[1])
+	assert.Equal(t, byte(0), data[2*5+2])
+}
+
 func TestFillNoData(t *testing.T) {
 	ds, _ := Create(Memory, "", 1, Byte, 1000, 1000)
 	mskds, _ := Create(Memory, "", 1, Byte, 1000, 1000)
@@ -2546,6 +4681,51 @@ func TestVectorTranslate(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestResultSetColumns(t *testing.T) {
+	ds, _ := CreateVector(Memory, "")
+	defer ds.Close()
+	lyr, _ := ds.CreateLayer("l1", nil, GTPoint,
+		NewFieldDefinition("name", FTString, FieldWidth(10)),
+		NewFieldDefinition("val", FTReal))
+	for i, wkt := range []string{"POINT (1 1)", "POINT (10 10)"} {
+		pt, _ := NewGeometryFromWKT(wkt, nil)
+		f, _ := lyr.NewFeature(pt)
+		_ = f.SetFieldValue(f.Fields()["name"], fmt.Sprintf("pt%d", i))
+		_ = lyr.UpdateFeature(f)
+		f.Close()
+		pt.Close()
+	}
+
+	rs, err := ds.ExecuteSQL("SELECT name, val FROM l1", OGRSQLDialect(), ErrLogger(eh().ErrorHandler))
+	require.NoError(t, err)
+	cols := rs.Columns()
+	require.Len(t, cols, 2)
+	assert.Equal(t, "name", cols[0].Name())
+	assert.Equal(t, FTString, cols[0].Type())
+	assert.Equal(t, 10, cols[0].Width())
+	assert.True(t, cols[0].Nullable())
+	assert.Equal(t, "val", cols[1].Name())
+	assert.Equal(t, FTReal, cols[1].Type())
+	_, ok := cols[1].Default()
+	assert.False(t, ok)
+	_ = rs.Close()
+
+	rs, err = ds.ExecuteSQL("SELECT COUNT(*) AS cnt FROM l1", SQLDialect("SQLITE"))
+	require.NoError(t, err)
+	cols = rs.Columns()
+	require.Len(t, cols, 1)
+	assert.Equal(t, "cnt", cols[0].Name())
+	_ = rs.Close()
+
+	filter, _ := NewGeometryFromWKT("POLYGON ((0 0,0 2,2 2,2 0,0 0))", nil)
+	defer filter.Close()
+	rs, err = ds.ExecuteSQL("SELECT name FROM l1", SpatialFilter(filter))
+	require.NoError(t, err)
+	cnt, _ := rs.FeatureCount()
+	assert.Equal(t, 1, cnt)
+	_ = rs.Close()
+}
+
 func TestExecuteSQL(t *testing.T) {
 	poly1Wkt := "POLYGON ((-72.573946 44.254648, -72.573946 44.255163, -72.573076 44.255163, -72.573076 44.254648, -72.573946 44.254648))"
 	poly2Wkt := "POLYGON ((-72.576558 44.25799, -72.576558 44.258213, -72.576064 44.258213, -72.576064 44.25799, -72.576558 44.25799))"
@@ -2901,11 +5081,177 @@ func TestNewGeometry(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "POINT (30 10)", wkt)
 
-	_, err = (&Geometry{}).WKB()
-	assert.Error(t, err)
+	_, err = (&Geometry{}).WKB()
+	assert.Error(t, err)
+
+	_, err = (&Geometry{}).WKT()
+	assert.Error(t, err)
+}
+
+func TestGeometryWKBVariants(t *testing.T) {
+	gp, _ := NewGeometryFromWKT("POINT Z (30 10 5)", nil)
+	defer gp.Close()
+
+	iso, err := gp.WKB()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 0xe9, 0x03, 0, 0}, iso[0:5]) // 1001: ISO point Z
+
+	ext, err := gp.WKB(Variant(WkbExtended))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 1, 0, 0, 0x80}, ext[0:5]) // 0x80000001: extended point 25D
+
+	xdr, err := gp.WKB(ByteOrder(WkbXDR), Variant(WkbISO))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0x03, 0xe9}, xdr[0:5])
+	assert.Equal(t, len(iso), len(xdr))
+
+	for _, wkb := range [][]byte{iso, ext, xdr} {
+		g, err := NewGeometryFromWKB(wkb, nil)
+		assert.NoError(t, err)
+		wkt, _ := g.WKT()
+		assert.Equal(t, "POINT Z (30 10 5)", wkt)
+		g.Close()
+	}
+
+	ehc := eh()
+	_, err = (&Geometry{}).WKB(ByteOrder(WkbXDR), ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+}
+
+func TestFeatureGeometryWKB(t *testing.T) {
+	ds, _ := Open("testdata/test.geojson")
+	defer ds.Close()
+	l := ds.Layers()[0]
+	f := l.NextFeature()
+	defer f.Close()
+	wkb, err := f.GeometryWKB()
+	assert.NoError(t, err)
+	expected, _ := f.Geometry().WKB()
+	assert.Equal(t, expected, wkb)
+	ehc := eh()
+	_, err = f.GeometryWKB(ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+
+	mds, _ := CreateVector(Memory, "")
+	defer mds.Close()
+	ml, _ := mds.CreateLayer("nogeom", nil, GTNone)
+	nf, _ := ml.NewFeature(nil)
+	defer nf.Close()
+	wkb, err = nf.GeometryWKB()
+	assert.NoError(t, err)
+	assert.Nil(t, wkb)
+}
+
+func benchmarkFeatureWKB(b *testing.B, fn func(f *Feature) ([]byte, error)) {
+	ds, _ := Open("testdata/test.geojson")
+	defer ds.Close()
+	l := ds.Layers()[0]
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.ResetReading()
+		for f := l.NextFeature(); f != nil; f = l.NextFeature() {
+			if _, err := fn(f); err != nil {
+				b.Fatal(err)
+			}
+			f.Close()
+		}
+	}
+}
+
+func BenchmarkFeatureGeometryWKB(b *testing.B) {
+	benchmarkFeatureWKB(b, func(f *Feature) ([]byte, error) {
+		return f.GeometryWKB()
+	})
+}
+
+func BenchmarkFeatureGeometryThenWKB(b *testing.B) {
+	benchmarkFeatureWKB(b, func(f *Feature) ([]byte, error) {
+		return f.Geometry().WKB()
+	})
+}
+
+func TestReadContext(t *testing.T) {
+	ds, _ := Open("testdata/test.tif")
+	defer ds.Close()
+	rc := NewReadContext(ConfigOption("GDAL_CACHEMAX=64"))
+	defer rc.Close()
+
+	expected := make([]uint8, 300)
+	_ = ds.Read(0, 0, expected, 10, 10)
+	for i := 0; i < 3; i++ {
+		buf := make([]uint8, 300)
+		err := ds.Read(0, 0, buf, 10, 10, rc)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, buf)
+	}
+	bbuf := make([]uint8, 100)
+	err := ds.Bands()[0].Read(0, 0, bbuf, 10, 10, rc)
+	assert.NoError(t, err)
+	assert.Equal(t, expected[0], bbuf[0])
+
+	// errors do not leak into subsequent calls
+	err = ds.Bands()[0].Read(5, 5, bbuf, 10, 10, rc)
+	assert.Error(t, err)
+	ehc := eh()
+	err = ds.Read(5, 5, expected, 10, 10, rc, ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+	err = ds.Bands()[0].Read(0, 0, bbuf, 10, 10, rc)
+	assert.NoError(t, err)
+	err = ds.Read(0, 0, expected, 10, 10, rc, ConfigOption("GDAL_CACHEMAX=32"))
+	assert.NoError(t, err)
+
+	rc.Close()
+	rc.Close()
+	assert.Error(t, ds.Read(0, 0, expected, 10, 10, rc))
+	assert.Error(t, ds.Bands()[0].Read(0, 0, bbuf, 10, 10, rc))
+}
+
+// benchmarkRead compares tight read loops with and without a ReadContext. The malloc/free
+// calls saved by the ReadContext are C allocations which are not seen by the go allocation
+// counters, so they are reported separately as C-mallocs/op.
+func benchmarkRead(b *testing.B, opts ...BandIOOption) {
+	ds, _ := Open("testdata/test.tif")
+	defer ds.Close()
+	bnd := ds.Bands()[0]
+	buf := make([]uint8, 100)
+	b.ReportAllocs()
+	b.ResetTimer()
+	start := atomic.LoadUint64(&cgoContextMallocs)
+	for i := 0; i < b.N; i++ {
+		if err := bnd.Read(0, 0, buf, 10, 10, opts...); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(atomic.LoadUint64(&cgoContextMallocs)-start)/float64(b.N), "C-mallocs/op")
+}
+
+func BenchmarkBandRead(b *testing.B) {
+	benchmarkRead(b, ConfigOption("GDAL_CACHEMAX=64"))
+}
+
+func BenchmarkBandReadContext(b *testing.B) {
+	rc := NewReadContext(ConfigOption("GDAL_CACHEMAX=64"))
+	defer rc.Close()
+	benchmarkRead(b, rc)
+}
 
-	_, err = (&Geometry{}).WKT()
-	assert.Error(t, err)
+func TestReadContextMallocs(t *testing.T) {
+	ds, _ := Open("testdata/test.tif")
+	defer ds.Close()
+	bnd := ds.Bands()[0]
+	buf := make([]uint8, 100)
+	start := atomic.LoadUint64(&cgoContextMallocs)
+	_ = bnd.Read(0, 0, buf, 10, 10, ConfigOption("GDAL_CACHEMAX=64"))
+	assert.Equal(t, uint64(3), atomic.LoadUint64(&cgoContextMallocs)-start)
+
+	rc := NewReadContext(ConfigOption("GDAL_CACHEMAX=64"))
+	defer rc.Close()
+	start = atomic.LoadUint64(&cgoContextMallocs)
+	for i := 0; i < 10; i++ {
+		_ = bnd.Read(0, 0, buf, 10, 10, rc)
+	}
+	assert.Equal(t, uint64(0), atomic.LoadUint64(&cgoContextMallocs)-start)
 }
 
 func TestNewGeometryFromGeoJSON(t *testing.T) {
@@ -2992,6 +5338,234 @@ func TestGeometryUnion(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGeometryUnaryUnionPolygonize(t *testing.T) {
+	_, err := UnaryUnion(nil)
+	assert.Error(t, err)
+	_, err = UnaryUnion([]*Geometry{{}})
+	assert.Error(t, err)
+
+	geoms := make([]*Geometry, 0, 4)
+	for i := 0; i < 4; i++ {
+		g, _ := NewGeometryFromWKT(fmt.Sprintf("POLYGON((%d 0,%d 0,%d 1,%d 1,%d 0))", i, i+1, i+1, i, i), nil)
+		defer g.Close()
+		geoms = append(geoms, g)
+	}
+	ehc := eh()
+	u, err := UnaryUnion(geoms, ErrLogger(ehc.ErrorHandler))
+	if !CheckMinVersion(3, 7, 0) {
+		assert.Error(t, err)
+	} else {
+		assert.NoError(t, err)
+		assert.Equal(t, "POLYGON", u.Name())
+		assert.InDelta(t, 4.0, u.Area(), 1e-9)
+		assert.InDelta(t, 1.0, geoms[0].Area(), 1e-9)
+		u.Close()
+	}
+
+	_, err = (&Geometry{}).Polygonize()
+	assert.Error(t, err)
+
+	lines, _ := NewGeometryFromWKT("MULTILINESTRING((0 0,1 0),(1 0,1 1),(1 1,0 1),(0 1,0 0))", nil)
+	defer lines.Close()
+	p, err := lines.Polygonize(ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, p.GeometryCount())
+	assert.InDelta(t, 1.0, p.Area(), 1e-9)
+	p.Close()
+
+	pt, _ := NewGeometryFromWKT("POINT(0 0)", nil)
+	defer pt.Close()
+	_, err = pt.Polygonize()
+	assert.Error(t, err)
+}
+
+func TestGeometryConvexHullBoundary(t *testing.T) {
+	mp, _ := NewGeometryFromWKT("MULTIPOINT ((0 0),(2 0),(1 1),(2 2),(0 2))", nil)
+	defer mp.Close()
+
+	hull, err := mp.ConvexHull()
+	assert.NoError(t, err)
+	assert.Equal(t, GTPolygon, hull.Type())
+	assert.Equal(t, 4.0, hull.Area())
+
+	bnd, err := hull.Boundary()
+	assert.NoError(t, err)
+	assert.Equal(t, GTLineString, bnd.Type())
+	bnd.Close()
+	hull.Close()
+
+	_, err = (&Geometry{}).ConvexHull()
+	assert.Error(t, err)
+	ehc := eh()
+	_, err = (&Geometry{}).Boundary(ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+}
+
+func TestGeometryPoints(t *testing.T) {
+	ls, _ := NewGeometryFromWKT("LINESTRING (0 1,2 3,4 5)", nil)
+	defer ls.Close()
+	assert.Equal(t, 3, ls.PointCount())
+	x, y, z := ls.Point(1)
+	assert.Equal(t, [3]float64{2, 3, 0}, [3]float64{x, y, z})
+	assert.Equal(t, [][3]float64{{0, 1, 0}, {2, 3, 0}, {4, 5, 0}}, ls.Points())
+
+	ls3, _ := NewGeometryFromWKT("LINESTRING Z (0 1 2,3 4 5)", nil)
+	defer ls3.Close()
+	assert.Equal(t, [][3]float64{{0, 1, 2}, {3, 4, 5}}, ls3.Points())
+
+	pt, _ := NewGeometryFromWKT("POINT (7 8)", nil)
+	defer pt.Close()
+	assert.Equal(t, 1, pt.PointCount())
+	assert.Equal(t, [][3]float64{{7, 8, 0}}, pt.Points())
+
+	poly, _ := NewGeometryFromWKT("POLYGON ((0 0,1 0,1 1,0 0))", nil)
+	defer poly.Close()
+	assert.Equal(t, 0, poly.PointCount())
+	assert.Nil(t, poly.Points())
+	ring, _ := poly.SubGeometry(0)
+	assert.Equal(t, 4, ring.PointCount())
+	assert.Equal(t, [3]float64{1, 1, 0}, ring.Points()[2])
+}
+
+func TestNewGeometryBuilder(t *testing.T) {
+	ring := NewGeometry(GTLinearRing)
+	require.NotNil(t, ring)
+	ring.AddPoint2D(0, 0)
+	ring.AddPoint2D(2, 0)
+	ring.AddPoint2D(2, 2)
+	ring.AddPoint2D(0, 2)
+	poly := NewGeometry(GTPolygon)
+	defer poly.Close()
+	assert.True(t, poly.Empty())
+	assert.NoError(t, poly.AddGeometry(ring))
+	ring.Close()
+	poly.CloseRings()
+	wkt, _ := poly.WKT()
+	assert.Equal(t, "POLYGON ((0 0,2 0,2 2,0 2,0 0))", wkt)
+	assert.Equal(t, 4.0, poly.Area())
+
+	ls := NewGeometry(GTLineString)
+	defer ls.Close()
+	ls.AddPoint(1, 2, 3)
+	ls.AddPoint(4, 5, 6)
+	assert.Equal(t, [][3]float64{{1, 2, 3}, {4, 5, 6}}, ls.Points())
+
+	pt := NewGeometry(GTPoint)
+	defer pt.Close()
+	pt.AddPoint2D(3, 4)
+	wkt, _ = pt.WKT()
+	assert.Equal(t, "POINT (3 4)", wkt)
+}
+
+func TestGeometryAreaInCRS(t *testing.T) {
+	sr4326, _ := NewSpatialRefFromEPSG(4326)
+	defer sr4326.Close()
+	ea, err := NewEqualAreaSpatialRef()
+	require.NoError(t, err)
+	defer ea.Close()
+
+	g, _ := NewGeometryFromWKT("POLYGON((2 45,3 45,3 46,2 46,2 45))", sr4326)
+	defer g.Close()
+	ehc := eh()
+	area, err := g.AreaInCRS(ea, ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+
+	// area of a lat/lon cell on a sphere of the WGS84 authalic radius
+	const r = 6371007.181
+	rad := math.Pi / 180
+	geodesic := r * r * rad * (math.Sin(46*rad) - math.Sin(45*rad))
+	assert.InEpsilon(t, geodesic, area, 0.005)
+
+	// the original geometry is not modified
+	assert.Equal(t, 1.0, g.Area())
+
+	nosr, _ := NewGeometryFromWKT("POLYGON((2 45,3 45,3 46,2 46,2 45))", nil)
+	defer nosr.Close()
+	_, err = nosr.AreaInCRS(ea)
+	assert.Error(t, err)
+}
+
+func TestGeometryPointOnSurface(t *testing.T) {
+	//U shaped polygon whose centroid falls outside the surface
+	u, _ := NewGeometryFromWKT("POLYGON ((0 0,3 0,3 3,2 3,2 1,1 1,1 3,0 3,0 0))", nil)
+	defer u.Close()
+	pt, err := u.PointOnSurface()
+	assert.NoError(t, err)
+	assert.Equal(t, GTPoint, pt.Type())
+	assert.True(t, u.Contains(pt))
+	pt.Close()
+
+	_, err = (&Geometry{}).PointOnSurface()
+	assert.Error(t, err)
+	ehc := eh()
+	_, err = (&Geometry{}).PointOnSurface(ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+
+	ring, _ := NewGeometryFromWKT("LINESTRING (0 0,1 0,1 1,0 1,0 0)", nil)
+	defer ring.Close()
+	assert.True(t, ring.IsRing())
+	assert.True(t, ring.IsSimple())
+	open, _ := NewGeometryFromWKT("LINESTRING (0 0,1 0,1 1)", nil)
+	defer open.Close()
+	assert.False(t, open.IsRing())
+	bowtie, _ := NewGeometryFromWKT("LINESTRING (0 0,1 1,1 0,0 1,0 0)", nil)
+	defer bowtie.Close()
+	assert.False(t, bowtie.IsSimple())
+	assert.False(t, bowtie.IsRing())
+}
+
+func TestGeometryMakeValid(t *testing.T) {
+	bowtie, _ := NewGeometryFromWKT("POLYGON ((0 0,2 2,2 0,0 2,0 0))", nil)
+	defer bowtie.Close()
+	require.False(t, bowtie.Valid())
+
+	ehc := eh()
+	valid, err := bowtie.MakeValid(ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	assert.True(t, valid.Valid())
+	assert.Equal(t, GTMultiPolygon, valid.Type())
+	assert.InDelta(t, 2.0, valid.Area(), 1e-9)
+	valid.Close()
+
+	if CheckMinVersion(3, 4, 0) {
+		valid, err = bowtie.MakeValid(MakeValidLinework)
+		assert.NoError(t, err)
+		assert.True(t, valid.Valid())
+		valid.Close()
+	} else {
+		_, err = bowtie.MakeValid(MakeValidStructure, KeepCollapsed())
+		assert.Error(t, err)
+	}
+
+	_, err = (&Geometry{}).MakeValid()
+	assert.Error(t, err)
+}
+
+func TestGeometrySegmentizeSetPrecision(t *testing.T) {
+	ls, _ := NewGeometryFromWKT("LINESTRING (0 0,10 0)", nil)
+	defer ls.Close()
+	ehc := eh()
+	require.NoError(t, ls.Segmentize(2.5, ErrLogger(ehc.ErrorHandler)))
+	wkt, _ := ls.WKT()
+	assert.Equal(t, "LINESTRING (0 0,2.5 0,5 0,7.5 0,10 0)", wkt)
+	assert.Error(t, ls.Segmentize(0))
+	assert.Error(t, (&Geometry{}).Segmentize(1))
+
+	ls2, _ := NewGeometryFromWKT("LINESTRING (0.1 0.2,0.9 1.1,1.05 0.95,3.2 2.8)", nil)
+	defer ls2.Close()
+	snapped, err := ls2.SetPrecision(1, 0)
+	if !CheckMinVersion(3, 9, 0) {
+		assert.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+	defer snapped.Close()
+	wkt, _ = snapped.WKT()
+	assert.Equal(t, "LINESTRING (0 0,1 1,3 3)", wkt)
+	_, err = (&Geometry{}).SetPrecision(1, PrecisionNoTopo|PrecisionKeepCollapsed)
+	assert.Error(t, err)
+}
+
 func TestGeometryIntersects(t *testing.T) {
 	_, err := (&Geometry{}).Intersects(&Geometry{})
 	assert.Error(t, err)
@@ -3036,6 +5610,63 @@ func TestGeometryIntersects(t *testing.T) {
 	assert.False(t, ret)
 }
 
+func TestGeometryPredicates(t *testing.T) {
+	sq, _ := NewGeometryFromWKT("POLYGON((0 0,2 0,2 2,0 2,0 0))", nil)
+	adj, _ := NewGeometryFromWKT("POLYGON((2 0,3 0,3 2,2 2,2 0))", nil)
+	ovl, _ := NewGeometryFromWKT("POLYGON((1 1,3 1,3 3,1 3,1 1))", nil)
+	in, _ := NewGeometryFromWKT("POLYGON((0.5 0.5,1 0.5,1 1,0.5 1,0.5 0.5))", nil)
+	far, _ := NewGeometryFromWKT("POLYGON((5 5,6 5,6 6,5 6,5 5))", nil)
+	line, _ := NewGeometryFromWKT("LINESTRING(-1 1,3 1)", nil)
+	rot, _ := NewGeometryFromWKT("POLYGON((2 0,2 2,0 2,0 0,2 0))", nil)
+	same, _ := NewGeometryFromWKT("POLYGON((0 0,2 0,2 2,0 2,0 0))", nil)
+
+	ret, err := sq.Touches(adj)
+	assert.NoError(t, err)
+	assert.True(t, ret)
+	ret, _ = sq.Touches(ovl)
+	assert.False(t, ret)
+
+	ret, err = line.Crosses(sq)
+	assert.NoError(t, err)
+	assert.True(t, ret)
+	ret, _ = in.Crosses(sq)
+	assert.False(t, ret)
+
+	ret, err = in.Within(sq)
+	assert.NoError(t, err)
+	assert.True(t, ret)
+	ret, _ = sq.Within(in)
+	assert.False(t, ret)
+
+	ret, err = sq.Overlaps(ovl)
+	assert.NoError(t, err)
+	assert.True(t, ret)
+	ret, _ = sq.Overlaps(in)
+	assert.False(t, ret)
+
+	ret, err = sq.Disjoint(far)
+	assert.NoError(t, err)
+	assert.True(t, ret)
+	ret, _ = sq.Disjoint(adj)
+	assert.False(t, ret)
+
+	ehc := eh()
+	ret, err = sq.Equals(same, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.True(t, ret)
+	ret, _ = sq.Equals(rot)
+	assert.False(t, ret, "vertex order differs")
+	ret, _ = sq.Equals(ovl)
+	assert.False(t, ret)
+
+	_, err = sq.Touches(nil)
+	assert.Error(t, err)
+	_, err = (&Geometry{}).Within(sq)
+	assert.Error(t, err)
+	_, err = sq.Equals(&Geometry{}, ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+}
+
 func TestGeomToGeoJSON(t *testing.T) {
 	sr, _ := NewSpatialRefFromEPSG(4326)
 	g, _ := NewGeometryFromWKT("POINT (10.123456789 10)", sr)
@@ -3059,67 +5690,434 @@ func TestGeometryToGML(t *testing.T) {
 	sr, _ := NewSpatialRefFromEPSG(4326)
 	defer sr.Close()
 
-	polyStr := "POLYGON ((0 0,2 0,2 2,0 2,0 0))"
-	polyGeom, _ := NewGeometryFromWKT(polyStr, sr)
+	polyStr := "POLYGON ((0 0,2 0,2 2,0 2,0 0))"
+	polyGeom, _ := NewGeometryFromWKT(polyStr, sr)
+
+	gml, err := polyGeom.GML()
+	assert.NoError(t, err)
+	assert.Equal(t, gml, `<gml:Polygon srsName="EPSG:4326"><gml:outerBoundaryIs><gml:LinearRing><gml:coordinates>0,0 2,0 2,2 0,2 0,0</gml:coordinates></gml:LinearRing></gml:outerBoundaryIs></gml:Polygon>`)
+
+	gml, err = polyGeom.GML(CreationOption("FORMAT=GML3", "SRSNAME_FORMAT=OGC_URN"))
+	assert.NoError(t, err)
+	assert.Equal(t, gml, `<gml:Polygon srsName="urn:ogc:def:crs:EPSG::4326"><gml:exterior><gml:LinearRing><gml:posList>0 0 0 2 2 2 2 0 0 0</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon>`)
+
+	ehc := eh()
+	_, err = polyGeom.GML(CreationOption("FORMAT=GML3", "SRSNAME_FORMAT=fake"), ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+}
+
+func TestMultiPolygonGeometry(t *testing.T) {
+	sr, _ := NewSpatialRefFromEPSG(4326)
+	defer sr.Close()
+
+	multiPolyStr := "MULTIPOLYGON(((1 1,5 1,5 5,1 5,1 1),(2 2,2 3,3 3,3 2,2 2)),((6 3,9 2,9 4,6 3)))"
+	multiPolyGeom, _ := NewGeometryFromWKT(multiPolyStr, sr)
+
+	assert.Equal(t, multiPolyGeom.Area(), 18.0)
+	assert.Equal(t, multiPolyGeom.GeometryCount(), 2)
+	assert.Equal(t, multiPolyGeom.Name(), "MULTIPOLYGON")
+	assert.Equal(t, multiPolyGeom.Type(), GTMultiPolygon)
+
+	subGeom, err := multiPolyGeom.SubGeometry(0)
+	assert.NoError(t, err)
+	wkt, _ := subGeom.WKT()
+	assert.Equal(t, wkt, "POLYGON ((1 1,5 1,5 5,1 5,1 1),(2 2,2 3,3 3,3 2,2 2))")
+	subGeom, err = multiPolyGeom.SubGeometry(1)
+	assert.NoError(t, err)
+	wkt, _ = subGeom.WKT()
+	assert.Equal(t, wkt, "POLYGON ((6 3,9 2,9 4,6 3))")
+	ehc := eh()
+	_, err = multiPolyGeom.SubGeometry(2, ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+
+	polyGeom := multiPolyGeom.ForceToPolygon()
+	wkt, _ = polyGeom.WKT()
+	assert.Equal(t, wkt, "POLYGON ((1 1,5 1,5 5,1 5,1 1),(2 2,2 3,3 3,3 2,2 2),(6 3,9 2,9 4,6 3))")
+	assert.False(t, polyGeom.Valid())
+
+	polyStr := "POLYGON((1 1,5 1,5 5,1 5,1 1))"
+	polyGeom, _ = NewGeometryFromWKT(polyStr, sr)
+	multiPolyGeom = polyGeom.ForceToMultiPolygon()
+	wkt, _ = multiPolyGeom.WKT()
+	assert.Equal(t, wkt, "MULTIPOLYGON (((1 1,5 1,5 5,1 5,1 1)))")
+	assert.True(t, polyGeom.Valid())
+
+	multiPolyStr = "MULTIPOLYGON (((1 1,5 1,5 5,1 5,1 1)))"
+	multiPolyGeom, _ = NewGeometryFromWKT(multiPolyStr, sr)
+	polyStr = "POLYGON((6 3,9 2,9 4,6 3))"
+	polyGeom, _ = NewGeometryFromWKT(polyStr, sr)
+	assert.False(t, multiPolyGeom.Contains(polyGeom))
+	err = multiPolyGeom.AddGeometry(polyGeom, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	wkt, _ = multiPolyGeom.WKT()
+	assert.Equal(t, wkt, "MULTIPOLYGON (((1 1,5 1,5 5,1 5,1 1)),((6 3,9 2,9 4,6 3)))")
+}
+
+func TestSubGeometryOwnership(t *testing.T) {
+	mls, _ := NewGeometryFromWKT("MULTILINESTRING ((0 0,1 1),(2 2,3 3))", nil)
+	defer mls.Close()
+
+	ref, err := mls.SubGeometry(0)
+	require.NoError(t, err)
+	ref.AddPoint2D(4, 4)
+	wkt, _ := mls.WKT()
+	assert.Equal(t, "MULTILINESTRING ((0 0,1 1,4 4),(2 2,3 3))", wkt)
+	//closing a reference must not free the parent's memory
+	ref.Close()
+	ref.Close()
+	ref, _ = mls.SubGeometry(0)
+	assert.Equal(t, 3, ref.PointCount())
+
+	cl, err := mls.SubGeometryClone(1)
+	require.NoError(t, err)
+	cl.AddPoint2D(5, 5)
+	wkt, _ = cl.WKT()
+	assert.Equal(t, "LINESTRING (2 2,3 3,5 5)", wkt)
+	wkt, _ = mls.WKT()
+	assert.Equal(t, "MULTILINESTRING ((0 0,1 1,4 4),(2 2,3 3))", wkt)
+	cl.Close()
+
+	_, err = mls.SubGeometryClone(2)
+	assert.Error(t, err)
+	ehc := eh()
+	_, err = mls.SubGeometryClone(2, ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+}
+
+func TestCreateLayerOptions(t *testing.T) {
+	tmpname := tempfile() + ".gpkg"
+	defer os.Remove(tmpname)
+	ds, err := CreateVector(GeoPackage, tmpname)
+	require.NoError(t, err)
+	defer ds.Close()
+	ehc := eh()
+	_, err = ds.CreateLayer("l1", nil, GTPoint, GeometryColumn("the_geom"), FIDColumn("my_id"),
+		LayerCreationOption("SPATIAL_INDEX=NO"), NewFieldDefinition("name", FTString), ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+
+	rs, err := ds.ExecuteSQL("SELECT column_name FROM gpkg_geometry_columns WHERE table_name='l1'")
+	require.NoError(t, err)
+	f := rs.NextFeature()
+	require.NotNil(t, f)
+	assert.Equal(t, "the_geom", f.Fields()["column_name"].String())
+	f.Close()
+	_ = rs.Close()
+
+	rs, err = ds.ExecuteSQL("SELECT my_id, name FROM l1")
+	require.NoError(t, err)
+	_ = rs.Close()
+
+	rs, err = ds.ExecuteSQL("SELECT COUNT(*) AS cnt FROM gpkg_extensions WHERE table_name='l1' AND extension_name='gpkg_rtree_index'")
+	require.NoError(t, err)
+	f = rs.NextFeature()
+	require.NotNil(t, f)
+	assert.Equal(t, int64(0), f.Fields()["cnt"].Int())
+	f.Close()
+	_ = rs.Close()
+}
+
+func TestAlterFieldDefn(t *testing.T) {
+	tmpname := tempfile() + ".gpkg"
+	defer os.Remove(tmpname)
+	ds, err := CreateVector(GeoPackage, tmpname)
+	require.NoError(t, err)
+	lyr, err := ds.CreateLayer("l1", nil, GTPoint, NewFieldDefinition("name", FTString))
+	require.NoError(t, err)
+	pnt, _ := NewGeometryFromWKT("POINT (1 1)", nil)
+	defer pnt.Close()
+	nf, err := lyr.NewFeature(pnt)
+	require.NoError(t, err)
+	_ = nf.SetFieldValue(nf.Fields()["name"], "foo")
+	_ = lyr.UpdateFeature(nf)
+	nf.Close()
+
+	ehc := eh()
+	err = lyr.AlterFieldDefn(0, NewFieldDefinition("label", FTString), AlterFieldName, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	err = lyr.AlterFieldDefn(10, NewFieldDefinition("label", FTString), AlterFieldName)
+	assert.Error(t, err)
+	_ = ds.Close()
+
+	ds, err = Open(tmpname, VectorOnly())
+	require.NoError(t, err)
+	defer ds.Close()
+	lyr = ds.Layers()[0]
+	f := lyr.NextFeature()
+	require.NotNil(t, f)
+	defer f.Close()
+	attrs := f.Fields()
+	_, ok := attrs["name"]
+	assert.False(t, ok)
+	assert.Equal(t, "foo", attrs["label"].String())
+}
+
+func TestLayerExtent(t *testing.T) {
+	tmpdir, _ := ioutil.TempDir("", "")
+	defer os.RemoveAll(tmpdir)
+	ds, err := CreateVector(Shapefile, filepath.Join(tmpdir, "pts.shp"))
+	require.NoError(t, err)
+	defer ds.Close()
+	lyr, err := ds.CreateLayer("pts", nil, GTPoint25D)
+	require.NoError(t, err)
+	for _, wkt := range []string{"POINT Z (1 2 3)", "POINT Z (4 5 -6)"} {
+		g, _ := NewGeometryFromWKT(wkt, nil)
+		f, err := lyr.NewFeature(g)
+		require.NoError(t, err)
+		f.Close()
+		g.Close()
+	}
+
+	bnds, err := lyr.Bounds(ForceExtent(false))
+	assert.NoError(t, err)
+	assert.Equal(t, [4]float64{1, 2, 4, 5}, bnds)
+
+	ehc := eh()
+	ext, err := lyr.Extent3D(ErrLogger(ehc.ErrorHandler))
+	if CheckMinVersion(3, 9, 0) {
+		assert.NoError(t, err)
+		assert.Equal(t, [6]float64{1, 2, -6, 4, 5, 3}, ext)
+	} else {
+		assert.Error(t, err)
+	}
+	_, err = Layer{}.Extent3D(ForceExtent(true))
+	assert.Error(t, err)
+}
+
+func TestFeatureSetFieldByIndex(t *testing.T) {
+	ds, _ := CreateVector(Memory, "")
+	defer ds.Close()
+	lyr, _ := ds.CreateLayer("l", nil, GTNone,
+		NewFieldDefinition("i", FTInt),
+		NewFieldDefinition("r", FTReal),
+		NewFieldDefinition("s", FTString),
+	)
+	f, err := lyr.NewFeature(nil)
+	require.NoError(t, err)
+	defer f.Close()
+
+	assert.NoError(t, f.SetFieldInteger(0, 42))
+	assert.NoError(t, f.SetFieldDouble(1, 1.5))
+	ehc := eh()
+	assert.NoError(t, f.SetFieldString(2, "foo", ErrLogger(ehc.ErrorHandler)))
+	attrs := f.Fields()
+	assert.Equal(t, int64(42), attrs["i"].Int())
+	assert.Equal(t, 1.5, attrs["r"].Float())
+	assert.Equal(t, "foo", attrs["s"].String())
+
+	// out of int32 range values are not truncated
+	assert.Error(t, f.SetFieldInteger(0, 1<<40))
+	assert.NoError(t, f.SetFieldInteger(1, 1<<40))
+	assert.Equal(t, float64(1<<40), f.Fields()["r"].Float())
+
+	assert.NoError(t, f.SetFieldNull(1))
+	assert.True(t, f.IsFieldSet(1))
+	assert.True(t, f.IsFieldNull(1))
+	assert.NoError(t, f.UnsetField(2))
+	assert.False(t, f.IsFieldSet(2))
+	assert.False(t, f.IsFieldNull(2))
+	assert.True(t, f.IsFieldSet(0))
+	assert.False(t, f.IsFieldNull(0))
+
+	assert.Error(t, f.SetFieldInteger(3, 1))
+	assert.Error(t, f.SetFieldDouble(-1, 1))
+	assert.Error(t, f.SetFieldString(3, ""))
+	assert.Error(t, f.SetFieldNull(3))
+	assert.Error(t, f.UnsetField(3))
+}
+
+func TestFeatureSetFrom(t *testing.T) {
+	ds, _ := CreateVector(Memory, "")
+	defer ds.Close()
+	src, _ := ds.CreateLayer("src", nil, GTPoint,
+		NewFieldDefinition("name", FTString),
+		NewFieldDefinition("extra", FTString),
+		NewFieldDefinition("pop", FTInt),
+	)
+	dst, _ := ds.CreateLayer("dst", nil, GTPoint,
+		NewFieldDefinition("pop", FTReal),
+		NewFieldDefinition("name", FTString),
+	)
+	pnt, _ := NewGeometryFromWKT("POINT (1 2)", nil)
+	defer pnt.Close()
+	sf, _ := src.NewFeature(pnt)
+	defer sf.Close()
+	_ = sf.SetFieldString(0, "paris")
+	_ = sf.SetFieldString(1, "ignored")
+	_ = sf.SetFieldInteger(2, 2000000)
+
+	df, _ := dst.NewFeature(nil)
+	defer df.Close()
+	ehc := eh()
+	err := df.SetFrom(sf, true, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	attrs := df.Fields()
+	assert.Len(t, attrs, 2)
+	assert.Equal(t, "paris", attrs["name"].String())
+	assert.Equal(t, 2000000.0, attrs["pop"].Float())
+	wkt, _ := df.Geometry().WKT()
+	assert.Equal(t, "POINT (1 2)", wkt)
+
+	assert.Error(t, df.SetFrom(nil, true))
+	assert.Error(t, df.SetFrom(&Feature{}, false))
+}
+
+func TestCreateFeatureBatch(t *testing.T) {
+	mds, _ := CreateVector(Memory, "")
+	defer mds.Close()
+	mlyr, _ := mds.CreateLayer("m", nil, GTPoint, NewFieldDefinition("v", FTInt))
+	pnt, _ := NewGeometryFromWKT("POINT (1 1)", nil)
+	defer pnt.Close()
+	feats := make([]*Feature, 50)
+	for i := range feats {
+		feats[i], _ = mlyr.NewFeature(pnt)
+		defer feats[i].Close()
+		_ = feats[i].SetFieldInteger(0, i)
+		feats[i].SetFID(-1)
+	}
+
+	tmpname := tempfile() + ".gpkg"
+	defer os.Remove(tmpname)
+	ds, err := CreateVector(GeoPackage, tmpname)
+	require.NoError(t, err)
+	defer ds.Close()
+	lyr, err := ds.CreateLayer("l", nil, GTPoint, NewFieldDefinition("v", FTInt))
+	require.NoError(t, err)
+
+	ehc := eh()
+	err = lyr.CreateFeatureBatch(feats, ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	cnt, _ := lyr.FeatureCount()
+	assert.Equal(t, 50, cnt)
+
+	// duplicate fid: nothing must be written
+	feats[0].SetFID(1000)
+	feats[1].SetFID(1000)
+	err = lyr.CreateFeatureBatch(feats[:3])
+	assert.Error(t, err)
+	cnt, _ = lyr.FeatureCount()
+	assert.Equal(t, 50, cnt)
+
+	err = lyr.CreateFeatureBatch([]*Feature{nil})
+	assert.Error(t, err)
 
-	gml, err := polyGeom.GML()
-	assert.NoError(t, err)
-	assert.Equal(t, gml, `<gml:Polygon srsName="EPSG:4326"><gml:outerBoundaryIs><gml:LinearRing><gml:coordinates>0,0 2,0 2,2 0,2 0,0</gml:coordinates></gml:LinearRing></gml:outerBoundaryIs></gml:Polygon>`)
+	// memory layers do not support transactions
+	err = mlyr.CreateFeatureBatch(feats[2:3])
+	assert.Error(t, err)
+}
 
-	gml, err = polyGeom.GML(CreationOption("FORMAT=GML3", "SRSNAME_FORMAT=OGC_URN"))
-	assert.NoError(t, err)
-	assert.Equal(t, gml, `<gml:Polygon srsName="urn:ogc:def:crs:EPSG::4326"><gml:exterior><gml:LinearRing><gml:posList>0 0 0 2 2 2 2 0 0 0</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon>`)
+func TestRenameLayer(t *testing.T) {
+	tmpname := tempfile() + ".gpkg"
+	defer os.Remove(tmpname)
+	ds, err := CreateVector(GeoPackage, tmpname)
+	require.NoError(t, err)
+	defer ds.Close()
+	_, err = ds.CreateLayer("roads", nil, GTLineString)
+	require.NoError(t, err)
 
 	ehc := eh()
-	_, err = polyGeom.GML(CreationOption("FORMAT=GML3", "SRSNAME_FORMAT=fake"), ErrLogger(ehc.ErrorHandler))
-	assert.Error(t, err)
+	err = ds.RenameLayer("roads", "highways", ErrLogger(ehc.ErrorHandler))
+	if !CheckMinVersion(3, 5, 0) {
+		assert.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+	assert.NotNil(t, ds.LayerByName("highways"))
+	assert.Nil(t, ds.LayerByName("roads"))
+
+	assert.Error(t, ds.RenameLayer("roads", "other"))
+
+	mds, _ := Open("testdata/test.geojson", VectorOnly())
+	defer mds.Close()
+	assert.Error(t, mds.RenameLayer("test", "other"))
 }
 
-func TestMultiPolygonGeometry(t *testing.T) {
-	sr, _ := NewSpatialRefFromEPSG(4326)
-	defer sr.Close()
+func TestFieldDefinitionOptions(t *testing.T) {
+	tmpname := tempfile() + ".gpkg"
+	defer os.Remove(tmpname)
+	ds, err := CreateVector(GeoPackage, tmpname)
+	require.NoError(t, err)
+	lyr, err := ds.CreateLayer("l1", nil, GTPoint,
+		NewFieldDefinition("label", FTString, FieldWidth(12)),
+		NewFieldDefinition("val", FTReal, FieldWidth(10), FieldPrecision(3)),
+		NewFieldDefinition("def", FTString, FieldDefault("'foo'")),
+		NewFieldDefinition("req", FTInt, FieldNullable(false), FieldDefault("3")),
+	)
+	require.NoError(t, err)
+	pnt, _ := NewGeometryFromWKT("POINT (1 1)", nil)
+	defer pnt.Close()
+	nf, err := lyr.NewFeature(pnt)
+	require.NoError(t, err)
+	nf.Close()
 
-	multiPolyStr := "MULTIPOLYGON(((1 1,5 1,5 5,1 5,1 1),(2 2,2 3,3 3,3 2,2 2)),((6 3,9 2,9 4,6 3)))"
-	multiPolyGeom, _ := NewGeometryFromWKT(multiPolyStr, sr)
+	rs, err := ds.ExecuteSQL("SELECT sql FROM sqlite_master WHERE name='l1'")
+	require.NoError(t, err)
+	f := rs.NextFeature()
+	require.NotNil(t, f)
+	ddl := f.Fields()["sql"].String()
+	f.Close()
+	_ = rs.Close()
+	assert.Contains(t, ddl, "TEXT(12)")
+	assert.Contains(t, ddl, "NOT NULL")
+	_ = ds.Close()
 
-	assert.Equal(t, multiPolyGeom.Area(), 18.0)
-	assert.Equal(t, multiPolyGeom.GeometryCount(), 2)
-	assert.Equal(t, multiPolyGeom.Name(), "MULTIPOLYGON")
-	assert.Equal(t, multiPolyGeom.Type(), GTMultiPolygon)
+	ds, _ = Open(tmpname, VectorOnly())
+	defer ds.Close()
+	f = ds.Layers()[0].NextFeature()
+	require.NotNil(t, f)
+	defer f.Close()
+	attrs := f.Fields()
+	assert.Equal(t, "foo", attrs["def"].String())
+	assert.Equal(t, int64(3), attrs["req"].Int())
+}
 
-	subGeom, err := multiPolyGeom.SubGeometry(0)
-	assert.NoError(t, err)
-	wkt, _ := subGeom.WKT()
-	assert.Equal(t, wkt, "POLYGON ((1 1,5 1,5 5,1 5,1 1),(2 2,2 3,3 3,3 2,2 2))")
-	subGeom, err = multiPolyGeom.SubGeometry(1)
+func TestCreateDeleteField(t *testing.T) {
+	tmpname := tempfile() + ".gpkg"
+	defer os.Remove(tmpname)
+	ds, err := CreateVector(GeoPackage, tmpname)
+	require.NoError(t, err)
+	lyr, err := ds.CreateLayer("l1", nil, GTPoint, NewFieldDefinition("name", FTString))
+	require.NoError(t, err)
+	pnt, _ := NewGeometryFromWKT("POINT (1 1)", nil)
+	defer pnt.Close()
+	nf, err := lyr.NewFeature(pnt)
+	require.NoError(t, err)
+	nf.Close()
+	_ = ds.Close()
+
+	ds, err = Open(tmpname, VectorOnly(), Update())
+	require.NoError(t, err)
+	lyr = ds.Layers()[0]
+	assert.Equal(t, 0, lyr.FieldIndex("name"))
+	assert.Equal(t, -1, lyr.FieldIndex("area"))
+	err = lyr.CreateField(NewFieldDefinition("area", FTReal))
 	assert.NoError(t, err)
-	wkt, _ = subGeom.WKT()
-	assert.Equal(t, wkt, "POLYGON ((6 3,9 2,9 4,6 3))")
+	assert.Equal(t, 1, lyr.FieldIndex("area"))
+
 	ehc := eh()
-	_, err = multiPolyGeom.SubGeometry(2, ErrLogger(ehc.ErrorHandler))
+	err = lyr.DeleteField(lyr.FieldIndex("name"), ErrLogger(ehc.ErrorHandler))
+	assert.NoError(t, err)
+	assert.Equal(t, -1, lyr.FieldIndex("name"))
+	assert.Equal(t, 0, lyr.FieldIndex("area"))
+	err = lyr.DeleteField(10)
 	assert.Error(t, err)
+	_ = ds.Close()
 
-	polyGeom := multiPolyGeom.ForceToPolygon()
-	wkt, _ = polyGeom.WKT()
-	assert.Equal(t, wkt, "POLYGON ((1 1,5 1,5 5,1 5,1 1),(2 2,2 3,3 3,3 2,2 2),(6 3,9 2,9 4,6 3))")
-	assert.False(t, polyGeom.Valid())
-
-	polyStr := "POLYGON((1 1,5 1,5 5,1 5,1 1))"
-	polyGeom, _ = NewGeometryFromWKT(polyStr, sr)
-	multiPolyGeom = polyGeom.ForceToMultiPolygon()
-	wkt, _ = multiPolyGeom.WKT()
-	assert.Equal(t, wkt, "MULTIPOLYGON (((1 1,5 1,5 5,1 5,1 1)))")
-	assert.True(t, polyGeom.Valid())
+	ds, _ = Open(tmpname, VectorOnly())
+	defer ds.Close()
+	lyr = ds.Layers()[0]
+	f := lyr.NextFeature()
+	require.NotNil(t, f)
+	defer f.Close()
+	attrs := f.Fields()
+	assert.Len(t, attrs, 1)
+	_, ok := attrs["area"]
+	assert.True(t, ok)
 
-	multiPolyStr = "MULTIPOLYGON (((1 1,5 1,5 5,1 5,1 1)))"
-	multiPolyGeom, _ = NewGeometryFromWKT(multiPolyStr, sr)
-	polyStr = "POLYGON((6 3,9 2,9 4,6 3))"
-	polyGeom, _ = NewGeometryFromWKT(polyStr, sr)
-	assert.False(t, multiPolyGeom.Contains(polyGeom))
-	err = multiPolyGeom.AddGeometry(polyGeom, ErrLogger(ehc.ErrorHandler))
-	assert.NoError(t, err)
-	wkt, _ = multiPolyGeom.WKT()
-	assert.Equal(t, wkt, "MULTIPOLYGON (((1 1,5 1,5 5,1 5,1 1)),((6 3,9 2,9 4,6 3)))")
+	err = lyr.CreateField(NewFieldDefinition("ro", FTInt))
+	assert.Error(t, err)
+	ehc = eh()
+	err = lyr.CreateField(NewFieldDefinition("ro", FTInt), ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
 }
 
 func TestFeatureAttributes(t *testing.T) {
@@ -3387,6 +6385,113 @@ func TestVSIFile(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestVSIFileWrite(t *testing.T) {
+	fname := "/vsimem/vsifilewrite.txt"
+	defer func() { _ = VSIUnlink(fname) }()
+
+	vf, err := VSIOpen(fname, VSIOpenMode("w"))
+	require.NoError(t, err)
+	n, err := vf.Write([]byte("hello world"))
+	assert.NoError(t, err)
+	assert.Equal(t, 11, n)
+	assert.NoError(t, vf.Flush())
+	assert.NoError(t, vf.Close())
+
+	vf, err = VSIOpen(fname)
+	require.NoError(t, err)
+	assert.False(t, vf.EOF())
+	data, err := ioutil.ReadAll(vf)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+	assert.True(t, vf.EOF())
+	_, err = vf.Write([]byte("x"))
+	assert.Error(t, err)
+	assert.NoError(t, vf.Close())
+
+	_, err = VSIOpen("/vsimem/nonexistent/file.txt")
+	assert.Error(t, err)
+	assert.NotEmpty(t, err.Error())
+}
+
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	cw.writes++
+	return cw.Buffer.Write(p)
+}
+
+func TestVSIFileWriteTo(t *testing.T) {
+	fname := "/vsimem/vsifilewriteto.bin"
+	defer func() { _ = VSIUnlink(fname) }()
+	data := make([]byte, 3*1024*1024+123)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	vf, err := VSIOpen(fname, VSIOpenMode("w"))
+	require.NoError(t, err)
+	_, _ = vf.Write(data)
+	_ = vf.Close()
+
+	vf, _ = VSIOpen(fname)
+	cw := &countingWriter{}
+	n, err := io.Copy(cw, vf)
+	_ = vf.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), n)
+	assert.True(t, bytes.Equal(data, cw.Bytes()))
+	//io.Copy's default 32k buffer would have required ~100 writes
+	assert.Equal(t, 4, cw.writes)
+
+	vf, _ = VSIOpen(fname)
+	defer vf.Close()
+	buf := make([]byte, 100)
+	_, _ = vf.Read(buf)
+	bb := &bytes.Buffer{}
+	n, err = vf.WriteTo(bb)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)-100), n)
+	assert.True(t, bytes.Equal(data[100:], bb.Bytes()))
+}
+
+func TestCreateSOZip(t *testing.T) {
+	for name, content := range map[string]string{"/vsimem/sozip_a.txt": "aaaa", "/vsimem/sozip_b.txt": "bbbbbb"} {
+		vf, err := VSIOpen(name, VSIOpenMode("w"))
+		require.NoError(t, err)
+		_, _ = vf.Write([]byte(content))
+		_ = vf.Close()
+		defer func(name string) { _ = VSIUnlink(name) }(name)
+	}
+	zipname := "/vsimem/test_sozip.zip"
+	defer func() { _ = VSIUnlink(zipname) }()
+
+	err := CreateSOZip(zipname, map[string]string{
+		"a.txt":     "/vsimem/sozip_a.txt",
+		"dir/b.txt": "/vsimem/sozip_b.txt",
+	}, SOZipChunkSize(1024))
+	if !CheckMinVersion(3, 7, 0) {
+		assert.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+
+	vf, err := VSIOpen("/vsizip/" + zipname + "/dir/b.txt")
+	require.NoError(t, err)
+	data, _ := ioutil.ReadAll(vf)
+	assert.Equal(t, "bbbbbb", string(data))
+	_ = vf.Close()
+
+	err = CreateSOZip(zipname, nil)
+	assert.Error(t, err)
+	ehc := eh()
+	err = CreateSOZip("/vsimem/test_sozip_err.zip", map[string]string{"a.txt": "/vsimem/nonexistent"},
+		ErrLogger(ehc.ErrorHandler))
+	assert.Error(t, err)
+	_ = VSIUnlink("/vsimem/test_sozip_err.zip")
+}
+
 func TestUnexpectedVSIAccess(t *testing.T) {
 	vpa := vpHandler{datas: make(map[string]KeySizerReaderAt)}
 	tifdat, _ := ioutil.ReadFile("testdata/test.tif")
@@ -3716,6 +6821,34 @@ func TestBuildVRT(t *testing.T) {
 	assert.Contains(t, b.String(), "resampling=\"cubic\"")
 }
 
+func TestVirtualOverviews(t *testing.T) {
+	vrtname := "/vsimem/virtual_overviews.vrt"
+	defer func() { _ = VSIUnlink(vrtname) }()
+	ehc := eh()
+	ds, err := BuildVRT(vrtname, []string{"testdata/test.tif"}, nil, Resampling(Average), VirtualOverviews(2, 4),
+		ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	ovrs := ds.Bands()[0].Overviews()
+	require.Len(t, ovrs, 2)
+	assert.Equal(t, 5, ovrs[0].Structure().SizeX)
+	ds.Close()
+
+	vrtReader, err := VSIOpen(vrtname)
+	require.NoError(t, err)
+	b := bytes.Buffer{}
+	_, _ = io.Copy(&b, vrtReader)
+	vrtReader.Close()
+	assert.Contains(t, b.String(), "<OverviewList")
+	assert.Contains(t, b.String(), "2 4")
+
+	mem, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer mem.Close()
+	assert.Error(t, mem.AddVirtualOverviews(Average, []int{2}))
+	ds, _ = Open(vrtname)
+	defer ds.Close()
+	assert.Error(t, ds.AddVirtualOverviews(Average, nil))
+}
+
 func TestVSIGCS(t *testing.T) {
 	ctx := context.Background()
 	_, err := storage.NewClient(ctx)
@@ -3816,6 +6949,10 @@ func (e *errLogger) ErrorHandler(ec ErrorCategory, code int, message string) err
 func TestErrorHandling(t *testing.T) {
 	err := testErrorAndLogging()
 	assert.EqualError(t, err, "this is a warning message\nthis is a failure message")
+	var gerr GDALError
+	if assert.True(t, errors.As(err, &gerr)) {
+		assert.Equal(t, CE_Failure, gerr.Category)
+	}
 
 	el := errLogger{thresh: CE_Warning}
 	err = testErrorAndLogging(ErrLogger(el.ErrorHandler))
@@ -3845,6 +6982,202 @@ func TestErrorHandling(t *testing.T) {
 	assert.EqualError(t, err, "this is a failure message")
 }
 
+func TestDefaultErrorHandler(t *testing.T) {
+	var msgs []string
+	var codes []int
+	SetDefaultErrorHandler(func(ec ErrorCategory, code int, msg string) error {
+		msgs = append(msgs, msg)
+		codes = append(codes, code)
+		return nil
+	})
+	defer SetDefaultErrorHandler(nil)
+	defer SetLogLevel(CE_Debug)
+
+	testGlobalErrorHandling()
+	assert.Equal(t, []string{"this is a global warning message", "this is a global failure message"}, msgs)
+	assert.Equal(t, []int{CPLE_AppDefined, CPLE_FileIO}, codes)
+
+	msgs = nil
+	SetLogLevel(CE_Failure)
+	testGlobalErrorHandling()
+	assert.Equal(t, []string{"this is a global failure message"}, msgs)
+
+	// errors inside godal calls are still returned to the caller
+	msgs = nil
+	err := testErrorAndLogging()
+	assert.Error(t, err)
+	assert.Len(t, msgs, 0)
+
+	// the previous handler is returned and can be restored
+	var inner []string
+	prev := SetDefaultErrorHandler(func(ec ErrorCategory, code int, msg string) error {
+		inner = append(inner, msg)
+		return nil
+	})
+	require.NotNil(t, prev)
+	testGlobalErrorHandling()
+	assert.Len(t, inner, 1)
+	SetDefaultErrorHandler(prev)
+	msgs = nil
+	testGlobalErrorHandling()
+	assert.Len(t, msgs, 1)
+	assert.Len(t, inner, 1)
+
+	assert.NotNil(t, SetDefaultErrorHandler(nil))
+	msgs = nil
+	testGlobalErrorHandling()
+	assert.Len(t, msgs, 0)
+	assert.Nil(t, SetDefaultErrorHandler(nil))
+}
+
+func TestCaptureErrors(t *testing.T) {
+	err, logs := CaptureErrors(func() error {
+		// emitted outside of any godal call
+		testGlobalErrorHandling()
+		_, err := Open("testdata/nonexistent.tif")
+		return err
+	})
+	assert.Error(t, err)
+	require.Len(t, logs, 3)
+	assert.Equal(t, GDALError{CE_Warning, CPLE_AppDefined, "this is a global warning message"}, logs[0])
+	assert.Equal(t, GDALError{CE_Failure, CPLE_FileIO, "this is a global failure message"}, logs[1])
+	assert.Equal(t, CPLE_OpenFailed, logs[2].Code)
+
+	// nested captures
+	err, logs = CaptureErrors(func() error {
+		_, inner := CaptureErrors(func() error {
+			testGlobalErrorHandling()
+			return nil
+		})
+		assert.Len(t, inner, 2)
+		return testErrorAndLogging(SkipWarnings)
+	})
+	assert.EqualError(t, err, "this is a failure message")
+	assert.Len(t, logs, 2)
+
+	err, logs = CaptureErrors(func() error { return nil })
+	assert.NoError(t, err)
+	assert.Len(t, logs, 0)
+}
+
+func TestCaptureErrorsDeferredBlockRead(t *testing.T) {
+	drv, _ := getDriver("GTiff")
+	if !strings.Contains(drv.Metadata("DMD_CREATIONOPTIONLIST"), "<Value>JPEG</Value>") {
+		t.Skip("gdal built without jpeg support")
+	}
+	tmpname := tempfile()
+	defer os.Remove(tmpname)
+	ds, err := Create(GTiff, tmpname, 1, Byte, 64, 64,
+		CreationOption("TILED=YES", "BLOCKXSIZE=64", "BLOCKYSIZE=64", "COMPRESS=JPEG"))
+	require.NoError(t, err)
+	buf := make([]byte, 64*64)
+	for i := range buf {
+		buf[i] = byte((i * 7919) % 251)
+	}
+	require.NoError(t, ds.Write(0, 0, buf, 64, 64))
+	require.NoError(t, ds.Close())
+
+	//halve the TileByteCounts of the single jpeg tile, so that its decompression runs
+	//out of data and libjpeg emits a "premature end of file" warning
+	tif, err := ioutil.ReadFile(tmpname)
+	require.NoError(t, err)
+	require.Equal(t, "II*\x00", string(tif[:4]))
+	ifd := int(binary.LittleEndian.Uint32(tif[4:]))
+	patched := false
+	for i := 0; i < int(binary.LittleEndian.Uint16(tif[ifd:])); i++ {
+		entry := tif[ifd+2+12*i:]
+		if binary.LittleEndian.Uint16(entry) != 325 {
+			continue
+		}
+		switch binary.LittleEndian.Uint16(entry[2:]) {
+		case 3: //SHORT
+			binary.LittleEndian.PutUint16(entry[8:], binary.LittleEndian.Uint16(entry[8:])/2)
+		case 4: //LONG
+			binary.LittleEndian.PutUint32(entry[8:], binary.LittleEndian.Uint32(entry[8:])/2)
+		}
+		patched = true
+	}
+	require.True(t, patched)
+	require.NoError(t, ioutil.WriteFile(tmpname, tif, 0644))
+
+	//the block is only decompressed by the read, not when opening
+	ds, err = Open(tmpname)
+	require.NoError(t, err)
+	defer ds.Close()
+	_, logs := CaptureErrors(func() error {
+		//depending on the libjpeg version the truncated tile may also fail the read
+		_ = ds.Read(0, 0, buf, 64, 64, ErrLogger(func(ec ErrorCategory, code int, msg string) error {
+			return nil
+		}))
+		return nil
+	})
+	warned := false
+	for _, l := range logs {
+		if l.Category == CE_Warning {
+			warned = true
+		}
+	}
+	assert.True(t, warned, "deferred decompression warning not captured: %v", logs)
+}
+
+func TestWithConfig(t *testing.T) {
+	err := WithConfig(map[string]string{"GODAL_TEST_A": "a", "GODAL_TEST_B": "b"}, func() error {
+		v, ok := threadLocalConfigOption("GODAL_TEST_A")
+		assert.True(t, ok)
+		assert.Equal(t, "a", v)
+		return WithConfig(map[string]string{"GODAL_TEST_A": "aa"}, func() error {
+			v, _ := threadLocalConfigOption("GODAL_TEST_A")
+			assert.Equal(t, "aa", v)
+			v, _ = threadLocalConfigOption("GODAL_TEST_B")
+			assert.Equal(t, "b", v)
+			return fmt.Errorf("inner")
+		})
+	})
+	assert.EqualError(t, err, "inner")
+
+	err = WithConfig(map[string]string{"GODAL_TEST_A": "a"}, func() error {
+		v, _ := threadLocalConfigOption("GODAL_TEST_A")
+		assert.Equal(t, "a", v, "nested value must be restored")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	runtime.LockOSThread()
+	_, ok := threadLocalConfigOption("GODAL_TEST_A")
+	assert.False(t, ok)
+	PushConfigOption("GODAL_TEST_A", "pushed")
+	v, _ := threadLocalConfigOption("GODAL_TEST_A")
+	assert.Equal(t, "pushed", v)
+	PopConfigOption("GODAL_TEST_A")
+	_, ok = threadLocalConfigOption("GODAL_TEST_A")
+	assert.False(t, ok)
+	runtime.UnlockOSThread()
+}
+
+func TestGDALError(t *testing.T) {
+	var gerr GDALError
+	err := testErrorAndLogging()
+	require.True(t, errors.As(err, &gerr))
+	assert.Equal(t, CE_Failure, gerr.Category)
+	assert.Equal(t, CPLE_AppDefined, gerr.Code)
+
+	err = testErrorAndLogging(SkipWarnings)
+	gerr = GDALError{}
+	require.True(t, errors.As(err, &gerr))
+	assert.Equal(t, CE_Failure, gerr.Category)
+
+	_, err = Open("testdata/nonexistent.tif")
+	gerr = GDALError{}
+	require.True(t, errors.As(err, &gerr))
+	assert.Equal(t, CE_Failure, gerr.Category)
+	assert.Equal(t, CPLE_OpenFailed, gerr.Code)
+
+	// errors from custom handlers are returned as-is
+	el := errLogger{thresh: CE_Warning}
+	err = testErrorAndLogging(ErrLogger(el.ErrorHandler))
+	assert.False(t, errors.As(err, &gerr))
+}
+
 type debugLogger struct {
 	logs string
 }
@@ -4248,6 +7581,43 @@ func TestGridLinear(t *testing.T) {
 	assert.Equal(t, 0.5, gridBindingPoints[imageCentreIndex])
 }
 
+func TestGridInterpolate(t *testing.T) {
+	vds, err := CreateVector(Memory, "")
+	require.NoError(t, err)
+	defer vds.Close()
+	lyr, err := vds.CreateLayer("points", nil, GTPoint25D)
+	require.NoError(t, err)
+	for _, wkt := range []string{"POINT (0 0 10)", "POINT (10 0 20)", "POINT (0 10 30)", "POINT (10 10 40)"} {
+		g, _ := NewGeometryFromWKT(wkt, nil)
+		f, err := lyr.NewFeature(g)
+		require.NoError(t, err)
+		f.Close()
+		g.Close()
+	}
+
+	alg := GridAlgorithm{Name: "invdist", Power: 2, NoData: -1}
+	assert.Equal(t, "invdist:power=2:nodata=-1", alg.String())
+	rds, err := vds.GridInterpolate(alg, [4]float64{0, 0, 10, 10}, 10, 10)
+	require.NoError(t, err)
+	defer rds.Close()
+	st := rds.Structure()
+	assert.Equal(t, 10, st.SizeX)
+	assert.Equal(t, 10, st.SizeY)
+	assert.Equal(t, Float64, st.DataType)
+
+	data := make([]float64, 100)
+	err = rds.Read(0, 0, data, 10, 10)
+	assert.NoError(t, err)
+	//bottom-left pixel is closest to POINT(0 0 10), top-right to POINT(10 10 40)
+	assert.InDelta(t, 10, data[90], 1)
+	assert.InDelta(t, 40, data[9], 1)
+
+	_, err = vds.GridInterpolate(GridAlgorithm{Name: "foo"}, [4]float64{0, 0, 10, 10}, 10, 10)
+	assert.Error(t, err)
+	_, err = vds.GridInterpolate(alg, [4]float64{0, 0, 10, 10}, 0, 10)
+	assert.Error(t, err)
+}
+
 func TestGridCreateLinear(t *testing.T) {
 	var (
 		err error
@@ -4411,6 +7781,43 @@ func TestGridCreateMaximum(t *testing.T) {
 	assert.Equal(t, 1.0, gridCreateBindingPoints[imageCentreIndex])
 }
 
+func TestGridTypedAlgorithms(t *testing.T) {
+	str := func(p GridAlgorithmParams) string {
+		s, err := GridAlgorithmString(p)
+		assert.NoError(t, err)
+		return s
+	}
+	assert.Equal(t, "invdist:power=3:nodata=0", str(GridInvDist{Power: 3}))
+	assert.Equal(t, "invdistnn:power=2:radius=5:max_points=12:nodata=-1",
+		str(GridInvDist{Power: 2, Radius: 5, MaxPoints: 12, NoData: -1}))
+	assert.Equal(t, "nearest:radius1=2:radius2=2:nodata=0", str(GridNearestNeighbor{Radius: 2}))
+	assert.Equal(t, "average:radius1=1:radius2=1:min_points=2:nodata=0", str(GridAverage{Radius: 1, MinPoints: 2}))
+	assert.Equal(t, "linear:radius=3:nodata=0", str(GridAlgorithm{Name: "linear", Radius: 3}))
+	assert.Equal(t, GridAlgorithm{Name: "count", Radius1: 10, Radius2: 10}, GridMetric{Name: "count", Radius: 10}.Algorithm())
+
+	xCoords := []float64{0, 1, 0, 1}
+	yCoords := []float64{0, 0, 1, 1}
+	zCoords := []float64{1, 0, 0, 1}
+	out := make([]float64, 4)
+	err := GridCreate(str(GridMetric{Name: "count", Radius: 10}), xCoords, yCoords, zCoords, 0, 1, 0, 1, 2, 2, out)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{4, 4, 4, 4}, out)
+
+	_, err = GridAlgorithmString(GridMetric{Name: "median"})
+	assert.EqualError(t, err, "unknown gridding data metric median")
+	_, err = GridAlgorithmString(GridMetric{Name: "invdist"})
+	assert.Error(t, err)
+	_, err = GridAlgorithmString(GridAlgorithm{Name: "bogus"})
+	assert.EqualError(t, err, "unknown gridding algorithm bogus")
+	_, err = GridAlgorithmString(GridAverage{Radius: -1})
+	assert.Error(t, err)
+
+	vds, _ := CreateVector(Memory, "")
+	defer vds.Close()
+	_, err = vds.GridInterpolate(GridAlgorithm{Name: "bogus"}, [4]float64{0, 0, 1, 1}, 2, 2)
+	assert.EqualError(t, err, "unknown gridding algorithm bogus")
+}
+
 func TestGridInvalidSwitch(t *testing.T) {
 	vrtDs, err := CreateVector(Memory, "")
 	if err != nil {
@@ -4963,6 +8370,24 @@ func TestSetGCPs2InvalidDataset(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestClearGCPs(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Byte, 10, 10)
+	defer ds.Close()
+	assert.Equal(t, 0, ds.GCPCount())
+	err := ds.SetGCPs([]GCP{
+		{DfGCPPixel: 0, DfGCPLine: 0, DfGCPX: 10, DfGCPY: 20},
+		{DfGCPPixel: 10, DfGCPLine: 10, DfGCPX: 20, DfGCPY: 10},
+	}, GCPProjection("EPSG:4326"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, ds.GCPCount())
+
+	ehc := eh()
+	err = ds.ClearGCPs(ErrLogger(ehc.ErrorHandler))
+	require.NoError(t, err)
+	assert.Equal(t, 0, ds.GCPCount())
+	assert.Empty(t, ds.GCPs())
+}
+
 func TestGCPsToGeoTransformEmptyList(t *testing.T) {
 	var gcpList []GCP = []GCP{}
 
@@ -5322,3 +8747,75 @@ func TestDemColorReliefInvalidFilename(t *testing.T) {
 	_, err = vrtDs.Dem("/vsimem/out.tiff", "color-relief", invalidColorReliefFilename, []string{})
 	assert.Error(t, err)
 }
+
+func TestDemTypedModes(t *testing.T) {
+	ds, _ := Create(Memory, "", 1, Float32, 16, 16)
+	defer ds.Close()
+	_ = ds.SetGeoTransform([6]float64{0, 10, 0, 160, 0, -10})
+	buf := make([]float32, 16*16)
+	for i := range buf {
+		buf[i] = float32(i%16) * 10 //slope of 45° facing west
+	}
+	_ = ds.Write(0, 0, buf, 16, 16)
+
+	slope, err := ds.Slope("", SlopeParams{ComputeEdges: true}, Memory)
+	require.NoError(t, err)
+	_ = slope.Read(0, 0, buf, 16, 16)
+	assert.InDelta(t, 45, buf[8*16+8], 1e-3)
+	slope.Close()
+
+	slope, err = ds.Slope("", SlopeParams{Percent: true, ComputeEdges: true}, Memory)
+	require.NoError(t, err)
+	_ = slope.Read(0, 0, buf, 16, 16)
+	assert.InDelta(t, 100, buf[8*16+8], 1e-3)
+	slope.Close()
+
+	aspect, err := ds.Aspect("", AspectParams{}, Memory)
+	require.NoError(t, err)
+	_ = aspect.Read(0, 0, buf, 16, 16)
+	assert.InDelta(t, 270, buf[8*16+8], 1e-3)
+	aspect.Close()
+
+	az, alt := 270.0, 45.0
+	hs, err := ds.Hillshade("", HillshadeParams{Azimuth: &az, Altitude: &alt}, Memory)
+	require.NoError(t, err)
+	assert.Equal(t, Byte, hs.Structure().DataType)
+	hs.Close()
+
+	// zero azimuth and altitude are passed to gdaldem and not replaced by the defaults
+	zero := 0.0
+	sw, err := HillshadeParams{Azimuth: &zero, Altitude: &zero, Algorithm: ZevenbergenThorne}.switches()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-az", "0", "-alt", "0", "-alg", "ZevenbergenThorne"}, sw)
+	sw, err = HillshadeParams{}.switches()
+	require.NoError(t, err)
+	assert.Len(t, sw, 0)
+
+	_, err = ds.Hillshade("", HillshadeParams{Multidirectional: true, Combined: true}, Memory)
+	assert.Error(t, err)
+	_, err = ds.Hillshade("", HillshadeParams{Multidirectional: true, Azimuth: &zero}, Memory)
+	assert.Error(t, err)
+	_, err = ds.Slope("", SlopeParams{Algorithm: DemAlgorithm(42)}, Memory)
+	assert.Error(t, err)
+
+	for _, fn := range []func(string, ...DemOption) (*Dataset, error){ds.TRI, ds.TPI, ds.Roughness} {
+		out, err := fn("", Memory)
+		require.NoError(t, err)
+		_ = out.Read(0, 0, buf, 16, 16)
+		assert.NotEqual(t, float32(0), buf[8*16+8])
+		out.Close()
+	}
+
+	colorFile := "/vsimem/colors.txt"
+	vf, err := VSIOpen(colorFile, VSIOpenMode("w"))
+	require.NoError(t, err)
+	_, _ = vf.Write([]byte("0 0 0 0\n150 255 255 255\n"))
+	_ = vf.Close()
+	defer func() { _ = VSIUnlink(colorFile) }()
+	cr, err := ds.ColorRelief("", colorFile, ColorReliefParams{Alpha: true}, Memory)
+	require.NoError(t, err)
+	assert.Len(t, cr.Bands(), 4)
+	cr.Close()
+	_, err = ds.ColorRelief("", "", ColorReliefParams{}, Memory)
+	assert.Error(t, err)
+}
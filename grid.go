@@ -0,0 +1,232 @@
+// Copyright 2021 Airbus Defence and Space
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GridAlgorithm is a gridding algorithm and its parameters, as used by gdal_grid.
+//
+// The GridInvDist, GridNearestNeighbor, GridAverage and GridMetric types expose the
+// parameters relevant to each family of algorithms and can be converted to a
+// GridAlgorithm with their Algorithm() method, or to a validated algorithm string with
+// GridAlgorithmString.
+//
+// Zero valued parameters are not passed to gdal, which will then use its own
+// defaults. See https://gdal.org/programs/gdal_grid.html#interpolation-algorithms
+// for the parameters supported by each algorithm.
+type GridAlgorithm struct {
+	// Name is the algorithm name, e.g. "invdist", "invdistnn", "average", "nearest" or "linear"
+	Name string
+	// Power is the weighting power of inverse distance algorithms (gdal defaults to 2)
+	Power float64
+	// Smoothing is the smoothing parameter of inverse distance algorithms
+	Smoothing float64
+	// Radius1 and Radius2 are the axes of the search ellipse, in georeferenced units.
+	// Anisotropy is obtained by setting different values for Radius1 and Radius2.
+	Radius1, Radius2 float64
+	// Radius is the radius of the search circle of the "invdistnn" and "linear" algorithms
+	Radius float64
+	// Angle is the counter-clockwise rotation of the search ellipse, in degrees
+	Angle float64
+	// MaxPoints is the maximum number of data points to use
+	MaxPoints int
+	// MinPoints is the minimum number of data points to use. If less points are found
+	// the output pixel is set to NoData
+	MinPoints int
+	// NoData is the value used to fill empty points
+	NoData float64
+}
+
+// String returns the gdal_grid algorithm string, e.g. "invdist:power=2:nodata=0"
+func (ga GridAlgorithm) String() string {
+	params := []string{ga.Name}
+	appendFloat := func(key string, v float64) {
+		if v != 0 {
+			params = append(params, key+"="+strconv.FormatFloat(v, 'g', -1, 64))
+		}
+	}
+	appendFloat("power", ga.Power)
+	appendFloat("smoothing", ga.Smoothing)
+	appendFloat("radius1", ga.Radius1)
+	appendFloat("radius2", ga.Radius2)
+	appendFloat("radius", ga.Radius)
+	appendFloat("angle", ga.Angle)
+	if ga.MaxPoints != 0 {
+		params = append(params, "max_points="+strconv.Itoa(ga.MaxPoints))
+	}
+	if ga.MinPoints != 0 {
+		params = append(params, "min_points="+strconv.Itoa(ga.MinPoints))
+	}
+	params = append(params, "nodata="+strconv.FormatFloat(ga.NoData, 'g', -1, 64))
+	return strings.Join(params, ":")
+}
+
+// Algorithm returns ga itself, so that a GridAlgorithm can be used as GridAlgorithmParams
+func (ga GridAlgorithm) Algorithm() GridAlgorithm {
+	return ga
+}
+
+// GridAlgorithmParams is implemented by GridAlgorithm and by the typed gridding
+// algorithms GridInvDist, GridNearestNeighbor, GridAverage and GridMetric
+type GridAlgorithmParams interface {
+	Algorithm() GridAlgorithm
+}
+
+// GridAlgorithmString validates params and returns the corresponding gdal_grid
+// algorithm string, as expected by GridCreate, e.g.
+//
+//	alg, err := GridAlgorithmString(GridInvDist{Power: 3, Radius: 10})
+func GridAlgorithmString(params GridAlgorithmParams) (string, error) {
+	var err error
+	if v, ok := params.(interface{ Validate() error }); ok {
+		err = v.Validate()
+	} else {
+		err = params.Algorithm().Validate()
+	}
+	if err != nil {
+		return "", err
+	}
+	return params.Algorithm().String(), nil
+}
+
+// Validate checks that the algorithm name is known to gdal_grid and that the
+// search radii and point counts are not negative
+func (ga GridAlgorithm) Validate() error {
+	if _, err := gridAlgFromString(ga.Name); err != nil {
+		return err
+	}
+	if ga.Radius1 < 0 || ga.Radius2 < 0 || ga.Radius < 0 {
+		return fmt.Errorf("invalid negative search radius for gridding algorithm %s", ga.Name)
+	}
+	if ga.MaxPoints < 0 || ga.MinPoints < 0 {
+		return fmt.Errorf("invalid negative point count for gridding algorithm %s", ga.Name)
+	}
+	return nil
+}
+
+// GridInterpolate interpolates the scattered points of the vector dataset ds into a
+// w*h Float64 in-memory raster spanning extent (i.e. [minX,minY,maxX,maxY]), using the
+// Z values of the point geometries.
+//
+// The returned dataset is north-up and uses the spatial reference of the input layer.
+func (ds *Dataset) GridInterpolate(alg GridAlgorithm, extent [4]float64, w, h int, opts ...GridOption) (*Dataset, error) {
+	if err := alg.Validate(); err != nil {
+		return nil, err
+	}
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("invalid output size %dx%d", w, h)
+	}
+	ftoa := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	switches := []string{
+		"-a", alg.String(),
+		"-txe", ftoa(extent[0]), ftoa(extent[2]),
+		//ymax first, to have consistent north-up output on all gdal versions
+		"-tye", ftoa(extent[3]), ftoa(extent[1]),
+		"-outsize", strconv.Itoa(w), strconv.Itoa(h),
+		"-ot", "Float64",
+		"-of", "MEM",
+	}
+	return ds.Grid("", switches, opts...)
+}
+
+// GridInvDist is the inverse distance to a power gridding algorithm. If Radius is set,
+// only the points inside the search circle are used ("invdistnn" algorithm).
+type GridInvDist struct {
+	// Power is the weighting power (gdal defaults to 2)
+	Power float64
+	// Smoothing is the smoothing parameter
+	Smoothing float64
+	// Radius is the radius of the search circle, in georeferenced units
+	Radius float64
+	// MaxPoints and MinPoints are the maximum and minimum number of points to use
+	MaxPoints, MinPoints int
+	// NoData is the value used to fill empty points
+	NoData float64
+}
+
+// Algorithm returns the corresponding GridAlgorithm
+func (g GridInvDist) Algorithm() GridAlgorithm {
+	ga := GridAlgorithm{Name: "invdist", Power: g.Power, Smoothing: g.Smoothing,
+		MaxPoints: g.MaxPoints, MinPoints: g.MinPoints, NoData: g.NoData}
+	if g.Radius != 0 {
+		ga.Name = "invdistnn"
+		ga.Radius = g.Radius
+	}
+	return ga
+}
+
+// GridNearestNeighbor is the nearest neighbor gridding algorithm
+type GridNearestNeighbor struct {
+	// Radius is the radius of the search circle, in georeferenced units
+	Radius float64
+	// NoData is the value used to fill empty points
+	NoData float64
+}
+
+// Algorithm returns the corresponding GridAlgorithm
+func (g GridNearestNeighbor) Algorithm() GridAlgorithm {
+	return GridAlgorithm{Name: "nearest", Radius1: g.Radius, Radius2: g.Radius, NoData: g.NoData}
+}
+
+// GridAverage is the moving average gridding algorithm
+type GridAverage struct {
+	// Radius is the radius of the search circle, in georeferenced units
+	Radius float64
+	// MinPoints is the minimum number of points to average
+	MinPoints int
+	// NoData is the value used to fill empty points
+	NoData float64
+}
+
+// Algorithm returns the corresponding GridAlgorithm
+func (g GridAverage) Algorithm() GridAlgorithm {
+	return GridAlgorithm{Name: "average", Radius1: g.Radius, Radius2: g.Radius,
+		MinPoints: g.MinPoints, NoData: g.NoData}
+}
+
+// GridMetric is a data metrics gridding algorithm
+type GridMetric struct {
+	// Name is one of "minimum", "maximum", "range", "count", "average_distance" or
+	// "average_distance_pts"
+	Name string
+	// Radius is the radius of the search circle, in georeferenced units
+	Radius float64
+	// MinPoints is the minimum number of points required to compute the metric
+	MinPoints int
+	// NoData is the value used to fill empty points
+	NoData float64
+}
+
+// Algorithm returns the corresponding GridAlgorithm
+func (g GridMetric) Algorithm() GridAlgorithm {
+	return GridAlgorithm{Name: g.Name, Radius1: g.Radius, Radius2: g.Radius,
+		MinPoints: g.MinPoints, NoData: g.NoData}
+}
+
+// Validate checks that Name is one of the supported data metrics
+func (g GridMetric) Validate() error {
+	switch g.Name {
+	case "minimum", "maximum", "range", "count", "average_distance", "average_distance_pts":
+		return g.Algorithm().Validate()
+	default:
+		return fmt.Errorf("unknown gridding data metric %s", g.Name)
+	}
+}
@@ -86,6 +86,7 @@ func (aoo approximateOkOption) setHistogramOpt(ho *histogramOpts) {
 func Approximate() interface {
 	HistogramOption
 	StatisticsOption
+	AutoStretchOption
 } {
 	return approximateOkOption{}
 }
@@ -108,3 +109,65 @@ func Intervals(count int, min, max float64) interface {
 } {
 	return intervalsOption{min: min, max: max, buckets: int32(count)}
 }
+
+type defaultHistogramOpts struct {
+	errorHandler ErrorHandler
+}
+
+// DefaultHistogramOption is an option that can be passed to Band.GetDefaultHistogram()
+//
+// Available DefaultHistogramOptions are:
+//   - ErrLogger
+type DefaultHistogramOption interface {
+	setDefaultHistogramOpt(ho *defaultHistogramOpts)
+}
+
+type setDefaultHistogramOpts struct {
+	errorHandler ErrorHandler
+}
+
+// SetDefaultHistogramOption is an option that can be passed to Band.SetDefaultHistogram()
+//
+// Available SetDefaultHistogramOptions are:
+//   - ErrLogger
+type SetDefaultHistogramOption interface {
+	setSetDefaultHistogramOpt(ho *setDefaultHistogramOpts)
+}
+
+type autoStretchOpts struct {
+	approx       int
+	percentiles  *[2]float64
+	errorHandler ErrorHandler
+}
+
+// AutoStretchOption is an option that can be passed to Band.AutoStretch(),
+// Dataset.StretchToByte() or Dataset.AutoStretch()
+//
+// Available AutoStretchOptions are:
+//   - Approximate() to compute the underlying histogram on overviews or a subset of all tiles
+//   - Percentiles() to change the default 2/98 percentiles used by Dataset.AutoStretch()
+//   - ErrLogger
+type AutoStretchOption interface {
+	setAutoStretchOpt(ao *autoStretchOpts)
+}
+
+func (aoo approximateOkOption) setAutoStretchOpt(ao *autoStretchOpts) {
+	ao.approx = 1
+}
+
+type percentilesOpt struct {
+	low, high float64
+}
+
+func (po percentilesOpt) setAutoStretchOpt(ao *autoStretchOpts) {
+	ao.percentiles = &[2]float64{po.low, po.high}
+}
+
+// Percentiles sets the percentiles used by Dataset.AutoStretch() to compute the
+// stretch bounds of each band. It is ignored by Band.AutoStretch() and
+// Dataset.StretchToByte() which take them as explicit arguments.
+func Percentiles(lowPct, highPct float64) interface {
+	AutoStretchOption
+} {
+	return percentilesOpt{lowPct, highPct}
+}
@@ -0,0 +1,238 @@
+// Copyright 2021 Airbus Defence and Space
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package godal
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// imageBands returns the 0-based indexes of the gray or red/green/blue bands of the
+// dataset, along with the index of its alpha band (-1 if none)
+func imageBands(bands []Band) (colors []int, alpha int) {
+	alpha = -1
+	r, g, b := -1, -1, -1
+	for i, bnd := range bands {
+		switch bnd.ColorInterp() {
+		case CIRed:
+			r = i
+		case CIGreen:
+			g = i
+		case CIBlue:
+			b = i
+		case CIAlpha:
+			if alpha == -1 {
+				alpha = i
+			}
+		}
+	}
+	if r >= 0 && g >= 0 && b >= 0 {
+		return []int{r, g, b}, alpha
+	}
+	// no explicit RGB interpretation: rely on the band order
+	switch len(bands) {
+	case 1:
+		return []int{0}, -1
+	case 2:
+		return []int{0}, 1
+	case 3:
+		return []int{0, 1, 2}, -1
+	default:
+		return []int{0, 1, 2}, 3
+	}
+}
+
+// ReadAsImage reads the dataset's pixels into an image.Image suitable for visualization
+// or encoding with the standard library image packages. The pixels are read from the
+// window starting at srcX,srcY, and the returned image is of size w*h. As for Read, the
+// Window option can be used to read a source window of a different size, e.g. to create
+// thumbnails, in which case the Resampling option also applies.
+//
+// Up to four bands are read, and converted to Byte by gdal if needed (i.e. values are
+// clamped, not rescaled: see StretchToByte for that). The red, green, blue and alpha
+// bands are selected from their ColorInterp, falling back to the band order if the dataset
+// does not have an RGB color interpretation. The returned image is an *image.Gray for single
+// band datasets and an *image.RGBA for three band datasets, or an *image.NRGBA if the
+// dataset has an alpha band or a nodata mask. Paletted datasets should be expanded with
+// ExpandPaletteToRGBA first.
+//
+// Options controlling the layout of the buffer (i.e. Bands and strides) are not supported.
+func (ds *Dataset) ReadAsImage(srcX, srcY, w, h int, opts ...DatasetIOOption) (image.Image, error) {
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("invalid image size %dx%d", w, h)
+	}
+	bands := ds.Bands()
+	if len(bands) == 0 {
+		return nil, fmt.Errorf("cannot read image from dataset with no bands")
+	}
+	colors, alpha := imageBands(bands)
+
+	var alphaBuf []byte
+	if alpha >= 0 {
+		alphaBuf = make([]byte, w*h)
+		if err := ds.Read(srcX, srcY, alphaBuf, w, h, append(opts[:len(opts):len(opts)], Bands(alpha))...); err != nil {
+			return nil, err
+		}
+	} else if !bands[colors[0]].MaskFlags().IsAllValid() {
+		alphaBuf = make([]byte, w*h)
+		bopts := []BandIOOption{}
+		ro := datasetIOOpts{}
+		for _, opt := range opts {
+			opt.setDatasetIOOpt(&ro)
+		}
+		if ro.dsWidth > 0 || ro.dsHeight > 0 {
+			if ro.dsWidth == 0 {
+				ro.dsWidth = w
+			}
+			if ro.dsHeight == 0 {
+				ro.dsHeight = h
+			}
+			bopts = append(bopts, Window(ro.dsWidth, ro.dsHeight))
+		}
+		if len(ro.config) > 0 {
+			bopts = append(bopts, ConfigOption(ro.config...))
+		}
+		if ro.errorHandler != nil {
+			bopts = append(bopts, ErrLogger(ro.errorHandler))
+		}
+		if err := bands[colors[0]].MaskBand().Read(srcX, srcY, alphaBuf, w, h, bopts...); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(colors) == 1 && alphaBuf == nil {
+		img := image.NewGray(image.Rect(0, 0, w, h))
+		if err := ds.Read(srcX, srcY, img.Pix, w, h, append(opts[:len(opts):len(opts)], Bands(colors[0]))...); err != nil {
+			return nil, err
+		}
+		return img, nil
+	}
+
+	// read the color bands into the first 3 bytes of each 4 byte pixel
+	pix := make([]byte, 4*w*h)
+	ropts := append(opts[:len(opts):len(opts)], PixelStride(4), LineStride(4*w), BandStride(1))
+	if len(colors) == 1 {
+		ropts = append(ropts, Bands(colors[0], colors[0], colors[0]))
+	} else {
+		ropts = append(ropts, Bands(colors...))
+	}
+	if err := ds.Read(srcX, srcY, pix, w, h, ropts...); err != nil {
+		return nil, err
+	}
+	rect := image.Rect(0, 0, w, h)
+	if alphaBuf == nil {
+		for i := 3; i < len(pix); i += 4 {
+			pix[i] = 255
+		}
+		return &image.RGBA{Pix: pix, Stride: 4 * w, Rect: rect}, nil
+	}
+	for i, a := range alphaBuf {
+		pix[4*i+3] = a
+	}
+	return &image.NRGBA{Pix: pix, Stride: 4 * w, Rect: rect}, nil
+}
+
+// CreateFromImage creates a dataset of the size of img with the given driver, and writes
+// the pixels of img into it. The band count and data type depend on the concrete type of
+// img:
+//   - *image.Gray: a single Byte band
+//   - *image.Gray16: a single UInt16 band
+//   - *image.Paletted: a single Byte band with a color table
+//   - *image.RGBA, *image.NRGBA and any other image type: four Byte bands holding the
+//     red, green, blue and (non premultiplied) alpha values
+//
+// The ColorInterp of each band is set accordingly. The returned dataset has no
+// georeferencing. Drivers that do not support direct creation (e.g. PNG or JPEG) can be
+// targeted by creating a Memory dataset and calling Translate on it.
+func CreateFromImage(driver DriverName, name string, img image.Image, opts ...DatasetCreateOption) (*Dataset, error) {
+	r := img.Bounds()
+	w, h := r.Dx(), r.Dy()
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("cannot create dataset from empty image")
+	}
+	var (
+		pix      interface{}
+		nBands   = 1
+		dtype    = Byte
+		stride   int //in pixel values
+		interps  = []ColorInterp{CIGray}
+		colorTbl *ColorTable
+	)
+	switch im := img.(type) {
+	case *image.Gray:
+		pix, stride = im.Pix[im.PixOffset(r.Min.X, r.Min.Y):], im.Stride
+	case *image.Gray16:
+		//image.Gray16 stores big-endian values
+		buf := make([]uint16, w*h)
+		for y := 0; y < h; y++ {
+			off := im.PixOffset(r.Min.X, r.Min.Y+y)
+			for x := 0; x < w; x++ {
+				buf[y*w+x] = uint16(im.Pix[off+2*x])<<8 | uint16(im.Pix[off+2*x+1])
+			}
+		}
+		pix, stride, dtype = buf, w, UInt16
+	case *image.Paletted:
+		pix, stride = im.Pix[im.PixOffset(r.Min.X, r.Min.Y):], im.Stride
+		interps = []ColorInterp{CIPalette}
+		ct := ColorTable{PaletteInterp: RGBPalette, Entries: make([][4]int16, len(im.Palette))}
+		for i, c := range im.Palette {
+			nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+			ct.Entries[i] = [4]int16{int16(nc.R), int16(nc.G), int16(nc.B), int16(nc.A)}
+		}
+		colorTbl = &ct
+	default:
+		nrgba, ok := img.(*image.NRGBA)
+		if !ok {
+			nrgba = image.NewNRGBA(r)
+			draw.Draw(nrgba, r, img, r.Min, draw.Src)
+		}
+		pix, stride = nrgba.Pix[nrgba.PixOffset(r.Min.X, r.Min.Y):], nrgba.Stride
+		nBands = 4
+		interps = []ColorInterp{CIRed, CIGreen, CIBlue, CIAlpha}
+	}
+
+	ds, err := Create(driver, name, nBands, dtype, w, h, opts...)
+	if err != nil {
+		return nil, err
+	}
+	co := dsCreateOpts{}
+	for _, opt := range opts {
+		opt.setDatasetCreateOpt(&co)
+	}
+	wopts := []DatasetIOOption{LineStride(stride)}
+	if nBands > 1 {
+		wopts = append(wopts, PixelStride(nBands), BandStride(1))
+	}
+	if co.errorHandler != nil {
+		wopts = append(wopts, ErrLogger(co.errorHandler))
+	}
+	err = ds.Write(0, 0, pix, w, h, wopts...)
+	for i, bnd := range ds.Bands() {
+		if err != nil {
+			break
+		}
+		err = bnd.SetColorInterp(interps[i], ErrLogger(co.errorHandler))
+	}
+	if err == nil && colorTbl != nil {
+		err = ds.Bands()[0].SetColorTable(*colorTbl, ErrLogger(co.errorHandler))
+	}
+	if err != nil {
+		ds.Close()
+		return nil, err
+	}
+	return ds, nil
+}
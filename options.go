@@ -14,7 +14,13 @@
 
 package godal
 
-import "sort"
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
 
 // GetGeoTransformOption is an option that can be passed to Dataset.GeoTransform()
 //
@@ -72,6 +78,19 @@ type setNodataOpts struct {
 	errorHandler ErrorHandler
 }
 
+// SetNoDataFromMaskOption is an option that can be passed to Band.SetNoDataFromMask()
+//
+// Available SetNoDataFromMaskOptions are:
+//   - ConfigOption
+//   - ErrLogger
+type SetNoDataFromMaskOption interface {
+	setSetNoDataFromMaskOpt(so *setNoDataFromMaskOpts)
+}
+type setNoDataFromMaskOpts struct {
+	config       []string
+	errorHandler ErrorHandler
+}
+
 // SetScaleOffsetOption is an option that can be passed to Band.SetScaleOffset(),
 // Band.ClearScaleOffset(), Dataset.SetScaleOffset()
 //
@@ -84,7 +103,30 @@ type setScaleOffsetOpts struct {
 	errorHandler ErrorHandler
 }
 
+// SetUnitTypeOption is an option that can be passed to Band.SetUnitType()
+//
+// Available SetUnitTypeOptions are:
+//   - ErrLogger
+type SetUnitTypeOption interface {
+	setSetUnitTypeOpt(so *setUnitTypeOpts)
+}
+type setUnitTypeOpts struct {
+	errorHandler ErrorHandler
+}
+
+// SetCategoryNamesOption is an option that can be passed to Band.SetCategoryNames()
+//
+// Available SetCategoryNamesOptions are:
+//   - ErrLogger
+type SetCategoryNamesOption interface {
+	setSetCategoryNamesOpt(so *setCategoryNamesOpts)
+}
+type setCategoryNamesOpts struct {
+	errorHandler ErrorHandler
+}
+
 // SetColorInterpOption is an option that can be passed to Band.SetColorInterpretation()
+// or Dataset.SetColorInterps()
 //
 // Available SetColorInterpOption are:
 //   - ErrLogger
@@ -138,6 +180,11 @@ type bandIOOpts struct {
 	resampling                ResamplingAlg
 	pixelSpacing, lineSpacing int
 	pixelStride, lineStride   int
+	overviewUsed              *bool
+	scaled                    bool
+	mask                      []byte
+	srcWindowF                *[4]float64
+	readContext               *ReadContext
 	errorHandler              ErrorHandler
 }
 
@@ -151,10 +198,69 @@ type bandIOOpts struct {
 //   - ConfigOption
 //   - PixelSpacing
 //   - LineSpacing
+//   - ReportOverviewUsage
+//   - Scaled
+//   - WithMask
+//   - *ReadContext
 type BandIOOption interface {
 	setBandIOOpt(ro *bandIOOpts)
 }
 
+type overviewUsedOpt struct {
+	used *bool
+}
+
+func (ouo overviewUsedOpt) setBandIOOpt(ro *bandIOOpts) {
+	ro.overviewUsed = ouo.used
+}
+
+// ReportOverviewUsage makes Band.Read/Band.IO set used to true if the read is served
+// from one of the band's overviews rather than from the full resolution band, i.e. when
+// downsampling a window of a band that has overviews. This is determined by looking up
+// the overview GDAL selects for the requested sampling (GDALGetRasterSampleOverview)
+// before the read. used is always set to false for writes.
+func ReportOverviewUsage(used *bool) interface {
+	BandIOOption
+} {
+	return overviewUsedOpt{used}
+}
+
+type scaledOpt struct{}
+
+func (so scaledOpt) setBandIOOpt(ro *bandIOOpts) {
+	ro.scaled = true
+}
+
+// Scaled makes Band.Read/Band.IO return physical values, i.e. raw pixel values
+// multiplied by the band's scale and incremented by its offset. Pixels equal to the
+// band's nodata value are left untouched. Scaled is only supported for reads into
+// []float32 or []float64 buffers.
+func Scaled() interface {
+	BandIOOption
+} {
+	return scaledOpt{}
+}
+
+type withMaskOpt struct {
+	mask []byte
+}
+
+func (wmo withMaskOpt) setBandIOOpt(ro *bandIOOpts) {
+	ro.mask = wmo.mask
+}
+
+// WithMask makes Band.Read/Band.IO also fill mask with the values of the band's mask
+// band (i.e. 0 for nodata pixels and 255 for valid ones) over the same window, as set
+// by Window or SourceWindowF, and at the same resolution as the data (using nearest
+// neighbour resampling). The mask is fetched by a second read on the mask band, once
+// the data has been read. mask must hold at least bufWidth*bufHeight elements and is
+// filled without any spacing or stride. WithMask is only supported for reads.
+func WithMask(mask []byte) interface {
+	BandIOOption
+} {
+	return withMaskOpt{mask}
+}
+
 type fillnodataOpts struct {
 	mask *Band
 	//options      []string
@@ -186,6 +292,7 @@ type sieveFilterOpts struct {
 //
 // Available SieveFilterOptions are:
 //   - EightConnected() to enable 8-connectivity. Leave out completely for 4-connectivity (default)
+//   - Connectedness(n) to select 4 or 8-connectivity
 //   - Mask(band) to use given band as nodata mask instead of the internal nodata mask
 //   - NoMask() to ignore the the source band's nodata value or mask band
 //   - Destination(band) where to output the sieved band, instead of updating in-place
@@ -197,6 +304,8 @@ type polygonizeOpts struct {
 	mask          *Band
 	options       []string
 	pixFieldIndex int
+	pixFieldName  string
+	connectedness int
 	errorHandler  ErrorHandler
 }
 
@@ -204,8 +313,11 @@ type polygonizeOpts struct {
 //
 // Available PolygonizeOptions are:
 //   - EightConnected() to enable 8-connectivity. Leave out completely for 4-connectivity (default)
+//   - Connectedness(n) to select 4 or 8-connectivity
 //   - PixelValueFieldIndex(fieldidx) to populate the fieldidx'th field of the output
 //     dataset with the polygon's pixel value
+//   - PixelValueFieldName(name) to populate the field named name of the output dataset
+//     with the polygon's pixel value
 //   - Mask(band) to use given band as nodata mask instead of the internal nodata mask
 type PolygonizeOption interface {
 	setPolygonizeOpt(ro *polygonizeOpts)
@@ -224,9 +336,26 @@ type DatasetCreateMaskOption interface {
 	setDatasetCreateMaskOpt(dcm *dsCreateMaskOpts)
 }
 
+type addBandOpts struct {
+	config       []string
+	creation     []string
+	errorHandler ErrorHandler
+}
+
+// AddBandOption is an option that can be passed to Dataset.AddBand()
+//
+// Available AddBandOptions are:
+//   - ConfigOption
+//   - CreationOption
+//   - ErrLogger
+type AddBandOption interface {
+	setAddBandOpt(abo *addBandOpts)
+}
+
 type dsTranslateOpts struct {
 	config       []string
 	creation     []string
+	compression  *compressionOpt
 	driver       DriverName
 	errorHandler ErrorHandler
 }
@@ -236,15 +365,126 @@ type dsTranslateOpts struct {
 // Available DatasetTranslateOptions are:
 //   - ConfigOption
 //   - CreationOption
+//   - Compression
 //   - DriverName
 type DatasetTranslateOption interface {
 	setDatasetTranslateOpt(dto *dsTranslateOpts)
 }
 
+type splitBandsOpts struct {
+	config       []string
+	driver       DriverName
+	errorHandler ErrorHandler
+}
+
+// SplitBandsOption is an option that can be passed to Dataset.SplitBands()
+//
+// Available SplitBandsOptions are:
+//   - ConfigOption
+//   - DriverName (VRT or Memory)
+//   - ErrLogger
+type SplitBandsOption interface {
+	setSplitBandsOpt(so *splitBandsOpts)
+}
+
+type multiDimInfoOpts struct {
+	config       []string
+	errorHandler ErrorHandler
+}
+
+// MultiDimInfoOption is an option that can be passed to Dataset.MultiDimInfo()
+//
+// Available MultiDimInfoOptions are:
+//   - ConfigOption
+//   - ErrLogger
+type MultiDimInfoOption interface {
+	setMultiDimInfoOpt(mo *multiDimInfoOpts)
+}
+
+type expandPaletteOpts struct {
+	config       []string
+	driver       DriverName
+	errorHandler ErrorHandler
+}
+
+// ExpandPaletteOption is an option that can be passed to Dataset.ExpandPaletteToRGBA()
+//
+// Available ExpandPaletteOptions are:
+//   - ConfigOption
+//   - DriverName (VRT or Memory)
+//   - ErrLogger
+type ExpandPaletteOption interface {
+	setExpandPaletteOpt(eo *expandPaletteOpts)
+}
+
+type retileOpts struct {
+	config       []string
+	creation     []string
+	driver       DriverName
+	overlap      int
+	pattern      string
+	errorHandler ErrorHandler
+}
+
+// RetileOption is an option that can be passed to Dataset.Retile()
+//
+// Available RetileOptions are:
+//   - ConfigOption
+//   - CreationOption
+//   - DriverName
+//   - ErrLogger
+//   - Overlap
+//   - TileNamePattern
+type RetileOption interface {
+	setRetileOpt(ro *retileOpts)
+}
+
+type overlapOpt struct {
+	overlap int
+}
+
+// Overlap makes consecutive tiles created by Retile share n pixels (i.e. tiles
+// start every tileW-n columns and tileH-n rows).
+func Overlap(n int) interface {
+	RetileOption
+} {
+	return overlapOpt{n}
+}
+
+func (oo overlapOpt) setRetileOpt(ro *retileOpts) {
+	ro.overlap = oo.overlap
+}
+
+type tileNamePatternOpt struct {
+	pattern string
+}
+
+// TileNamePattern sets the filename of the tiles created by Retile. pattern is a
+// fmt format string that receives the tile row and column indexes (in that order),
+// and defaults to "tile_%d_%d.tif"
+func TileNamePattern(pattern string) interface {
+	RetileOption
+} {
+	return tileNamePatternOpt{pattern}
+}
+
+func (tno tileNamePatternOpt) setRetileOpt(ro *retileOpts) {
+	ro.pattern = tno.pattern
+}
+
 type dsWarpOpts struct {
 	config       []string
 	creation     []string
+	compression  *compressionOpt
 	driver       DriverName
+	nodata       *float64
+	srcNoData    []float64
+	dstNoData    []float64
+	resampling   *ResamplingAlg
+	cutline      *cutlineOpt
+	crop         bool
+	memoryLimit  int
+	threads      *int
 	errorHandler ErrorHandler
 }
 
@@ -253,7 +493,17 @@ type dsWarpOpts struct {
 // Available DatasetWarpOptions are:
 //   - ConfigOption
 //   - CreationOption
+//   - Compression
 //   - DriverName
+//   - UnifyNoData
+//   - SrcNoData
+//   - DstNoData
+//   - Resampling
+//   - Cutline
+//   - CutlineLayer
+//   - CropToCutline
+//   - WarpMemoryLimit
+//   - MultiThreaded
 type DatasetWarpOption interface {
 	setDatasetWarpOpt(dwo *dsWarpOpts)
 }
@@ -262,12 +512,255 @@ type DatasetWarpOption interface {
 //
 // Available DatasetWarpIntoOption is:
 //   - ConfigOption
+//   - UnifyNoData
+//   - SrcNoData
+//   - DstNoData
+//   - Resampling
+//   - Cutline
+//   - CutlineLayer
+//   - WarpMemoryLimit
+//   - MultiThreaded
 type DatasetWarpIntoOption interface {
 	setDatasetWarpIntoOpt(dwo *dsWarpIntoOpts)
 }
 
+type unifyNoDataOpt struct {
+	nd float64
+}
+
+// UnifyNoData makes Warp and WarpInto use nd as the nodata value of all the bands of
+// all the source datasets (overriding their own nodata values) and of the destination
+// dataset, i.e. it is equivalent to passing "-srcnodata nd -dstnodata nd" to gdalwarp.
+//
+// This avoids inconsistent seams when mosaicing sources that use differing nodata
+// conventions, at the expense of requiring the empty areas of all sources to be
+// filled with nd.
+func UnifyNoData(nd float64) interface {
+	DatasetWarpOption
+	DatasetWarpIntoOption
+} {
+	return unifyNoDataOpt{nd}
+}
+
+func (uno unifyNoDataOpt) setDatasetWarpOpt(o *dsWarpOpts) {
+	nd := uno.nd
+	o.nodata = &nd
+}
+func (uno unifyNoDataOpt) setDatasetWarpIntoOpt(o *dsWarpIntoOpts) {
+	nd := uno.nd
+	o.nodata = &nd
+}
+
+type srcNoDataOpt struct {
+	vals []float64
+}
+
+// SrcNoData sets the nodata values of the source datasets of Warp and WarpInto, i.e. it
+// is the equivalent of gdalwarp's -srcnodata switch. A single value applies to all the
+// bands, otherwise one value per band must be given.
+func SrcNoData(vals ...float64) interface {
+	DatasetWarpOption
+	DatasetWarpIntoOption
+} {
+	return srcNoDataOpt{append([]float64{}, vals...)}
+}
+
+func (sno srcNoDataOpt) setDatasetWarpOpt(o *dsWarpOpts) {
+	o.srcNoData = sno.vals
+}
+func (sno srcNoDataOpt) setDatasetWarpIntoOpt(o *dsWarpIntoOpts) {
+	o.srcNoData = sno.vals
+}
+
+type dstNoDataOpt struct {
+	vals []float64
+}
+
+// DstNoData sets the nodata values of the destination dataset of Warp and WarpInto, i.e.
+// it is the equivalent of gdalwarp's -dstnodata switch. A single value applies to all
+// the bands, otherwise one value per band must be given.
+func DstNoData(vals ...float64) interface {
+	DatasetWarpOption
+	DatasetWarpIntoOption
+} {
+	return dstNoDataOpt{append([]float64{}, vals...)}
+}
+
+func (dno dstNoDataOpt) setDatasetWarpOpt(o *dsWarpOpts) {
+	o.dstNoData = dno.vals
+}
+func (dno dstNoDataOpt) setDatasetWarpIntoOpt(o *dsWarpIntoOpts) {
+	o.dstNoData = dno.vals
+}
+
+type cutlineOpt struct {
+	geom  *Geometry
+	ds    *Dataset
+	layer string
+}
+
+// Cutline makes Warp and WarpInto only write the pixels falling inside geom, i.e. it is
+// the equivalent of gdalwarp's -cutline switch. geom is expected to be a polygon or
+// multipolygon and should have an associated SpatialRef, otherwise it is assumed to be
+// in the spatial reference of the destination dataset.
+//
+// Use CropToCutline to also restrict the extent of the dataset created by Warp to the
+// extent of geom.
+func Cutline(geom *Geometry) interface {
+	DatasetWarpOption
+	DatasetWarpIntoOption
+} {
+	return cutlineOpt{geom: geom}
+}
+
+// CutlineLayer is like Cutline, but uses the union of the geometries of the layer named
+// layerName of the vector dataset ds.
+func CutlineLayer(ds *Dataset, layerName string) interface {
+	DatasetWarpOption
+	DatasetWarpIntoOption
+} {
+	return cutlineOpt{ds: ds, layer: layerName}
+}
+
+func (co cutlineOpt) setDatasetWarpOpt(o *dsWarpOpts) {
+	o.cutline = &co
+}
+func (co cutlineOpt) setDatasetWarpIntoOpt(o *dsWarpIntoOpts) {
+	o.cutline = &co
+}
+
+type cropToCutlineOpt struct{}
+
+// CropToCutline makes Warp use the extent of the Cutline or CutlineLayer geometries for
+// the created dataset, i.e. it is the equivalent of gdalwarp's -crop_to_cutline switch.
+func CropToCutline() interface {
+	DatasetWarpOption
+} {
+	return cropToCutlineOpt{}
+}
+
+func (cropToCutlineOpt) setDatasetWarpOpt(o *dsWarpOpts) {
+	o.crop = true
+}
+
+type warpMemoryLimitOpt struct {
+	mb int
+}
+
+// WarpMemoryLimit sets the amount of memory, in megabytes, that the warp API is allowed
+// to use for caching, i.e. the equivalent of gdalwarp's -wm switch.
+func WarpMemoryLimit(mb int) interface {
+	DatasetWarpOption
+	DatasetWarpIntoOption
+} {
+	return warpMemoryLimitOpt{mb}
+}
+
+func (wml warpMemoryLimitOpt) setDatasetWarpOpt(o *dsWarpOpts) {
+	o.memoryLimit = wml.mb
+}
+func (wml warpMemoryLimitOpt) setDatasetWarpIntoOpt(o *dsWarpIntoOpts) {
+	o.memoryLimit = wml.mb
+}
+
+type multiThreadedOpt struct {
+	n int
+}
+
+// MultiThreaded makes the warp use multithreaded warping with nThreads threads, i.e. the
+// equivalent of gdalwarp's "-multi -wo NUM_THREADS=nThreads" switches. nThreads<=0 means
+// using all available CPUs.
+func MultiThreaded(nThreads int) interface {
+	DatasetWarpOption
+	DatasetWarpIntoOption
+} {
+	return multiThreadedOpt{nThreads}
+}
+
+func (mto multiThreadedOpt) setDatasetWarpOpt(o *dsWarpOpts) {
+	n := mto.n
+	o.threads = &n
+}
+func (mto multiThreadedOpt) setDatasetWarpIntoOpt(o *dsWarpIntoOpts) {
+	n := mto.n
+	o.threads = &n
+}
+
+// warpPerformanceSwitches returns the gdalwarp switches corresponding to the
+// WarpMemoryLimit and MultiThreaded options
+func warpPerformanceSwitches(memoryLimit int, threads *int) ([]string, error) {
+	switches := []string{}
+	if memoryLimit < 0 {
+		return nil, fmt.Errorf("invalid warp memory limit %dMB", memoryLimit)
+	}
+	if memoryLimit > 0 {
+		//gdalwarp interprets values >= 10000 as bytes instead of megabytes
+		if memoryLimit >= 10000 {
+			switches = append(switches, "-wm", strconv.FormatInt(int64(memoryLimit)*1024*1024, 10))
+		} else {
+			switches = append(switches, "-wm", strconv.Itoa(memoryLimit))
+		}
+	}
+	if threads != nil {
+		nt := "ALL_CPUS"
+		if *threads > 0 {
+			nt = strconv.Itoa(*threads)
+		}
+		switches = append(switches, "-multi", "-wo", "NUM_THREADS="+nt)
+	}
+	return switches, nil
+}
+
+func unifiedNoDataSwitches(nd float64) []string {
+	snd := strconv.FormatFloat(nd, 'g', -1, 64)
+	return []string{"-srcnodata", snd, "-dstnodata", snd}
+}
+
+// warpSwitches returns the gdalwarp switches corresponding to the nodata and resampling
+// options
+func warpSwitches(unified *float64, src, dst []float64, resampling *ResamplingAlg) ([]string, error) {
+	switches := []string{}
+	if unified != nil {
+		if src != nil || dst != nil {
+			return nil, fmt.Errorf("UnifyNoData cannot be used together with SrcNoData or DstNoData")
+		}
+		switches = append(switches, unifiedNoDataSwitches(*unified)...)
+	}
+	for _, nd := range []struct {
+		sw   string
+		vals []float64
+	}{{"-srcnodata", src}, {"-dstnodata", dst}} {
+		if nd.vals == nil {
+			continue
+		}
+		if len(nd.vals) == 0 {
+			return nil, fmt.Errorf("%s requires at least one value", nd.sw)
+		}
+		svals := make([]string, len(nd.vals))
+		for i, v := range nd.vals {
+			svals[i] = strconv.FormatFloat(v, 'g', -1, 64)
+		}
+		switches = append(switches, nd.sw, strings.Join(svals, " "))
+	}
+	if resampling != nil {
+		name, err := resampling.warpName()
+		if err != nil {
+			return nil, err
+		}
+		switches = append(switches, "-r", name)
+	}
+	return switches, nil
+}
+
 type dsWarpIntoOpts struct {
 	config       []string
+	nodata       *float64
+	srcNoData    []float64
+	dstNoData    []float64
+	resampling   *ResamplingAlg
+	cutline      *cutlineOpt
+	memoryLimit  int
+	threads      *int
 	errorHandler ErrorHandler
 }
 
@@ -277,6 +770,9 @@ type buildOvrOpts struct {
 	resampling   ResamplingAlg
 	bands        []int
 	levels       []int
+	external     bool
+	keepExisting bool
+	maskResample *ResamplingAlg
 	errorHandler ErrorHandler
 }
 
@@ -288,21 +784,40 @@ type buildOvrOpts struct {
 //   - Levels
 //   - MinSize
 //   - Bands
+//   - External
+//   - KeepExisting
+//   - MaskResampling
 type BuildOverviewsOption interface {
 	setBuildOverviewsOpt(bo *buildOvrOpts)
 }
 type clearOvrOpts struct {
+	keepExternal bool
 	errorHandler ErrorHandler
 }
 
 // ClearOverviewsOption is an option passed to Dataset.ClearOverviews
 //
 // Available options are:
+//   - KeepExternal
 //   - ErrLogger
 type ClearOverviewsOption interface {
 	setClearOverviewsOpt(bo *clearOvrOpts)
 }
 
+type keepExternalOpt struct{}
+
+func (keo keepExternalOpt) setClearOverviewsOpt(co *clearOvrOpts) {
+	co.keepExternal = true
+}
+
+// KeepExternal makes ClearOverviews only delete the overviews stored inside the dataset,
+// leaving an external .ovr sidecar file untouched.
+func KeepExternal() interface {
+	ClearOverviewsOption
+} {
+	return keepExternalOpt{}
+}
+
 type datasetIOOpts struct {
 	config                                 []string
 	bands                                  []int
@@ -311,6 +826,8 @@ type datasetIOOpts struct {
 	bandInterleave                         bool //return r1r2...rn,g1g2...gn,b1b2...bn instead of r1g1b1,r2g2b2,...,rngnbn
 	bandSpacing, pixelSpacing, lineSpacing int
 	bandStride, pixelStride, lineStride    int
+	srcWindowF                             *[4]float64
+	readContext                            *ReadContext
 	errorHandler                           ErrorHandler
 }
 
@@ -328,6 +845,7 @@ type datasetIOOpts struct {
 //   - PixelSpacing
 //   - LineSpacing
 //   - BandSpacing
+//   - *ReadContext
 type DatasetIOOption interface {
 	setDatasetIOOpt(ro *datasetIOOpts)
 }
@@ -335,6 +853,7 @@ type DatasetIOOption interface {
 type dsCreateOpts struct {
 	config       []string
 	creation     []string
+	compression  *compressionOpt
 	errorHandler ErrorHandler
 }
 
@@ -343,6 +862,7 @@ type dsCreateOpts struct {
 // Available DatasetCreateOptions are:
 //   - CreationOption
 //   - ConfigOption
+//   - Compression
 //   - ErrLogger
 type DatasetCreateOption interface {
 	setDatasetCreateOpt(dc *dsCreateOpts)
@@ -353,14 +873,37 @@ type openOpts struct {
 	drivers      []string //list of drivers that can be tried to open the given name
 	options      []string //driver specific open options (see gdal docs for each driver)
 	siblingFiles []string //list of sidecar files
+	openedBy     []DriverName
+	config       []string
+	errorHandler ErrorHandler
+}
+
+type identifyDriverOpts struct {
+	flags        uint
+	drivers      []string
+	siblingFiles []string
 	config       []string
 	errorHandler ErrorHandler
 }
 
+// IdentifyDriverOption is an option passed to IdentifyDriver()
+//
+// Available IdentifyDriverOptions are:
+//   - Drivers
+//   - SiblingFiles
+//   - RasterOnly
+//   - VectorOnly
+//   - ConfigOption
+//   - ErrLogger
+type IdentifyDriverOption interface {
+	setIdentifyDriverOpt(ido *identifyDriverOpts)
+}
+
 // OpenOption is an option passed to Open()
 //
 // Available OpenOptions are:
 //   - Drivers
+//   - OpenedBy
 //   - SiblingFiles
 //   - Shared
 //   - ConfigOption
@@ -368,6 +911,7 @@ type openOpts struct {
 //   - DriverOpenOption
 //   - RasterOnly
 //   - VectorOnly
+//   - Multidimensional
 type OpenOption interface {
 	setOpenOpt(oo *openOpts)
 }
@@ -384,6 +928,42 @@ type CloseOption interface {
 	setCloseOpt(o *closeOpts)
 }
 
+type extent3DOpts struct {
+	noForce      bool
+	errorHandler ErrorHandler
+}
+
+// Extent3DOption is an option passed to Layer.Extent3D()
+//
+// Available options are:
+//   - ForceExtent
+//   - ErrLogger
+type Extent3DOption interface {
+	setExtent3DOpt(eo *extent3DOpts)
+}
+
+type forceExtentOpt struct {
+	force bool
+}
+
+// ForceExtent controls whether Layer.Bounds and Layer.Extent3D may scan all the
+// features of the layer to compute its extent (the default). When force is false, only
+// an extent that the driver can obtain cheaply (e.g. from a file header) is returned,
+// and an error is returned if none is available.
+func ForceExtent(force bool) interface {
+	BoundsOption
+	Extent3DOption
+} {
+	return forceExtentOpt{force}
+}
+
+func (feo forceExtentOpt) setBoundsOpt(o *boundsOpts) {
+	o.noForce = !feo.force
+}
+func (feo forceExtentOpt) setExtent3DOpt(o *extent3DOpts) {
+	o.noForce = !feo.force
+}
+
 type featureCountOpts struct {
 	errorHandler ErrorHandler
 }
@@ -411,6 +991,9 @@ type differenceOpts struct {
 type intersectsOpts struct {
 	errorHandler ErrorHandler
 }
+type predicateOpts struct {
+	errorHandler ErrorHandler
+}
 type subGeometryOpts struct {
 	errorHandler ErrorHandler
 }
@@ -420,6 +1003,31 @@ type intersectionOpts struct {
 type unionOpts struct {
 	errorHandler ErrorHandler
 }
+type unaryUnionOpts struct {
+	errorHandler ErrorHandler
+}
+type geometryPolygonizeOpts struct {
+	errorHandler ErrorHandler
+}
+type convexHullOpts struct {
+	errorHandler ErrorHandler
+}
+type boundaryOpts struct {
+	errorHandler ErrorHandler
+}
+type pointOnSurfaceOpts struct {
+	errorHandler ErrorHandler
+}
+type makeValidOpts struct {
+	options      []string
+	errorHandler ErrorHandler
+}
+type segmentizeOpts struct {
+	errorHandler ErrorHandler
+}
+type setPrecisionOpts struct {
+	errorHandler ErrorHandler
+}
 
 // AddGeometryOption is an option passed to Geometry.AddGeometry()
 //
@@ -461,7 +1069,16 @@ type IntersectsOption interface {
 	setIntersectsOpt(bo *intersectsOpts)
 }
 
-// SubGeometryOption is an option passed to Geometry.SubGeometry()
+// PredicateOption is an option passed to the Geometry.Touches(), Crosses(), Within(),
+// Overlaps(), Disjoint() and Equals() spatial predicates
+//
+// Available options are:
+//   - ErrLogger
+type PredicateOption interface {
+	setPredicateOpt(po *predicateOpts)
+}
+
+// SubGeometryOption is an option passed to Geometry.SubGeometry() or Geometry.SubGeometryClone()
 //
 // Available options are:
 //   - ErrLogger
@@ -477,6 +1094,22 @@ type IntersectionOption interface {
 	setIntersectionOpt(io *intersectionOpts)
 }
 
+// UnaryUnionOption is an option passed to UnaryUnion()
+//
+// Available options are:
+//   - ErrLogger
+type UnaryUnionOption interface {
+	setUnaryUnionOpt(uo *unaryUnionOpts)
+}
+
+// GeometryPolygonizeOption is an option passed to Geometry.Polygonize()
+//
+// Available options are:
+//   - ErrLogger
+type GeometryPolygonizeOption interface {
+	setGeometryPolygonizeOpt(po *geometryPolygonizeOpts)
+}
+
 // UnionOption is an option passed to Geometry.Union()
 //
 // Available options are:
@@ -485,11 +1118,96 @@ type UnionOption interface {
 	setUnionOpt(uo *unionOpts)
 }
 
+// ConvexHullOption is an option passed to Geometry.ConvexHull()
+//
+// Available options are:
+//   - ErrLogger
+type ConvexHullOption interface {
+	setConvexHullOpt(co *convexHullOpts)
+}
+
+// BoundaryOption is an option passed to Geometry.Boundary()
+//
+// Available options are:
+//   - ErrLogger
+type BoundaryOption interface {
+	setBoundaryOpt(bo *boundaryOpts)
+}
+
+// MakeValidOption is an option passed to Geometry.MakeValid()
+//
+// Available options are:
+//   - MakeValidMethod
+//   - KeepCollapsed
+//   - ErrLogger
+type MakeValidOption interface {
+	setMakeValidOpt(mo *makeValidOpts)
+}
+
+// MakeValidMethod is the algorithm used by Geometry.MakeValid. Selecting a method
+// requires GDAL >= 3.4 built against GEOS >= 3.10 for MakeValidStructure.
+type MakeValidMethod string
+
+const (
+	// MakeValidLinework builds valid geometries by first extracting all lines, noding
+	// that linework together, then building a geometry output from the linework. This
+	// is the default method.
+	MakeValidLinework MakeValidMethod = "LINEWORK"
+	// MakeValidStructure first makes all rings valid, then merges shells and subtracts
+	// holes from shells to generate a valid result. It assumes that holes and shells
+	// are correctly categorized.
+	MakeValidStructure MakeValidMethod = "STRUCTURE"
+)
+
+func (mvm MakeValidMethod) setMakeValidOpt(mo *makeValidOpts) {
+	mo.options = append(mo.options, "METHOD="+string(mvm))
+}
+
+type keepCollapsedOpt struct{}
+
+// KeepCollapsed makes MakeValid with the MakeValidStructure method keep the components
+// that collapse into a lower dimensionality (e.g. a polygon collapsing to a line),
+// instead of dropping them.
+func KeepCollapsed() interface {
+	MakeValidOption
+} {
+	return keepCollapsedOpt{}
+}
+
+func (keepCollapsedOpt) setMakeValidOpt(mo *makeValidOpts) {
+	mo.options = append(mo.options, "KEEP_COLLAPSED=YES")
+}
+
+// SegmentizeOption is an option passed to Geometry.Segmentize()
+//
+// Available options are:
+//   - ErrLogger
+type SegmentizeOption interface {
+	setSegmentizeOpt(so *segmentizeOpts)
+}
+
+// SetPrecisionOption is an option passed to Geometry.SetPrecision()
+//
+// Available options are:
+//   - ErrLogger
+type SetPrecisionOption interface {
+	setSetPrecisionOpt(so *setPrecisionOpts)
+}
+
+// PointOnSurfaceOption is an option passed to Geometry.PointOnSurface()
+//
+// Available options are:
+//   - ErrLogger
+type PointOnSurfaceOption interface {
+	setPointOnSurfaceOpt(po *pointOnSurfaceOpts)
+}
+
 type setGeometryOpts struct {
 	errorHandler ErrorHandler
 }
 
-// SetGeometryOption is an option passed to Feature.SetGeometry()
+// SetGeometryOption is an option passed to Feature.SetGeometry() and
+// Feature.SetGeometryByIndex()
 //
 // Available options are:
 //   - ErrLogger
@@ -501,7 +1219,8 @@ type setFieldValueOpts struct {
 	errorHandler ErrorHandler
 }
 
-// SetFieldValueOption is an option passed to Feature.SetFieldValue()
+// SetFieldValueOption is an option passed to Feature.SetFieldValue() and the other
+// Feature.SetField* setters
 //
 // Available options are:
 //   - ErrLogger
@@ -509,17 +1228,48 @@ type SetFieldValueOption interface {
 	setSetFieldValueOpt(so *setFieldValueOpts)
 }
 
+type setFromOpts struct {
+	errorHandler ErrorHandler
+}
+
+// SetFromOption is an option passed to Feature.SetFrom()
+//
+// Available options are:
+//   - ErrLogger
+type SetFromOption interface {
+	setSetFromOpt(so *setFromOpts)
+}
+
 type vsiOpenOpts struct {
+	mode         string
 	errorHandler ErrorHandler
 }
 
 // VSIOpenOption is an option passed to VSIOpen()
 //
 // Available options are:
+//   - VSIOpenMode
 //   - ErrLogger
 type VSIOpenOption interface {
 	setVSIOpenOpt(vo *vsiOpenOpts)
 }
+
+type vsiOpenModeOpt struct {
+	mode string
+}
+
+// VSIOpenMode sets the access mode used by VSIOpen, following fopen() conventions:
+// "r" (the default), "r+", "w", "w+", "a" or "a+". A "b" suffix is accepted and ignored.
+func VSIOpenMode(mode string) interface {
+	VSIOpenOption
+} {
+	return vsiOpenModeOpt{mode}
+}
+
+func (vm vsiOpenModeOpt) setVSIOpenOpt(vo *vsiOpenOpts) {
+	vo.mode = vm.mode
+}
+
 type vsiUnlinkOpts struct {
 	errorHandler ErrorHandler
 }
@@ -532,6 +1282,61 @@ type VSIUnlinkOption interface {
 	setVSIUnlinkOpt(vo *vsiUnlinkOpts)
 }
 
+type vsiMemOpts struct {
+	errorHandler ErrorHandler
+}
+
+// VSIMemOption is an option passed to VSIMemCreate() or VSIMemSteal()
+//
+// Available options are:
+//   - ErrLogger
+type VSIMemOption interface {
+	setVSIMemOpt(vo *vsiMemOpts)
+}
+
+type pathSpecificOpts struct {
+	errorHandler ErrorHandler
+}
+
+// PathSpecificOption is an option passed to SetPathSpecificOption(), SetCredential() or
+// ClearPathSpecificOptions()
+//
+// Available options are:
+//   - ErrLogger
+type PathSpecificOption interface {
+	setPathSpecificOpt(po *pathSpecificOpts)
+}
+
+type createSOZipOpts struct {
+	options      []string
+	errorHandler ErrorHandler
+}
+
+// CreateSOZipOption is an option passed to CreateSOZip()
+//
+// Available options are:
+//   - SOZipChunkSize
+//   - ErrLogger
+type CreateSOZipOption interface {
+	setCreateSOZipOpt(so *createSOZipOpts)
+}
+
+type soZipChunkSizeOpt struct {
+	size int
+}
+
+func (scs soZipChunkSizeOpt) setCreateSOZipOpt(so *createSOZipOpts) {
+	so.options = append(so.options, "SOZIP_CHUNK_SIZE="+strconv.Itoa(scs.size))
+}
+
+// SOZipChunkSize sets the size in bytes of the chunks of the SOZip seek index.
+// Defaults to 32KB.
+func SOZipChunkSize(size int) interface {
+	CreateSOZipOption
+} {
+	return soZipChunkSizeOpt{size}
+}
+
 type geometryWKTOpts struct {
 	errorHandler ErrorHandler
 }
@@ -544,17 +1349,73 @@ type GeometryWKTOption interface {
 	setGeometryWKTOpt(o *geometryWKTOpts)
 }
 type geometryWKBOpts struct {
+	byteOrder    WKBByteOrder
+	variant      WKBVariant
 	errorHandler ErrorHandler
 }
 
-// GeometryWKBOption is an option passed to Geometry.WKB()
+// GeometryWKBOption is an option passed to Geometry.WKB() or Feature.GeometryWKB()
 //
 // Available options are:
+//   - ByteOrder
+//   - Variant
 //   - ErrLogger
 type GeometryWKBOption interface {
 	setGeometryWKBOpt(o *geometryWKBOpts)
 }
 
+// WKBByteOrder is the endianness of an exported WKB geometry
+type WKBByteOrder int
+
+const (
+	// WkbNDR exports little-endian WKB. This is the default.
+	WkbNDR WKBByteOrder = iota
+	// WkbXDR exports big-endian WKB.
+	WkbXDR
+)
+
+// WKBVariant is the flavor used to encode the geometry type of 3D and measured geometries
+// in an exported WKB
+type WKBVariant int
+
+const (
+	// WkbISO exports ISO SQL/MM WKB, where e.g. a 3D point has type 1001. This is the default.
+	WkbISO WKBVariant = iota
+	// WkbExtended exports the legacy "extended" WKB used by PostGIS and GDAL < 2.0, where
+	// the 3D flag is set with the 0x80000000 bit.
+	WkbExtended
+)
+
+type wkbByteOrderOpt struct {
+	bo WKBByteOrder
+}
+
+// ByteOrder sets the endianness of the WKB exported by Geometry.WKB()
+func ByteOrder(bo WKBByteOrder) interface {
+	GeometryWKBOption
+} {
+	return wkbByteOrderOpt{bo}
+}
+
+func (o wkbByteOrderOpt) setGeometryWKBOpt(wo *geometryWKBOpts) {
+	wo.byteOrder = o.bo
+}
+
+type wkbVariantOpt struct {
+	v WKBVariant
+}
+
+// Variant selects between ISO and extended WKB for the geometries exported by Geometry.WKB()
+func Variant(v WKBVariant) interface {
+	GeometryWKBOption
+} {
+	return wkbVariantOpt{v}
+}
+
+func (o wkbVariantOpt) setGeometryWKBOpt(wo *geometryWKBOpts) {
+	wo.variant = o.v
+}
+
 type newGeometryOpts struct {
 	errorHandler ErrorHandler
 }
@@ -579,6 +1440,127 @@ type UpdateFeatureOption interface {
 	setUpdateFeatureOpt(o *updateFeatureOpts)
 }
 
+type alterFieldDefnOpts struct {
+	errorHandler ErrorHandler
+}
+
+// AlterFieldDefnOption is an option passed to Layer.AlterFieldDefn()
+//
+// Available options are:
+//   - ErrLogger
+type AlterFieldDefnOption interface {
+	setAlterFieldDefnOpt(o *alterFieldDefnOpts)
+}
+
+type fieldDefinitionOpts struct {
+	width        int
+	precision    int
+	nullable     *bool
+	defaultValue *string
+}
+
+// FieldDefinitionOption is an option passed to NewFieldDefinition()
+//
+// Available options are:
+//   - FieldWidth
+//   - FieldPrecision
+//   - FieldNullable
+//   - FieldDefault
+type FieldDefinitionOption interface {
+	setFieldDefinitionOpt(o *fieldDefinitionOpts)
+}
+
+type fieldWidthOpt struct {
+	w int
+}
+
+// FieldWidth sets the width of a field, e.g. the maximum number of characters of a
+// string field, or the total number of digits of a numeric field.
+func FieldWidth(w int) interface {
+	FieldDefinitionOption
+} {
+	return fieldWidthOpt{w}
+}
+
+func (fwo fieldWidthOpt) setFieldDefinitionOpt(o *fieldDefinitionOpts) {
+	o.width = fwo.w
+}
+
+type fieldPrecisionOpt struct {
+	p int
+}
+
+// FieldPrecision sets the number of digits after the decimal point of a real field.
+func FieldPrecision(p int) interface {
+	FieldDefinitionOption
+} {
+	return fieldPrecisionOpt{p}
+}
+
+func (fpo fieldPrecisionOpt) setFieldDefinitionOpt(o *fieldDefinitionOpts) {
+	o.precision = fpo.p
+}
+
+type fieldNullableOpt struct {
+	nullable bool
+}
+
+// FieldNullable sets whether the field may contain null values (the default). Drivers
+// not supporting NOT NULL constraints ignore it.
+func FieldNullable(nullable bool) interface {
+	FieldDefinitionOption
+} {
+	return fieldNullableOpt{nullable}
+}
+
+func (fno fieldNullableOpt) setFieldDefinitionOpt(o *fieldDefinitionOpts) {
+	nullable := fno.nullable
+	o.nullable = &nullable
+}
+
+type fieldDefaultOpt struct {
+	def string
+}
+
+// FieldDefault sets the default value of the field. def must be formatted as an SQL
+// literal, i.e. string values must be single-quoted (e.g. "'foo'"), whereas numeric
+// values are not (e.g. "12.5"). CURRENT_TIMESTAMP, CURRENT_DATE and CURRENT_TIME are
+// also accepted.
+func FieldDefault(def string) interface {
+	FieldDefinitionOption
+} {
+	return fieldDefaultOpt{def}
+}
+
+func (fdo fieldDefaultOpt) setFieldDefinitionOpt(o *fieldDefinitionOpts) {
+	def := fdo.def
+	o.defaultValue = &def
+}
+
+type createFieldOpts struct {
+	errorHandler ErrorHandler
+}
+
+// CreateFieldOption is an option passed to Layer.CreateField()
+//
+// Available options are:
+//   - ErrLogger
+type CreateFieldOption interface {
+	setCreateFieldOpt(o *createFieldOpts)
+}
+
+type deleteFieldOpts struct {
+	errorHandler ErrorHandler
+}
+
+// DeleteFieldOption is an option passed to Layer.DeleteField()
+//
+// Available options are:
+//   - ErrLogger
+type DeleteFieldOption interface {
+	setDeleteFieldOpt(o *deleteFieldOpts)
+}
+
 type deleteFeatureOpts struct {
 	errorHandler ErrorHandler
 }
@@ -614,6 +1596,17 @@ type geometryTransformOpts struct {
 type GeometryTransformOption interface {
 	setGeometryTransformOpt(o *geometryTransformOpts)
 }
+type areaInCRSOpts struct {
+	errorHandler ErrorHandler
+}
+
+// AreaInCRSOption is an option passed to Geometry.AreaInCRS()
+//
+// Available options are:
+//   - ErrLogger
+type AreaInCRSOption interface {
+	setAreaInCRSOpt(o *areaInCRSOpts)
+}
 type geometryReprojectOpts struct {
 	errorHandler ErrorHandler
 }
@@ -644,9 +1637,14 @@ type siblingFilesOpt struct {
 // reading the directory content and/or probing for well-known sidecar filenames will be used.
 func SiblingFiles(files ...string) interface {
 	OpenOption
+	IdentifyDriverOption
+	NewReadViewOption
 } {
 	return siblingFilesOpt{files}
 }
+func (sf siblingFilesOpt) setNewReadViewOpt(o *newReadViewOpts) {
+	o.open = append(o.open, sf)
+}
 func (sf siblingFilesOpt) setOpenOpt(oo *openOpts) {
 	if len(sf.files) > 0 {
 		oo.siblingFiles = append(oo.siblingFiles, sf.files...)
@@ -654,6 +1652,13 @@ func (sf siblingFilesOpt) setOpenOpt(oo *openOpts) {
 		oo.siblingFiles = nil
 	}
 }
+func (sf siblingFilesOpt) setIdentifyDriverOpt(ido *identifyDriverOpts) {
+	if len(sf.files) > 0 {
+		ido.siblingFiles = append(ido.siblingFiles, sf.files...)
+	} else {
+		ido.siblingFiles = nil
+	}
+}
 
 type setDescriptionOpts struct {
 	errorHandler ErrorHandler
@@ -705,6 +1710,7 @@ func Bands(bnds ...int) interface {
 	BuildOverviewsOption
 	RasterizeGeometryOption
 	BuildVRTOption
+	ProcessBlocksOption
 } {
 	ib := make([]int, len(bnds))
 	for i := range bnds {
@@ -725,6 +1731,9 @@ func (bo bandOpt) setRasterizeGeometryOpt(o *rasterizeGeometryOpts) {
 func (bo bandOpt) setBuildVRTOpt(bvo *buildVRTOpts) {
 	bvo.bands = bo.bnds
 }
+func (bo bandOpt) setProcessBlocksOpt(po *processBlocksOpts) {
+	po.bands = bo.bnds
+}
 
 type bandSpacingOpt struct {
 	sp int
@@ -859,6 +1868,40 @@ func (wo windowOpt) setBandIOOpt(ro *bandIOOpts) {
 	ro.dsHeight = wo.sy
 }
 
+type srcWindowFOpt struct {
+	win [4]float64
+}
+
+// SourceWindowF specifies a floating point source window of w*h pixels starting at x,y,
+// for precise sub-pixel resampling when reading a window into a buffer of a different
+// size (e.g. for seamless tile generation). When set, the srcX and srcY arguments and the
+// Window option of the IO call are ignored, and replaced by the smallest integer window
+// containing the floating point one.
+func SourceWindowF(x, y, w, h float64) interface {
+	DatasetIOOption
+	BandIOOption
+} {
+	return srcWindowFOpt{[4]float64{x, y, w, h}}
+}
+
+func (swo srcWindowFOpt) setDatasetIOOpt(ro *datasetIOOpts) {
+	win := swo.win
+	ro.srcWindowF = &win
+}
+func (swo srcWindowFOpt) setBandIOOpt(ro *bandIOOpts) {
+	win := swo.win
+	ro.srcWindowF = &win
+}
+
+// integerWindow returns the smallest integer window containing the floating point window win
+func integerWindow(win [4]float64) (x, y, w, h int) {
+	x = int(math.Floor(win[0]))
+	y = int(math.Floor(win[1]))
+	w = int(math.Ceil(win[0]+win[2])) - x
+	h = int(math.Ceil(win[1]+win[3])) - y
+	return
+}
+
 type bandInterleaveOp struct{}
 
 // BandInterleaved makes Read return a band interleaved buffer instead of a pixel interleaved one.
@@ -871,6 +1914,7 @@ type bandInterleaveOp struct{}
 // BandStride, LineStride, or PixelStride
 func BandInterleaved() interface {
 	DatasetIOOption
+	ProcessBlocksOption
 } {
 	return bandInterleaveOp{}
 }
@@ -878,6 +1922,9 @@ func BandInterleaved() interface {
 func (bio bandInterleaveOp) setDatasetIOOpt(ro *datasetIOOpts) {
 	ro.bandInterleave = true
 }
+func (bio bandInterleaveOp) setProcessBlocksOpt(po *processBlocksOpts) {
+	po.bandInterleave = true
+}
 
 type creationOpt struct {
 	creation []string
@@ -894,6 +1941,9 @@ func CreationOption(opts ...string) interface {
 	DatasetVectorTranslateOption
 	GMLExportOption
 	RasterizeOption
+	RetileOption
+	AddBandOption
+	DemOption
 } {
 	return creationOpt{opts}
 }
@@ -901,6 +1951,9 @@ func CreationOption(opts ...string) interface {
 func (co creationOpt) setDatasetCreateOpt(dc *dsCreateOpts) {
 	dc.creation = append(dc.creation, co.creation...)
 }
+func (co creationOpt) setAddBandOpt(abo *addBandOpts) {
+	abo.creation = append(abo.creation, co.creation...)
+}
 func (co creationOpt) setDatasetWarpOpt(dc *dsWarpOpts) {
 	dc.creation = append(dc.creation, co.creation...)
 }
@@ -916,6 +1969,12 @@ func (co creationOpt) setGMLExportOpt(gmlo *gmlExportOpts) {
 func (co creationOpt) setRasterizeOpt(o *rasterizeOpts) {
 	o.create = append(o.create, co.creation...)
 }
+func (co creationOpt) setDemOpt(o *demOpts) {
+	o.creation = append(o.creation, co.creation...)
+}
+func (co creationOpt) setRetileOpt(o *retileOpts) {
+	o.creation = append(o.creation, co.creation...)
+}
 
 type configOpt struct {
 	config []string
@@ -940,6 +1999,16 @@ func ConfigOption(cfgs ...string) interface {
 	DatasetIOOption
 	BandIOOption
 	BuildVRTOption
+	RetileOption
+	SplitBandsOption
+	ExpandPaletteOption
+	AddBandOption
+	MultiDimInfoOption
+	SetNoDataFromMaskOption
+	IdentifyDriverOption
+	ProcessBlocksOption
+	NewReadViewOption
+	ReadContextOption
 	errorAndLoggingOption
 } {
 	return configOpt{cfgs}
@@ -987,6 +2056,36 @@ func (co configOpt) setBandIOOpt(oo *bandIOOpts) {
 func (co configOpt) setBuildVRTOpt(bvo *buildVRTOpts) {
 	bvo.config = append(bvo.config, co.config...)
 }
+func (co configOpt) setRetileOpt(ro *retileOpts) {
+	ro.config = append(ro.config, co.config...)
+}
+func (co configOpt) setSplitBandsOpt(so *splitBandsOpts) {
+	so.config = append(so.config, co.config...)
+}
+func (co configOpt) setExpandPaletteOpt(eo *expandPaletteOpts) {
+	eo.config = append(eo.config, co.config...)
+}
+func (co configOpt) setAddBandOpt(abo *addBandOpts) {
+	abo.config = append(abo.config, co.config...)
+}
+func (co configOpt) setMultiDimInfoOpt(mo *multiDimInfoOpts) {
+	mo.config = append(mo.config, co.config...)
+}
+func (co configOpt) setSetNoDataFromMaskOpt(so *setNoDataFromMaskOpts) {
+	so.config = append(so.config, co.config...)
+}
+func (co configOpt) setIdentifyDriverOpt(ido *identifyDriverOpts) {
+	ido.config = append(ido.config, co.config...)
+}
+func (co configOpt) setProcessBlocksOpt(po *processBlocksOpts) {
+	po.config = append(po.config, co.config...)
+}
+func (co configOpt) setNewReadViewOpt(o *newReadViewOpts) {
+	o.open = append(o.open, co)
+}
+func (co configOpt) setReadContextOpt(o *readContextOpts) {
+	o.config = append(o.config, co.config...)
+}
 func (co configOpt) setErrorAndLoggingOpt(elo *errorAndLoggingOpts) {
 	elo.config = append(elo.config, co.config...)
 }
@@ -1021,6 +2120,8 @@ func Resampling(alg ResamplingAlg) interface {
 	DatasetIOOption
 	BandIOOption
 	BuildVRTOption
+	DatasetWarpOption
+	DatasetWarpIntoOption
 } {
 	return resamplingOpt{alg}
 }
@@ -1033,8 +2134,74 @@ func (ro resamplingOpt) setDatasetIOOpt(io *datasetIOOpts) {
 func (ro resamplingOpt) setBandIOOpt(io *bandIOOpts) {
 	io.resampling = ro.m
 }
-func (ro resamplingOpt) setBuildVRTOpt(bvo *buildVRTOpts) {
-	bvo.resampling = ro.m
+func (ro resamplingOpt) setBuildVRTOpt(bvo *buildVRTOpts) {
+	bvo.resampling = ro.m
+}
+func (ro resamplingOpt) setDatasetWarpOpt(o *dsWarpOpts) {
+	m := ro.m
+	o.resampling = &m
+}
+func (ro resamplingOpt) setDatasetWarpIntoOpt(o *dsWarpIntoOpts) {
+	m := ro.m
+	o.resampling = &m
+}
+
+type externalOpt struct{}
+
+// External makes BuildOverviews write the overviews to an external .ovr sidecar file,
+// even for formats that support internal overviews (e.g. GTiff). The main file is left
+// untouched, as the overviews are built on a read-only handle to the dataset.
+//
+// The overviews will not be visible through the original dataset handle, which must be
+// closed and reopened to access them.
+//
+// The block size of the generated .ovr file is controlled by the GDAL_TIFF_OVR_BLOCKSIZE
+// configuration option (defaults to 128), which can be set with ConfigOption.
+func External() interface {
+	BuildOverviewsOption
+} {
+	return externalOpt{}
+}
+
+func (eo externalOpt) setBuildOverviewsOpt(bo *buildOvrOpts) {
+	bo.external = true
+}
+
+type keepExistingOpt struct{}
+
+// KeepExisting makes BuildOverviews skip the requested levels for which an overview
+// already exists, so that only the missing levels are computed. Existing levels are
+// detected by inspecting the overviews of the first band.
+func KeepExisting() interface {
+	BuildOverviewsOption
+} {
+	return keepExistingOpt{}
+}
+
+func (ke keepExistingOpt) setBuildOverviewsOpt(bo *buildOvrOpts) {
+	bo.keepExisting = true
+}
+
+type maskResamplingOpt struct {
+	m ResamplingAlg
+}
+
+// MaskResampling sets the resampling algorithm used to compute the overviews of the
+// bands' mask, independently of the algorithm used for the data (as set by Resampling).
+// Using Nearest avoids the partially transparent pixels that appear along mask edges
+// when the masks are averaged.
+//
+// Only masks that are stored alongside the dataset are affected, i.e. not those derived
+// from nodata values or alpha bands.
+func MaskResampling(alg ResamplingAlg) interface {
+	BuildOverviewsOption
+} {
+	return maskResamplingOpt{alg}
+}
+
+func (mro maskResamplingOpt) setBuildOverviewsOpt(bo *buildOvrOpts) {
+	alg := mro.m
+	bo.maskResample = &alg
 }
 
 type levelsOpt struct {
@@ -1113,14 +2280,307 @@ func (mdo maxDistanceOpt) setFillnodataOpt(o *fillnodataOpts) {
 	o.maxDistance = int(mdo.d)
 }
 
+func (mdo maxDistanceOpt) setProximityOpt(o *proximityOpts) {
+	o.maxDistance = &mdo.d
+}
+
 // MaxDistance is an option that can be passed to Band.FillNoData which sets the maximum number of
 // pixels to search in all directions to find values to interpolate from.
+//
+// When passed to Band.ProximityRaster, it sets the maximum distance to search for target pixels.
+// Pixels further away are set to the nodata value. The distance is expressed in the units set by
+// DistanceUnits.
 func MaxDistance(d float64) interface {
 	FillNoDataOption
+	ProximityOption
 } {
 	return maxDistanceOpt{d}
 }
 
+type checksumOpts struct {
+	errorHandler ErrorHandler
+}
+
+// ChecksumOption is an option that can be passed to Band.Checksum
+//
+// Available ChecksumOptions are:
+//   - ErrLogger
+type ChecksumOption interface {
+	setChecksumOpt(co *checksumOpts)
+}
+
+type proximityOpts struct {
+	maxDistance  *float64
+	units        DistanceUnit
+	nodata       *float64
+	targets      []float64
+	fixedBuffer  *float64
+	errorHandler ErrorHandler
+}
+
+func (po proximityOpts) options() []string {
+	var options []string
+	if po.units == GeoDistance {
+		options = append(options, "DISTUNITS=GEO")
+	} else {
+		options = append(options, "DISTUNITS=PIXEL")
+	}
+	if po.maxDistance != nil {
+		options = append(options, "MAXDIST="+strconv.FormatFloat(*po.maxDistance, 'g', -1, 64))
+	}
+	if po.nodata != nil {
+		options = append(options, "NODATA="+strconv.FormatFloat(*po.nodata, 'g', -1, 64))
+	}
+	if po.fixedBuffer != nil {
+		options = append(options, "FIXED_BUF_VAL="+strconv.FormatFloat(*po.fixedBuffer, 'g', -1, 64))
+	}
+	if len(po.targets) > 0 {
+		svals := make([]string, len(po.targets))
+		for i, v := range po.targets {
+			svals[i] = strconv.FormatFloat(v, 'g', -1, 64)
+		}
+		options = append(options, "VALUES="+strings.Join(svals, ","))
+	}
+	return options
+}
+
+// ProximityOption is an option that can be passed to Band.ProximityRaster and
+// Band.ComputeProximity
+//
+// Available ProximityOptions are:
+//   - MaxDistance
+//   - DistanceUnits
+//   - ProximityNoData
+//   - TargetValues
+//   - FixedBufferValue
+//   - ErrLogger
+type ProximityOption interface {
+	setProximityOpt(po *proximityOpts)
+}
+
+// DistanceUnit is the unit used to express proximity distances
+type DistanceUnit int
+
+const (
+	// PixelDistance expresses distances in pixels
+	PixelDistance DistanceUnit = iota
+	// GeoDistance expresses distances in georeferenced units, as given by the geotransform
+	GeoDistance
+)
+
+type distanceUnitsOpt struct {
+	u DistanceUnit
+}
+
+func (duo distanceUnitsOpt) setProximityOpt(o *proximityOpts) {
+	o.units = duo.u
+}
+
+// DistanceUnits sets the units of the computed proximity distances (and of MaxDistance).
+// Defaults to PixelDistance.
+func DistanceUnits(u DistanceUnit) interface {
+	ProximityOption
+} {
+	return distanceUnitsOpt{u}
+}
+
+type targetValuesOpt struct {
+	vals []float64
+}
+
+func (tvo targetValuesOpt) setProximityOpt(o *proximityOpts) {
+	o.targets = tvo.vals
+}
+
+// TargetValues sets the values of the pixels from which distances are computed. If
+// not set, all non-zero pixels are considered as targets.
+func TargetValues(vals ...float64) interface {
+	ProximityOption
+} {
+	return targetValuesOpt{vals}
+}
+
+type fixedBufferValueOpt struct {
+	v float64
+}
+
+func (fbo fixedBufferValueOpt) setProximityOpt(o *proximityOpts) {
+	o.fixedBuffer = &fbo.v
+}
+
+// FixedBufferValue makes pixels within MaxDistance of a target pixel be set to v
+// instead of their actual distance, i.e. it produces a fixed value buffer around the
+// targets.
+func FixedBufferValue(v float64) interface {
+	ProximityOption
+} {
+	return fixedBufferValueOpt{v}
+}
+
+type proximityNoDataOpt struct {
+	nd float64
+}
+
+func (ndo proximityNoDataOpt) setProximityOpt(o *proximityOpts) {
+	o.nodata = &ndo.nd
+}
+
+// ProximityNoData sets the value written by ProximityRaster and ComputeProximity to
+// pixels that are further away than MaxDistance from any target pixel.
+func ProximityNoData(nd float64) interface {
+	ProximityOption
+} {
+	return proximityNoDataOpt{nd}
+}
+
+type contourNoDataOpt struct {
+	nd float64
+}
+
+func (ndo contourNoDataOpt) setContourOpt(o *contourOpts) {
+	o.nodata = &ndo.nd
+}
+
+// ContourNoData sets the value of the pixels to be ignored by Contour.
+func ContourNoData(nd float64) interface {
+	ContourOption
+} {
+	return contourNoDataOpt{nd}
+}
+
+type contourOpts struct {
+	interval     float64
+	base         float64
+	fixedLevels  []float64
+	elevField    int
+	idField      int
+	nodata       *float64
+	errorHandler ErrorHandler
+}
+
+// ContourOption is an option that can be passed to Band.Contour
+//
+// Available ContourOptions are:
+//   - ContourInterval
+//   - ContourBase
+//   - FixedLevels
+//   - ElevationField
+//   - IDField
+//   - ContourNoData
+//   - ErrLogger
+type ContourOption interface {
+	setContourOpt(co *contourOpts)
+}
+
+func (co contourOpts) options() []string {
+	ftoa := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	options := []string{}
+	if co.interval != 0 {
+		options = append(options, "LEVEL_INTERVAL="+ftoa(co.interval))
+	}
+	if co.base != 0 {
+		options = append(options, "LEVEL_BASE="+ftoa(co.base))
+	}
+	if len(co.fixedLevels) > 0 {
+		slevels := make([]string, len(co.fixedLevels))
+		for i, l := range co.fixedLevels {
+			slevels[i] = ftoa(l)
+		}
+		options = append(options, "FIXED_LEVELS="+strings.Join(slevels, ","))
+	}
+	if co.elevField >= 0 {
+		options = append(options, "ELEV_FIELD="+strconv.Itoa(co.elevField))
+	}
+	if co.idField >= 0 {
+		options = append(options, "ID_FIELD="+strconv.Itoa(co.idField))
+	}
+	if co.nodata != nil {
+		options = append(options, "NODATA="+ftoa(*co.nodata))
+	}
+	return options
+}
+
+type contourIntervalOpt struct {
+	i float64
+}
+
+func (cio contourIntervalOpt) setContourOpt(o *contourOpts) {
+	o.interval = cio.i
+}
+
+// ContourInterval sets the elevation interval between generated contours
+func ContourInterval(interval float64) interface {
+	ContourOption
+} {
+	return contourIntervalOpt{interval}
+}
+
+type contourBaseOpt struct {
+	b float64
+}
+
+func (cbo contourBaseOpt) setContourOpt(o *contourOpts) {
+	o.base = cbo.b
+}
+
+// ContourBase sets the elevation relative to which the ContourInterval levels are
+// computed. Defaults to 0.
+func ContourBase(base float64) interface {
+	ContourOption
+} {
+	return contourBaseOpt{base}
+}
+
+type fixedLevelsOpt struct {
+	levels []float64
+}
+
+func (flo fixedLevelsOpt) setContourOpt(o *contourOpts) {
+	o.fixedLevels = flo.levels
+}
+
+// FixedLevels makes Contour generate contours at the given elevations only. Fixed levels
+// replace the ones that would be obtained from ContourInterval and ContourBase.
+func FixedLevels(levels ...float64) interface {
+	ContourOption
+} {
+	return fixedLevelsOpt{levels}
+}
+
+type elevationFieldOpt struct {
+	idx int
+}
+
+func (efo elevationFieldOpt) setContourOpt(o *contourOpts) {
+	o.elevField = efo.idx
+}
+
+// ElevationField makes Contour write the elevation of each contour in the fieldIndex'th
+// field of the destination layer, which must be of a numeric type.
+func ElevationField(fieldIndex int) interface {
+	ContourOption
+} {
+	return elevationFieldOpt{fieldIndex}
+}
+
+type idFieldOpt struct {
+	idx int
+}
+
+func (ifo idFieldOpt) setContourOpt(o *contourOpts) {
+	o.idField = ifo.idx
+}
+
+// IDField makes Contour write a unique identifier of each contour in the fieldIndex'th
+// field of the destination layer, which must be of an integer type.
+func IDField(fieldIndex int) interface {
+	ContourOption
+} {
+	return idFieldOpt{fieldIndex}
+}
+
 type smoothingIterationsOpt struct {
 	it int
 }
@@ -1153,10 +2613,48 @@ func PixelValueFieldIndex(fld int) interface {
 	return polyPixField{fld}
 }
 
+type polyPixFieldName struct {
+	name string
+}
+
+func (ppf polyPixFieldName) setPolygonizeOpt(o *polygonizeOpts) {
+	o.pixFieldName = ppf.name
+}
+
+// PixelValueFieldName makes Polygonize write the polygon's pixel value into the
+// layer's field named name. The field index is looked up in the destination layer's
+// schema when Polygonize is called, and an error is returned if it does not exist.
+// It takes precedence over PixelValueFieldIndex.
+func PixelValueFieldName(name string) interface {
+	PolygonizeOption
+} {
+	return polyPixFieldName{name}
+}
+
+type connectednessOpt struct {
+	n int
+}
+
+func (co connectednessOpt) setPolygonizeOpt(o *polygonizeOpts) {
+	o.connectedness = co.n
+}
+func (co connectednessOpt) setSieveFilterOpt(sfo *sieveFilterOpts) {
+	sfo.connectedness = co.n
+}
+
+// Connectedness sets the pixel connectivity used by Polygonize and SieveFilter, which
+// must be either 4 (the default) or 8. Connectedness(8) is equivalent to EightConnected().
+func Connectedness(n int) interface {
+	PolygonizeOption
+	SieveFilterOption
+} {
+	return connectednessOpt{n}
+}
+
 type eightConnected struct{}
 
 func (ec eightConnected) setPolygonizeOpt(o *polygonizeOpts) {
-	o.options = append(o.options, "8connected=yes")
+	o.connectedness = 8
 }
 func (ec eightConnected) setSieveFilterOpt(sfo *sieveFilterOpts) {
 	sfo.connectedness = 8
@@ -1199,6 +2697,18 @@ type SpatialRefValidateOption interface {
 	setSpatialRefValidateOpt(o *spatialRefValidateOpts)
 }
 
+type findMatchesOpts struct {
+	errorHandler ErrorHandler
+}
+
+// FindMatchesOption is an option that can be passed to SpatialRef.FindMatches()
+//
+// Available FindMatchesOptions are:
+//   - ErrLogger
+type FindMatchesOption interface {
+	setFindMatchesOpt(o *findMatchesOpts)
+}
+
 type rasterizeOpts struct {
 	create       []string
 	config       []string
@@ -1268,10 +2778,18 @@ type NearblackOption interface {
 }
 
 type demOpts struct {
+	driver       DriverName
+	creation     []string
 	errorHandler ErrorHandler
 }
 
-// DemOption is an option that can be passed to Dataset.Dem()
+// DemOption is an option that can be passed to Dataset.Dem() and to the typed DEM
+// helpers (Hillshade, Slope, Aspect, ColorRelief, TRI, TPI and Roughness)
+//
+// Available DemOptions are:
+//   - DriverName
+//   - CreationOption
+//   - ErrLogger
 type DemOption interface {
 	setDemOpt(demOpt *demOpts)
 }
@@ -1287,6 +2805,42 @@ type SetGCPsOption interface {
 	setSetGCPsOpt(sgOpt *setGCPsOpts)
 }
 
+type driverDeleteOpts struct {
+	errorHandler ErrorHandler
+}
+
+// DriverDeleteOption is an option that can be passed to Driver.Delete()
+//
+// Available DriverDeleteOptions are:
+//   - ErrLogger
+type DriverDeleteOption interface {
+	setDriverDeleteOpt(ddOpt *driverDeleteOpts)
+}
+
+type driverRenameOpts struct {
+	errorHandler ErrorHandler
+}
+
+// DriverRenameOption is an option that can be passed to Driver.Rename()
+//
+// Available DriverRenameOptions are:
+//   - ErrLogger
+type DriverRenameOption interface {
+	setDriverRenameOpt(drOpt *driverRenameOpts)
+}
+
+type clearGCPsOpts struct {
+	errorHandler ErrorHandler
+}
+
+// ClearGCPsOption is an option that can be passed to Dataset.ClearGCPs()
+//
+// Available ClearGCPsOptions are:
+//   - ErrLogger
+type ClearGCPsOption interface {
+	setClearGCPsOpt(cgOpt *clearGCPsOpts)
+}
+
 type gcpsToGeoTransformOpts struct {
 	errorHandler ErrorHandler
 }
@@ -1386,6 +2940,30 @@ type CreateFeatureOption interface {
 	setCreateFeatureOpt(cfo *createFeatureOpts)
 }
 
+type renameLayerOpts struct {
+	errorHandler ErrorHandler
+}
+
+// RenameLayerOption is an option that can be passed to Dataset.RenameLayer
+//
+// Available options are:
+//   - ErrLogger
+type RenameLayerOption interface {
+	setRenameLayerOpt(ro *renameLayerOpts)
+}
+
+type createFeatureBatchOpts struct {
+	errorHandler ErrorHandler
+}
+
+// CreateFeatureBatchOption is an option that can be passed to Layer.CreateFeatureBatch
+//
+// Available options are:
+//   - ErrLogger
+type CreateFeatureBatchOption interface {
+	setCreateFeatureBatchOpt(cfo *createFeatureBatchOpts)
+}
+
 type newFeatureOpts struct {
 	errorHandler ErrorHandler
 }
@@ -1400,14 +2978,57 @@ type NewFeatureOption interface {
 
 type createLayerOpts struct {
 	fields       []*FieldDefinition
+	options      []string
 	errorHandler ErrorHandler
 }
 
 // CreateLayerOption is an option that can be passed to Dataset.CreateLayer()
+//
+// Available CreateLayerOptions are:
+//   - *FieldDefinition (see NewFieldDefinition)
+//   - LayerCreationOption
+//   - GeometryColumn
+//   - FIDColumn
+//   - ErrLogger
 type CreateLayerOption interface {
 	setCreateLayerOpt(clo *createLayerOpts)
 }
 
+type layerCreationOpt struct {
+	options []string
+}
+
+// LayerCreationOption are driver specific layer creation options passed to
+// Dataset.CreateLayer, e.g. LayerCreationOption("SPATIAL_INDEX=NO") for GeoPackage.
+// See the "Layer creation options" section of the driver's documentation.
+func LayerCreationOption(opts ...string) interface {
+	CreateLayerOption
+} {
+	return layerCreationOpt{opts}
+}
+
+func (lco layerCreationOpt) setCreateLayerOpt(o *createLayerOpts) {
+	o.options = append(o.options, lco.options...)
+}
+
+// GeometryColumn sets the name of the geometry column of the created layer. It is
+// equivalent to LayerCreationOption("GEOMETRY_NAME=name"), which is supported by most
+// database drivers (e.g. GeoPackage, PostgreSQL, SQLite).
+func GeometryColumn(name string) interface {
+	CreateLayerOption
+} {
+	return layerCreationOpt{[]string{"GEOMETRY_NAME=" + name}}
+}
+
+// FIDColumn sets the name of the FID column of the created layer. It is equivalent to
+// LayerCreationOption("FID=name"), which is supported by most database drivers (e.g.
+// GeoPackage, PostgreSQL, SQLite).
+func FIDColumn(name string) interface {
+	CreateLayerOption
+} {
+	return layerCreationOpt{[]string{"FID=" + name}}
+}
+
 type copyLayerOpts struct {
 	errorHandler ErrorHandler
 }
@@ -1460,6 +3081,7 @@ type buildVRTOpts struct {
 	openOptions  []string
 	bands        []int
 	resampling   ResamplingAlg
+	ovrLevels    []int
 	errorHandler ErrorHandler
 }
 
@@ -1470,10 +3092,119 @@ type buildVRTOpts struct {
 //   - DriverOpenOption
 //   - Bands
 //   - Resampling
+//   - VirtualOverviews
 type BuildVRTOption interface {
 	setBuildVRTOpt(bvo *buildVRTOpts)
 }
 
+type virtualOverviewsOpt struct {
+	levels []int
+}
+
+// VirtualOverviews makes BuildVRT add virtual overviews at the given levels to the
+// created VRT, using the Resampling algorithm. See Dataset.AddVirtualOverviews.
+func VirtualOverviews(levels ...int) interface {
+	BuildVRTOption
+} {
+	return virtualOverviewsOpt{levels}
+}
+func (voo virtualOverviewsOpt) setBuildVRTOpt(bvo *buildVRTOpts) {
+	bvo.ovrLevels = voo.levels
+}
+
+type addVirtualOverviewsOpts struct {
+	errorHandler ErrorHandler
+}
+
+// AddVirtualOverviewsOption is an option that can be passed to Dataset.AddVirtualOverviews
+//
+// Available AddVirtualOverviewsOptions are:
+//   - ErrLogger
+type AddVirtualOverviewsOption interface {
+	setAddVirtualOverviewsOpt(ao *addVirtualOverviewsOpts)
+}
+
+type processBlocksOpts struct {
+	bands          []int
+	bandInterleave bool
+	writeBack      bool
+	parallel       int
+	config         []string
+	errorHandler   ErrorHandler
+}
+
+// ProcessBlocksOption is an option that can be passed to Dataset.ProcessBlocks
+//
+// Available ProcessBlocksOptions are:
+//   - Bands
+//   - BandInterleaved
+//   - WriteBack
+//   - Parallel
+//   - ConfigOption
+//   - ErrLogger
+type ProcessBlocksOption interface {
+	setProcessBlocksOpt(po *processBlocksOpts)
+}
+
+type writeBackOpt struct{}
+
+// WriteBack makes ProcessBlocks write each block back to the dataset once it has been
+// processed, for in-place transforms. The dataset must have been opened in Update mode.
+func WriteBack() interface {
+	ProcessBlocksOption
+} {
+	return writeBackOpt{}
+}
+func (wbo writeBackOpt) setProcessBlocksOpt(po *processBlocksOpts) {
+	po.writeBack = true
+}
+
+type parallelOpt struct {
+	n int
+}
+
+// Parallel makes ProcessBlocks read and process n blocks concurrently, which mostly
+// benefits datasets backed by high-latency storage (e.g. /vsis3/ or a VSI handler).
+//
+// As gdal dataset handles are not thread-safe, n additional read-only handles are
+// opened on the dataset's name, which is therefore required to be reopenable (i.e.
+// in-memory datasets are not supported). Parallel cannot be combined with WriteBack.
+func Parallel(n int) interface {
+	ProcessBlocksOption
+} {
+	return parallelOpt{n}
+}
+func (po parallelOpt) setProcessBlocksOpt(o *processBlocksOpts) {
+	o.parallel = po.n
+}
+
+type newReadViewOpts struct {
+	open []OpenOption
+}
+
+// NewReadViewOption is an option that can be passed to Dataset.NewReadView
+//
+// Available NewReadViewOptions are:
+//   - SiblingFiles
+//   - DriverOpenOption
+//   - ConfigOption
+//   - ErrLogger
+type NewReadViewOption interface {
+	setNewReadViewOpt(nvo *newReadViewOpts)
+}
+
+type readContextOpts struct {
+	config []string
+}
+
+// ReadContextOption is an option that can be passed to NewReadContext
+//
+// Available ReadContextOptions are:
+//   - ConfigOption
+type ReadContextOption interface {
+	setReadContextOpt(rco *readContextOpts)
+}
+
 type vsiHandlerOpts struct {
 	bufferSize, cacheSize int
 	stripPrefix           bool
@@ -1529,6 +3260,8 @@ func VSIHandlerStripPrefix(v bool) VSIHandlerOption {
 	return stripPrefixOpt{v}
 }
 
+// SpatialFilterOption is an ExecuteSQLOption restricting the features of a ResultSet,
+// as returned by SpatialFilter
 type SpatialFilterOption struct {
 	geom *Geometry
 }
@@ -1537,7 +3270,10 @@ func (sf SpatialFilterOption) setExecuteSQLOpt(eso *executeSQLOpts) {
 	eso.spatialFilter = sf
 }
 
-// SpatialFilter filters a ResultSet using the provided Geometry
+// SpatialFilter filters a ResultSet using the provided Geometry: only the features whose
+// geometry intersects geom are returned. The geometry must be in the spatial reference of
+// the queried layer, and must not be closed before the ExecuteSQL call returns. Depending
+// on the driver, the filter may only be applied to the features' envelopes.
 func SpatialFilter(geom *Geometry) SpatialFilterOption {
 	return SpatialFilterOption{geom}
 }
@@ -1554,6 +3290,8 @@ type executeSQLOpts struct {
 // - OGRSQLDialect
 // - SQLiteDialect
 // - IndirectSQLiteDialect
+//
+// Any other dialect supported by the driver can be used directly, e.g. SQLDialect("SQLITE").
 type SQLDialect string
 
 func (s SQLDialect) setExecuteSQLOpt(eso *executeSQLOpts) {
@@ -1582,7 +3320,8 @@ func IndirectSQLiteDialect() SQLDialect {
 //
 // Available options are:
 // - SQLDialect
-// - SpatialFilterOption
+// - SpatialFilter
+// - ErrLogger
 type ExecuteSQLOption interface {
 	setExecuteSQLOpt(eso *executeSQLOpts)
 }
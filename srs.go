@@ -20,7 +20,7 @@ type srWKTOpts struct {
 	errorHandler ErrorHandler
 }
 
-//WKTExportOption is an option that can be passed to SpatialRef.WKT()
+//WKTExportOption is an option that can be passed to SpatialRef.WKT() or SpatialRef.ESRIWKT()
 //
 // Available WKTExportOptions are:
 //  - ErrLogger
@@ -28,24 +28,208 @@ type WKTExportOption interface {
 	setWKTExportOpt(sro *srWKTOpts)
 }
 
-type trnOpts struct {
+type proj4Opts struct {
+	errorHandler ErrorHandler
+}
+
+// Proj4ExportOption is an option that can be passed to SpatialRef.Proj4()
+//
+// Available Proj4ExportOptions are:
+//  - ErrLogger
+type Proj4ExportOption interface {
+	setProj4ExportOpt(po *proj4Opts)
+}
+
+type projJSONOpts struct {
+	options      []string
+	errorHandler ErrorHandler
+}
+
+// PROJJSONExportOption is an option that can be passed to SpatialRef.PROJJSON()
+//
+// Available PROJJSONExportOptions are:
+//  - SingleLine
+//  - ErrLogger
+type PROJJSONExportOption interface {
+	setPROJJSONExportOpt(po *projJSONOpts)
+}
+
+type singleLineOpt struct{}
+
+func (slo singleLineOpt) setPROJJSONExportOpt(po *projJSONOpts) {
+	po.options = append(po.options, "MULTILINE=NO")
+}
+
+// SingleLine makes SpatialRef.PROJJSON() export the PROJJSON on a single line
+// instead of the default indented multi-line output.
+func SingleLine() interface {
+	PROJJSONExportOption
+} {
+	return singleLineOpt{}
+}
+
+type datasetTransformOpts struct {
+	errorHandler ErrorHandler
+}
+
+// DatasetTransformOption is an option that can be passed to NewDatasetTransform
+//
+// Available DatasetTransformOptions are:
+//  - ErrLogger
+type DatasetTransformOption interface {
+	setDatasetTransformOpt(o *datasetTransformOpts)
+}
+
+type transformPixelsOpts struct {
+	errorHandler ErrorHandler
+}
+
+// TransformPixelsOption is an option that can be passed to DatasetTransform.Transform
+//
+// Available TransformPixelsOptions are:
+//  - ErrLogger
+type TransformPixelsOption interface {
+	setTransformPixelsOpt(o *transformPixelsOpts)
+}
+
+type pixelToGeoOpts struct {
+	transformer  []string
 	errorHandler ErrorHandler
 }
 
+// PixelToGeoOption is an option that can be passed to Dataset.PixelToGeo
+//
+// Available PixelToGeoOptions are:
+//   - RPCDEM
+//   - ErrLogger
+type PixelToGeoOption interface {
+	setPixelToGeoOpt(o *pixelToGeoOpts)
+}
+
+type geoToPixelOpts struct {
+	transformer  []string
+	errorHandler ErrorHandler
+}
+
+// GeoToPixelOption is an option that can be passed to Dataset.GeoToPixel
+//
+// Available GeoToPixelOptions are:
+//   - RPCDEM
+//   - ErrLogger
+type GeoToPixelOption interface {
+	setGeoToPixelOpt(o *geoToPixelOpts)
+}
+
+type rpcDEMOpt struct {
+	dem string
+}
+
+// RPCDEM makes PixelToGeo and GeoToPixel use the elevations of the dem raster (any
+// dataset name that can be opened by gdal) when transforming coordinates with RPCs,
+// i.e. it sets the RPC_DEM transformer option.
+func RPCDEM(dem string) interface {
+	PixelToGeoOption
+	GeoToPixelOption
+} {
+	return rpcDEMOpt{dem}
+}
+
+func (ro rpcDEMOpt) setPixelToGeoOpt(o *pixelToGeoOpts) {
+	o.transformer = append(o.transformer, "RPC_DEM="+ro.dem)
+}
+func (ro rpcDEMOpt) setGeoToPixelOpt(o *geoToPixelOpts) {
+	o.transformer = append(o.transformer, "RPC_DEM="+ro.dem)
+}
+
+type trnOpts struct {
+	areaOfInterest      *[4]float64
+	disallowBallpark    bool
+	traditionalGISOrder bool
+	errorHandler        ErrorHandler
+}
+
 // TransformOption is an option that can be passed to NewTransform
 //
 // Available TransformOptions are:
+//  - WithAreaOfInterest
+//  - DisallowBallpark
+//  - TransformTraditionalGISOrder
 //  - ErrLogger
 type TransformOption interface {
 	setTransformOpt(o *trnOpts)
 }
 
+type transformBoundsOpts struct {
+	errorHandler ErrorHandler
+}
+
+// TransformBoundsOption is an option that can be passed to Transform.TransformBounds
+//
+// Available TransformBoundsOptions are:
+//  - ErrLogger
+type TransformBoundsOption interface {
+	setTransformBoundsOpt(o *transformBoundsOpts)
+}
+
+type areaOfInterestOpt struct {
+	aoi [4]float64
+}
+
+func (aoi areaOfInterestOpt) setTransformOpt(o *trnOpts) {
+	o.areaOfInterest = &aoi.aoi
+}
+
+// WithAreaOfInterest restricts the candidate coordinate operations considered by
+// NewTransform to the ones relevant for the given area, expressed in degrees of longitude
+// and latitude. This allows selecting the most accurate datum shift for a known working area.
+// west may be greater than east for areas crossing the antimeridian.
+func WithAreaOfInterest(west, south, east, north float64) interface {
+	TransformOption
+} {
+	return areaOfInterestOpt{[4]float64{west, south, east, north}}
+}
+
+type disallowBallparkOpt struct{}
+
+func (dbo disallowBallparkOpt) setTransformOpt(o *trnOpts) {
+	o.disallowBallpark = true
+}
+
+// DisallowBallpark prevents NewTransform from silently falling back to a ballpark
+// transformation (e.g. ignoring datum shifts) when no accurate coordinate operation
+// is available. Transforming points will then fail instead.
+//
+// Requires GDAL >= 3.3
+func DisallowBallpark() interface {
+	TransformOption
+} {
+	return disallowBallparkOpt{}
+}
+
+type traditionalGISOrderOpt struct{}
+
+func (tgo traditionalGISOrderOpt) setTransformOpt(o *trnOpts) {
+	o.traditionalGISOrder = true
+}
+
+// TransformTraditionalGISOrder makes the Transform always consume and produce coordinates
+// in the traditional GIS order (i.e. longitude/latitude, easting/northing), whatever the
+// axis mapping strategy of the source and destination SpatialRefs. The SpatialRefs
+// themselves are not modified.
+func TransformTraditionalGISOrder() interface {
+	TransformOption
+} {
+	return traditionalGISOrderOpt{}
+}
+
 func (sr *SpatialRef) setBoundsOpt(o *boundsOpts) {
 	o.sr = sr
 }
 
 type boundsOpts struct {
 	sr           *SpatialRef
+	densifyPts   int
+	noForce      bool
 	errorHandler ErrorHandler
 }
 
@@ -53,12 +237,35 @@ type boundsOpts struct {
 //
 // Available options are:
 //  - *SpatialRef
+//  - DensifyBounds
+//  - ForceExtent (Layer.Bounds only)
 //  - ErrLogger
 type BoundsOption interface {
 	setBoundsOpt(o *boundsOpts)
 }
 
+type densifyBoundsOpt struct {
+	n int
+}
+
+func (dbo densifyBoundsOpt) setBoundsOpt(o *boundsOpts) {
+	o.densifyPts = dbo.n
+}
+
+// DensifyBounds makes the bounds reprojection (i.e. when a *SpatialRef is passed as
+// an option) add n points along each edge of the bounding box before transforming it,
+// instead of transforming only the 4 corners. This gives accurate results when the edges
+// are curved in the target projection.
+//
+// Requires GDAL >= 3.4, older versions fall back to transforming only the 4 corners.
+func DensifyBounds(n int) interface {
+	BoundsOption
+} {
+	return densifyBoundsOpt{n}
+}
+
 type createSpatialRefOpts struct {
+	allowNetwork bool
 	errorHandler ErrorHandler
 }
 
@@ -66,18 +273,41 @@ type createSpatialRefOpts struct {
 // reference object
 //
 // Available options are:
+//  - AllowNetworkAccess (for NewSpatialRefFromCRSURL)
 //  - ErrLogger
 type CreateSpatialRefOption interface {
 	setCreateSpatialRefOpt(so *createSpatialRefOpts)
 }
 
-func reprojectBounds(bnds [4]float64, src, dst *SpatialRef) ([4]float64, error) {
+type allowNetworkAccessOpt struct{}
+
+// AllowNetworkAccess lets NewSpatialRefFromCRSURL fetch the definition of CRS URLs
+// that cannot be resolved locally, i.e. those that are not OGC CRS URIs
+// (http://www.opengis.net/def/crs/...) or URNs (urn:ogc:def:crs:...).
+func AllowNetworkAccess() interface {
+	CreateSpatialRefOption
+} {
+	return allowNetworkAccessOpt{}
+}
+
+func (ano allowNetworkAccessOpt) setCreateSpatialRefOpt(so *createSpatialRefOpts) {
+	so.allowNetwork = true
+}
+
+func reprojectBounds(bnds [4]float64, src, dst *SpatialRef, densifyPts int) ([4]float64, error) {
 	var ret [4]float64
 	trn, err := NewTransform(src, dst)
 	if err != nil {
 		return ret, fmt.Errorf("create coordinate transform: %w", err)
 	}
 	defer trn.Close()
+	if densifyPts > 0 && CheckMinVersion(3, 4, 0) {
+		ret, err = trn.TransformBounds(bnds, densifyPts)
+		if err != nil {
+			return ret, fmt.Errorf("reproject bounds: %w", err)
+		}
+		return ret, nil
+	}
 	x := []float64{bnds[0], bnds[0], bnds[2], bnds[2]}
 	y := []float64{bnds[1], bnds[3], bnds[3], bnds[1]}
 	err = trn.TransformEx(x, y, nil, nil)